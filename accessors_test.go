@@ -0,0 +1,35 @@
+package discogs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessors_NilReceiver(t *testing.T) {
+	var release *discogs.ReleaseResponse
+
+	assert.Equal(t, int64(0), release.GetYear())
+	assert.Equal(t, discogs.Amount(0), release.GetLowestPrice())
+	assert.Equal(t, discogs.Timestamp{}, release.GetDateAdded())
+	assert.Nil(t, release.GetCommunity())
+	assert.Equal(t, float64(0), release.GetCommunity().GetRating().GetAverage())
+}
+
+func TestAccessors_SetFields(t *testing.T) {
+	added := discogs.Timestamp{Time: time.Date(2013, 1, 3, 20, 37, 51, 0, time.UTC)}
+	release := &discogs.ReleaseResponse{
+		Year:      discogs.Int64(1999),
+		DateAdded: &added,
+		Community: &discogs.ReleaseCommunity{
+			Rating: &discogs.CommunityRating{Average: discogs.Float64(4.5)},
+		},
+	}
+
+	assert.Equal(t, int64(1999), release.GetYear())
+	assert.Equal(t, added, release.GetDateAdded())
+	assert.Equal(t, 4.5, release.GetCommunity().GetRating().GetAverage())
+	assert.Equal(t, int64(0), release.GetCommunity().GetRating().GetCount())
+}
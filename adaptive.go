@@ -0,0 +1,127 @@
+package discogs
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// adaptiveDecreaseFactor is the factor the rate is multiplied by when the API signals that requests should slow down.
+	adaptiveDecreaseFactor = 0.5
+	// adaptiveIncrease is the number of requests per minute the rate is increased by after a response that did not
+	// signal that requests should slow down.
+	adaptiveIncrease = 1
+	// adaptiveRemainingThreshold is the fraction of the rate limit below which the remaining requests in the current
+	// window are considered nearly exhausted.
+	adaptiveRemainingThreshold = 0.1
+	// adaptiveMinRate is the minimum rate in requests per minute the adaptive rate limit backs off to.
+	adaptiveMinRate = 1
+	// paceSlowdownThreshold is the fraction of the rate limit below which the remaining requests in the current window
+	// are paced out more slowly.
+	paceSlowdownThreshold = 0.2
+)
+
+// adaptRateLimit adjusts the rate limiter of the host that sent res using additive increase/multiplicative decrease
+// (AIMD). The rate is decreased multiplicatively on a 429 response or when the remaining requests in the current
+// window are nearly exhausted, and increased additively otherwise. The rate never exceeds the ceiling given by the
+// X-Discogs-Ratelimit header and MaxRequests, lowered while the host warms up.
+func (dc *DiscogsClient) adaptRateLimit(res *http.Response) {
+	limiter := dc.hostLimiter()
+	if res.Request != nil {
+		limiter = dc.limiterFor(res.Request.URL)
+	}
+	// Limiters that do not report their limit cannot be adapted
+	state, ok := limiter.(limiterState)
+	if !ok || state.Limit() == rate.Inf {
+		return
+	}
+
+	var update rateLimitUpdate
+	defer func() { dc.reportRateLimitUpdates(update) }()
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	ceiling := dc.warmUpCeiling(res, float64(dc.rateLimitCeiling(res)))
+
+	throttled := res.StatusCode == http.StatusTooManyRequests
+	if remaining, err := strconv.Atoi(res.Header.Get(RateLimitRemainingHeader)); err == nil {
+		throttled = throttled || float64(remaining) <= ceiling*adaptiveRemainingThreshold
+	}
+
+	current := float64(state.Limit()) * time.Minute.Seconds()
+
+	var next float64
+	if throttled {
+		next = max(current*adaptiveDecreaseFactor, adaptiveMinRate)
+	} else {
+		next = min(current+adaptiveIncrease, ceiling)
+	}
+
+	update = setLimit(limiter, rate.Limit(next/time.Minute.Seconds()), max(int(next), 1))
+}
+
+// rateLimitCeiling returns the maximum number of requests per minute allowed for the host that sent res. It is the
+// minimum of the X-Discogs-Ratelimit header and MaxRequests, falling back to the default Discogs API limit for the
+// configured credentials if neither is known. The caller must hold dc.mu.
+func (dc *DiscogsClient) rateLimitCeiling(res *http.Response) int {
+	ceiling, err := strconv.Atoi(res.Header.Get(RateLimitHeader))
+	if err != nil || ceiling <= 0 {
+		if dc.Config.MaxRequests > 0 {
+			return dc.Config.MaxRequests
+		}
+		if dc.Config.CredentialProvider != nil || dc.Config.ConsumerKey != nil && dc.Config.ConsumerSecret != nil {
+			return RateLimitAuth
+		}
+		return RateLimitUnauth
+	}
+
+	if dc.Config.MaxRequests > 0 {
+		return min(ceiling, dc.Config.MaxRequests)
+	}
+	return ceiling
+}
+
+// paceRateLimit adjusts the rate limiter of the host that sent res to the requests remaining in the current window,
+// as reported by the X-Discogs-Ratelimit-Remaining header. While plenty of requests remain, the full rate is allowed,
+// with bursts of up to the remaining requests, so bulk jobs use the whole quota. Once fewer than a fifth of the rate
+// limit remain, the rate slows down in proportion, and recovers as soon as requests leave the moving window. A 429
+// response counts as no request remaining. Responses without the header adjust the rate limiter like the
+// X-Discogs-Ratelimit header does without pacing.
+func (dc *DiscogsClient) paceRateLimit(res *http.Response) {
+	remaining, err := strconv.Atoi(res.Header.Get(RateLimitRemainingHeader))
+	if res.StatusCode == http.StatusTooManyRequests {
+		remaining, err = 0, nil
+	}
+	if err != nil {
+		dc.updateRateLimitFromHeader(res)
+		return
+	}
+
+	limiter := dc.hostLimiter()
+	if res.Request != nil {
+		limiter = dc.limiterFor(res.Request.URL)
+	}
+	if limiterLimit(limiter) == rate.Inf {
+		return
+	}
+
+	var update rateLimitUpdate
+	defer func() { dc.reportRateLimitUpdates(update) }()
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	ceiling := dc.warmUpCeiling(res, float64(dc.rateLimitCeiling(res)))
+	threshold := ceiling * paceSlowdownThreshold
+
+	next := ceiling
+	if float64(remaining) < threshold {
+		next = max(ceiling*float64(remaining)/threshold, adaptiveMinRate)
+	}
+
+	update = setLimit(limiter, rate.Limit(next/time.Minute.Seconds()), max(min(remaining, int(ceiling)), 1))
+}
@@ -0,0 +1,128 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+// createAdaptiveMockResponse creates a mock response with the given status code and rate limit headers. Headers
+// with a negative value are omitted.
+func createAdaptiveMockResponse(status, rateLimit, remaining int) *http.Response {
+	recorder := httptest.NewRecorder()
+	if rateLimit >= 0 {
+		recorder.Header().Set(discogs.RateLimitHeader, strconv.Itoa(rateLimit))
+	}
+	if remaining >= 0 {
+		recorder.Header().Set(discogs.RateLimitRemainingHeader, strconv.Itoa(remaining))
+	}
+	recorder.WriteHeader(status)
+	return recorder.Result()
+}
+
+func TestDiscogsClient_AdaptRateLimit(t *testing.T) {
+	perMinute := func(requests float64) rate.Limit {
+		return rate.Limit(requests / time.Minute.Seconds())
+	}
+
+	type response struct {
+		status    int
+		rateLimit int
+		remaining int
+	}
+	tests := []struct {
+		name        string
+		maxRequests int
+		responses   []response
+		want        rate.Limit
+	}{
+		{
+			"AdaptRateLimit backs off on 429",
+			60,
+			[]response{{http.StatusTooManyRequests, 60, 0}},
+			perMinute(30),
+		},
+		{
+			"AdaptRateLimit backs off on low remaining",
+			60,
+			[]response{{http.StatusOK, 60, 5}, {http.StatusOK, 60, 4}},
+			perMinute(15),
+		},
+		{
+			"AdaptRateLimit ramps up after backing off",
+			60,
+			[]response{{http.StatusTooManyRequests, 60, 0}, {http.StatusOK, 60, 50}, {http.StatusOK, 60, 49}},
+			perMinute(32),
+		},
+		{
+			"AdaptRateLimit never exceeds the header limit",
+			60,
+			[]response{{http.StatusOK, 25, 20}},
+			perMinute(25),
+		},
+		{
+			"AdaptRateLimit never exceeds MaxRequests without headers",
+			10,
+			[]response{{http.StatusOK, -1, -1}},
+			perMinute(10),
+		},
+		{
+			"AdaptRateLimit never backs off below the minimum rate",
+			1,
+			[]response{{http.StatusTooManyRequests, 60, 0}, {http.StatusTooManyRequests, 60, 0}},
+			perMinute(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{MaxRequests: tt.maxRequests, AdaptiveRateLimit: true})
+
+			for _, res := range tt.responses {
+				client.AdaptRateLimit(createAdaptiveMockResponse(res.status, res.rateLimit, res.remaining))
+			}
+
+			assert.InDelta(t, float64(tt.want), float64(client.Limit()), 1e-9)
+		})
+	}
+}
+
+func TestDiscogsClient_PaceRateLimit(t *testing.T) {
+	perMinute := func(requests float64) rate.Limit {
+		return rate.Limit(requests / time.Minute.Seconds())
+	}
+
+	type response struct {
+		status    int
+		rateLimit int
+		remaining int
+	}
+	tests := []struct {
+		name      string
+		responses []response
+		wantLimit rate.Limit
+		wantBurst int
+	}{
+		{"full rate with plenty remaining", []response{{http.StatusOK, 60, 40}}, perMinute(60), 40},
+		{"slows down when nearly exhausted", []response{{http.StatusOK, 60, 6}}, perMinute(30), 6},
+		{"minimum rate on 429", []response{{http.StatusTooManyRequests, 60, 3}}, perMinute(1), 1},
+		{"speeds up after the window resets", []response{{http.StatusOK, 60, 1}, {http.StatusOK, 60, 59}}, perMinute(60), 59},
+		{"rate limit header only", []response{{http.StatusOK, 30, -1}}, perMinute(30), 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{PaceRateLimit: true})
+			for _, res := range tt.responses {
+				client.PaceRateLimit(createAdaptiveMockResponse(res.status, res.rateLimit, res.remaining))
+			}
+			assert.InDelta(t, float64(tt.wantLimit), float64(client.Limit()), 1e-9)
+			assert.Equal(t, tt.wantBurst, client.Diagnostics().Burst)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package discogs
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// auditPayloadLimit is the maximum number of bytes of a request body kept in an AuditEntry.
+const auditPayloadLimit = 512
+
+// AuditEntry represents a write operation recorded in the audit log.
+type AuditEntry struct {
+	Time     time.Time     `json:"time"`
+	Method   string        `json:"method"`
+	Endpoint string        `json:"endpoint"`
+	Route    string        `json:"route"`
+	Duration time.Duration `json:"duration"`
+	// Payload is the JSON body of the request, truncated to 512 bytes without splitting a character. It is empty if
+	// the body of a request sent with Do cannot be read again.
+	Payload string `json:"payload,omitempty"`
+	// Truncated reports whether Payload was truncated.
+	Truncated bool `json:"truncated,omitempty"`
+	// StatusCode is the status code of the failed response, or 0 if the request succeeded or no response was received.
+	StatusCode int `json:"status_code,omitempty"`
+	// Error is the error the request failed with, or empty if it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// Succeeded reports whether the recorded operation succeeded.
+func (e *AuditEntry) Succeeded() bool {
+	return e.Error == ""
+}
+
+// An AuditSink records the entries of the audit log. Implementations must be safe for concurrent use, and should not
+// block for long, since requests wait for their entry to be recorded.
+type AuditSink interface {
+	Record(entry AuditEntry) error
+}
+
+// AuditSinkFunc is an adapter to allow the use of ordinary functions as an AuditSink.
+type AuditSinkFunc func(entry AuditEntry) error
+
+// Record calls f(entry).
+func (f AuditSinkFunc) Record(entry AuditEntry) error {
+	return f(entry)
+}
+
+// JSONAuditSink is an AuditSink writing every entry as a line of JSON, for files or log collectors.
+type JSONAuditSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONAuditSink creates a new JSONAuditSink writing to w.
+func NewJSONAuditSink(w io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{enc: json.NewEncoder(w)}
+}
+
+// Record writes the entry as a line of JSON.
+func (s *JSONAuditSink) Record(entry AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(entry)
+}
+
+// audit records an attempt of a write operation, started at start, in the audit log of the DiscogsConfig, if any.
+// Errors of the sink are recorded as the last error of the client, since they must not fail the operation that was
+// already sent.
+func (dc *DiscogsClient) audit(req *http.Request, start time.Time, err error) {
+	if dc.Config.AuditLog == nil {
+		return
+	}
+
+	entry := AuditEntry{
+		Time:     start,
+		Method:   req.Method,
+		Endpoint: req.URL.Path,
+		Route:    requestRoute(req),
+		Duration: time.Since(start),
+	}
+	entry.Payload, entry.Truncated = auditPayload(req)
+
+	if err != nil {
+		entry.Error = err.Error()
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) {
+			entry.StatusCode = httpErr.StatusCode
+		}
+	}
+
+	if sinkErr := dc.Config.AuditLog.Record(entry); sinkErr != nil {
+		dc.recordError(sinkErr)
+	}
+}
+
+// auditPayload returns the body of req, read again through GetBody, truncated to auditPayloadLimit bytes on a rune
+// boundary, and whether it was truncated.
+func auditPayload(req *http.Request) (string, bool) {
+	if req.GetBody == nil {
+		return "", false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "", false
+	}
+	defer body.Close()
+
+	// Read one more byte, to tell whether the byte at the limit starts a rune
+	payload, err := io.ReadAll(io.LimitReader(body, auditPayloadLimit+1))
+	if err != nil || len(payload) <= auditPayloadLimit {
+		return string(payload), false
+	}
+
+	n := auditPayloadLimit
+	for n > 0 && !utf8.RuneStart(payload[n]) {
+		n--
+	}
+	return string(payload[:n]), true
+}
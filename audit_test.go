@@ -0,0 +1,73 @@
+package discogs_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_AuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/marketplace/listings/2" {
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = rw.Write([]byte(`{"message":"You don't have permission to access this resource."}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token, AuditLog: discogs.NewJSONAuditSink(&buf)})
+	client.Host = server.URL
+
+	assert.NoError(t, client.Get(ctx, "/marketplace/listings/1", nil, nil, nil))
+	assert.NoError(t, client.Post(ctx, "/marketplace/listings/1", nil, nil, map[string]any{"price": 10}, nil))
+	assert.Error(t, client.Delete(ctx, "/marketplace/listings/2", nil, nil, nil))
+	assert.NoError(t, client.Post(ctx, "/marketplace/listings/3", nil, nil, map[string]string{"comments": strings.Repeat("a", 600)}, nil))
+	assert.NoError(t, client.Post(ctx, "/marketplace/listings/4", nil, nil, map[string]string{"comments": strings.Repeat("é", 300)}, nil))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/marketplace/listings/5", strings.NewReader(`{"price":5}`))
+	assert.NoError(t, err)
+	assert.NoError(t, client.Do(ctx, req, nil))
+
+	var entries []discogs.AuditEntry
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var entry discogs.AuditEntry
+		assert.NoError(t, dec.Decode(&entry))
+		entries = append(entries, entry)
+	}
+
+	if assert.Len(t, entries, 5, "only write operations are recorded") {
+		assert.Equal(t, http.MethodPost, entries[0].Method)
+		assert.Equal(t, "/marketplace/listings/1", entries[0].Endpoint)
+		assert.Equal(t, "/marketplace/listings/{listing_id}", entries[0].Route)
+		assert.Equal(t, `{"price":10}`, entries[0].Payload)
+		assert.True(t, entries[0].Succeeded())
+		assert.False(t, entries[0].Time.IsZero())
+
+		assert.Equal(t, http.MethodDelete, entries[1].Method)
+		assert.False(t, entries[1].Succeeded())
+		assert.Equal(t, http.StatusForbidden, entries[1].StatusCode)
+
+		assert.True(t, entries[2].Truncated)
+		assert.Len(t, entries[2].Payload, 512)
+
+		// The payload is truncated before the character crossing the limit
+		assert.True(t, entries[3].Truncated)
+		assert.Len(t, entries[3].Payload, 511)
+		assert.True(t, utf8.ValidString(entries[3].Payload))
+
+		assert.Equal(t, "/marketplace/listings/5", entries[4].Endpoint)
+		assert.Equal(t, `{"price":5}`, entries[4].Payload)
+	}
+}
@@ -0,0 +1,84 @@
+package discogs
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff determines how long to wait before retrying a failed request. Implementations must be safe for concurrent
+// use, as a single Backoff is shared by all requests of a DiscogsClient.
+type Backoff interface {
+	// Next returns the time to wait before the given retry, where attempt is 1 for the first retry. The previous
+	// parameter is the time waited before the previous retry, or 0 before the first retry.
+	Next(attempt int, previous time.Duration) time.Duration
+}
+
+// ConstantBackoff waits the same amount of time before every retry.
+type ConstantBackoff struct {
+	Wait time.Duration
+}
+
+// Next returns the constant wait time.
+func (b ConstantBackoff) Next(attempt int, previous time.Duration) time.Duration {
+	return b.Wait
+}
+
+// ExponentialBackoff multiplies the wait time by Multiplier after every retry, starting at Initial and capped at Max.
+type ExponentialBackoff struct {
+	Initial time.Duration
+	// Max is the maximum wait time. The wait time is not capped if 0.
+	Max time.Duration
+	// Multiplier is the factor the wait time grows by. A Multiplier of 2 is used if unset.
+	Multiplier float64
+	// Jitter is the fraction of the wait time that is random, between 0 and 1, so clients that failed at the same time
+	// do not retry in lockstep. A Jitter of 0.5 waits between half and all of the wait time, and a Jitter of 1 between
+	// 0 and the wait time. The wait time is not random if 0.
+	Jitter float64
+}
+
+// Next returns Initial * Multiplier^(attempt-1), capped at Max, and reduced by a random part of up to Jitter.
+func (b ExponentialBackoff) Next(attempt int, previous time.Duration) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	wait := float64(b.Initial) * math.Pow(multiplier, float64(max(attempt-1, 0)))
+	if b.Max > 0 && wait > float64(b.Max) {
+		wait = float64(b.Max)
+	}
+	if jitter := min(max(b.Jitter, 0), 1); jitter > 0 {
+		wait -= wait * jitter * rand.Float64()
+	}
+	if wait > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(wait)
+}
+
+// DecorrelatedJitterBackoff waits a random time between Base and three times the previous wait time, capped at Max.
+// Spreading out retries this way keeps many clients that failed at the same time from retrying in lockstep.
+//
+// See https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration
+	// Max is the maximum wait time. The wait time is not capped if 0.
+	Max time.Duration
+}
+
+// Next returns a random wait time between Base and three times previous, capped at Max.
+func (b DecorrelatedJitterBackoff) Next(attempt int, previous time.Duration) time.Duration {
+	previous = max(previous, b.Base)
+
+	upper := min(previous*3, time.Duration(math.MaxInt64/2))
+	wait := b.Base
+	if upper > b.Base {
+		wait += rand.N(upper - b.Base)
+	}
+
+	if b.Max > 0 && wait > b.Max {
+		return b.Max
+	}
+	return wait
+}
@@ -0,0 +1,97 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantBackoff_Next(t *testing.T) {
+	backoff := discogs.ConstantBackoff{Wait: time.Second}
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		assert.Equal(t, time.Second, backoff.Next(attempt, time.Second))
+	}
+}
+
+func TestExponentialBackoff_Next(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff discogs.ExponentialBackoff
+		attempt int
+		want    time.Duration
+	}{
+		{"ExponentialBackoff first retry", discogs.ExponentialBackoff{Initial: time.Second}, 1, time.Second},
+		{"ExponentialBackoff default multiplier", discogs.ExponentialBackoff{Initial: time.Second}, 3, 4 * time.Second},
+		{"ExponentialBackoff custom multiplier", discogs.ExponentialBackoff{Initial: time.Second, Multiplier: 3}, 3, 9 * time.Second},
+		{"ExponentialBackoff capped", discogs.ExponentialBackoff{Initial: time.Second, Max: 5 * time.Second}, 4, 5 * time.Second},
+		{"ExponentialBackoff overflow", discogs.ExponentialBackoff{Initial: time.Second}, 100, time.Duration(1<<63 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.backoff.Next(tt.attempt, 0))
+		})
+	}
+}
+
+func TestExponentialBackoff_NextJitter(t *testing.T) {
+	backoff := discogs.ExponentialBackoff{Initial: time.Second, Max: 10 * time.Second, Jitter: 0.5}
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		want := min(time.Second<<(attempt-1), backoff.Max)
+		wait := backoff.Next(attempt, 0)
+
+		assert.GreaterOrEqual(t, wait, want/2)
+		assert.LessOrEqual(t, wait, want)
+	}
+}
+
+func TestDecorrelatedJitterBackoff_Next(t *testing.T) {
+	backoff := discogs.DecorrelatedJitterBackoff{Base: 100 * time.Millisecond, Max: time.Second}
+
+	var previous time.Duration
+	for attempt := 1; attempt <= 20; attempt++ {
+		wait := backoff.Next(attempt, previous)
+
+		assert.GreaterOrEqual(t, wait, backoff.Base)
+		assert.LessOrEqual(t, wait, backoff.Max)
+		assert.LessOrEqual(t, wait, max(previous, backoff.Base)*3)
+		previous = wait
+	}
+}
+
+// recordingBackoff records the retries it was asked for.
+type recordingBackoff struct {
+	mu       sync.Mutex
+	attempts []int
+	previous []time.Duration
+}
+
+func (b *recordingBackoff) Next(attempt int, previous time.Duration) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.attempts = append(b.attempts, attempt)
+	b.previous = append(b.previous, previous)
+	return time.Duration(attempt) * time.Millisecond
+}
+
+func TestDiscogsClient_Backoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	backoff := &recordingBackoff{}
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{MaxRetries: 3, Backoff: backoff})
+	client.Host = server.URL
+
+	assert.Error(t, client.Get(ctx, "/test", nil, nil, nil))
+	assert.Equal(t, []int{1, 2, 3}, backoff.attempts)
+	assert.Equal(t, []time.Duration{0, time.Millisecond, 2 * time.Millisecond}, backoff.previous)
+}
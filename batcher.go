@@ -0,0 +1,142 @@
+package discogs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultBatchWindow is the default duration a Batcher collects lookups before executing them.
+const DefaultBatchWindow = 10 * time.Millisecond
+
+// BatcherOptions represents the options for a Batcher.
+type BatcherOptions struct {
+	// Window is the duration lookups are collected before they are executed. DefaultBatchWindow is used if unset.
+	Window time.Duration
+	// ReleaseOptions are the options used for every release lookup.
+	ReleaseOptions *ReleaseOptions
+}
+
+// batchKey identifies a single lookup in a Batcher.
+type batchKey struct {
+	resource Resource
+	id       int64
+}
+
+// batchCall represents a lookup that is pending or in flight. Callers requesting the same lookup share the call
+// and wait for done to be closed.
+type batchCall struct {
+	ctx  context.Context
+	done chan struct{}
+	res  interface{}
+	err  error
+}
+
+// A Batcher coalesces release and master lookups. Lookups arriving within a short window are collected, duplicate
+// lookups are merged into a single request, and the requests are then executed together through the client, whose
+// rate limiter spaces them out. This is useful for web backends where many handlers request overlapping data.
+//
+// A Batcher is safe for concurrent use.
+type Batcher struct {
+	client  *DiscogsClient
+	options BatcherOptions
+
+	mu      sync.Mutex
+	calls   map[batchKey]*batchCall
+	queue   []batchKey
+	pending bool
+}
+
+// NewBatcher creates a new Batcher that executes lookups using the DiscogsClient. If options is nil, the default
+// options are used.
+func (dc *DiscogsClient) NewBatcher(options *BatcherOptions) *Batcher {
+	b := &Batcher{
+		client: dc,
+		calls:  make(map[batchKey]*batchCall),
+	}
+	if options != nil {
+		b.options = *options
+	}
+	if b.options.Window <= 0 {
+		b.options.Window = DefaultBatchWindow
+	}
+	return b
+}
+
+// Release fetches the release with the given ID, sharing the request with any other lookup of the same release
+// that is pending or in flight. See DiscogsClient.Release for details.
+func (b *Batcher) Release(ctx context.Context, releaseID int64) (*ReleaseResponse, error) {
+	res, err := b.lookup(ctx, batchKey{ResourceRelease, releaseID})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*ReleaseResponse), nil
+}
+
+// Master fetches the master release with the given ID, sharing the request with any other lookup of the same master
+// release that is pending or in flight. See DiscogsClient.Master for details.
+func (b *Batcher) Master(ctx context.Context, masterID int64) (*MasterResponse, error) {
+	res, err := b.lookup(ctx, batchKey{ResourceMaster, masterID})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*MasterResponse), nil
+}
+
+// lookup registers a lookup for key, or joins an existing one, and waits for its result.
+func (b *Batcher) lookup(ctx context.Context, key batchKey) (interface{}, error) {
+	b.mu.Lock()
+	call, ok := b.calls[key]
+	if !ok {
+		// The request outlives the caller that triggered it, since other callers may be waiting on it
+		call = &batchCall{ctx: context.WithoutCancel(ctx), done: make(chan struct{})}
+		b.calls[key] = call
+		b.queue = append(b.queue, key)
+
+		if !b.pending {
+			b.pending = true
+			time.AfterFunc(b.options.Window, b.flush)
+		}
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.res, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// flush executes all queued lookups.
+func (b *Batcher) flush() {
+	b.mu.Lock()
+	queue := b.queue
+	b.queue = nil
+	b.pending = false
+	calls := make([]*batchCall, len(queue))
+	for i, key := range queue {
+		calls[i] = b.calls[key]
+	}
+	b.mu.Unlock()
+
+	for i, key := range queue {
+		go b.execute(key, calls[i])
+	}
+}
+
+// execute performs a single lookup and publishes its result to all waiting callers.
+func (b *Batcher) execute(key batchKey, call *batchCall) {
+	switch key.resource {
+	case ResourceRelease:
+		call.res, call.err = b.client.Release(call.ctx, key.id, b.options.ReleaseOptions)
+	case ResourceMaster:
+		call.res, call.err = b.client.Master(call.ctx, key.id)
+	}
+
+	b.mu.Lock()
+	delete(b.calls, key)
+	b.mu.Unlock()
+
+	close(call.done)
+}
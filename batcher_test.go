@@ -0,0 +1,96 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatcher(t *testing.T) {
+	var requests sync.Map
+	var total atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		total.Add(1)
+		count, _ := requests.LoadOrStore(req.URL.Path, new(atomic.Int64))
+		count.(*atomic.Int64).Add(1)
+
+		var res interface{}
+		switch {
+		case strings.HasPrefix(req.URL.Path, "/releases/"):
+			res = discogs.ReleaseResponse{Title: "Release " + strings.TrimPrefix(req.URL.Path, "/releases/")}
+		case strings.HasPrefix(req.URL.Path, "/masters/"):
+			res = discogs.MasterResponse{Title: "Master " + strings.TrimPrefix(req.URL.Path, "/masters/")}
+		}
+
+		responseBody, err := json.Marshal(res)
+		if err != nil {
+			assert.FailNow(t, "unable to marshal json response: %w", err)
+		}
+
+		if _, err := rw.Write(responseBody); err != nil {
+			assert.FailNow(t, "failed to write the response body: %w", err)
+		}
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+	batcher := client.NewBatcher(&discogs.BatcherOptions{Window: 20 * time.Millisecond})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			res, err := batcher.Release(ctx, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, "Release 1", res.Title)
+		}()
+		go func() {
+			defer wg.Done()
+			res, err := batcher.Release(ctx, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, "Release 2", res.Title)
+		}()
+		go func() {
+			defer wg.Done()
+			res, err := batcher.Master(ctx, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, "Master 1", res.Title)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(3), total.Load())
+	for _, path := range []string{"/releases/1", "/releases/2", "/masters/1"} {
+		count, ok := requests.Load(path)
+		if assert.True(t, ok, "expected a request to %s", path) {
+			assert.Equal(t, int64(1), count.(*atomic.Int64).Load())
+		}
+	}
+}
+
+func TestBatcher_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+	batcher := client.NewBatcher(nil)
+
+	res, err := batcher.Master(ctx, 3)
+
+	assert.Nil(t, res)
+	assert.True(t, discogs.IsNotFound(err))
+}
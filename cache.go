@@ -0,0 +1,180 @@
+package discogs
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// A ResponseCache stores the response bodies of GET requests, keyed by endpoint and query string, such as
+// "/releases/1?curr_abbr=EUR", followed by the requested media type if any. Implementations must be safe for
+// concurrent use.
+type ResponseCache interface {
+	// Get returns the cached response body of a key, and reports whether it was found.
+	Get(key string) ([]byte, bool)
+	// Set caches the response body of a key.
+	Set(key string, body []byte)
+	// DeleteFunc removes the cached responses whose key matches.
+	DeleteFunc(match func(key string) bool)
+}
+
+// MemoryCache is a ResponseCache that keeps responses in memory for a fixed time.
+type MemoryCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// cacheEntry holds a cached response body along with its expiry time.
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates a new empty MemoryCache keeping responses for ttl. Responses never expire if ttl is 0.
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{ttl: ttl, entries: make(map[string]cacheEntry)}
+}
+
+// Get returns the cached response body of a key, unless it expired.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.body, true
+}
+
+// Set caches the response body of a key.
+func (c *MemoryCache) Set(key string, body []byte) {
+	entry := cacheEntry{body: body}
+	if c.ttl > 0 {
+		entry.expires = time.Now().Add(c.ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+}
+
+// Len returns the number of cached responses, including expired ones that were not removed yet.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// DeleteFunc removes the cached responses whose key matches.
+func (c *MemoryCache) DeleteFunc(match func(key string) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if match(key) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cacheInvalidations maps write routes to the patterns of the cached responses they affect besides their own, given
+// the path segments of the write endpoint. Writes to other routes only invalidate their own endpoint.
+var cacheInvalidations = map[string]func(segments []string) []string{
+	// Adding to or removing from a collection changes the folders, the fields and the counts of the profile
+	"/users/{username}/collection/folders/{folder_id}/releases/{release_id}": userInvalidation,
+	// Changing the wantlist changes its pages and the wantlist count of the profile
+	"/users/{username}/wants/{release_id}": userInvalidation,
+	// Renaming or deleting a folder changes the folder list
+	"/users/{username}/collection/folders/{folder_id}": userInvalidation,
+	// Editing a field of an instance changes the notes of the items listed in every folder
+	"/users/{username}/collection/folders/{folder_id}/releases/{release_id}/instances/{instance_id}/fields/{field_id}": userInvalidation,
+	// Moving, rating or removing an instance changes the folders, their counts and the counts of the profile
+	"/users/{username}/collection/folders/{folder_id}/releases/{release_id}/instances/{instance_id}": userInvalidation,
+	// Updating an order changes the list of orders
+	"/marketplace/orders/{order_id}": func(segments []string) []string {
+		return []string{"/marketplace/orders"}
+	},
+	// Messaging the buyer changes the last activity of the order, and its status if one is set
+	"/marketplace/orders/{order_id}/messages": func(segments []string) []string {
+		return []string{"/marketplace/orders"}
+	},
+	// Editing a listing changes it and the inventory of its seller, whose username is not part of the endpoint
+	"/marketplace/listings/{listing_id}": func(segments []string) []string {
+		return []string{"/users/{username}/inventory"}
+	},
+}
+
+// userInvalidation invalidates all cached responses of the user of a /users/{username} endpoint.
+func userInvalidation(segments []string) []string {
+	return []string{"/users/" + segments[2]}
+}
+
+// InvalidateCache removes the cached responses of the endpoints matching the patterns, along with the responses of
+// the endpoints beneath them, whatever their query string. Patterns use the syntax of EndpointAuthMap, so
+// "/users/{username}/inventory" invalidates the inventories of all users, including their pages, while "/releases/1"
+// invalidates release 1 and its rating and statistics. It does nothing if the client has no Cache.
+//
+// Writes sent through the client already invalidate the responses they affect; InvalidateCache covers changes made
+// elsewhere, such as on the Discogs website.
+func (dc *DiscogsClient) InvalidateCache(patterns ...string) {
+	if dc.Config.Cache == nil || len(patterns) == 0 {
+		return
+	}
+
+	dc.Config.Cache.DeleteFunc(func(key string) bool {
+		path, _, _ := strings.Cut(key, "?")
+		for _, pattern := range patterns {
+			if isMatchOrBeneath(pattern, path) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// invalidateAfterWrite invalidates the cached responses affected by a successful write to endpoint.
+func (dc *DiscogsClient) invalidateAfterWrite(endpoint string) {
+	if dc.Config.Cache == nil {
+		return
+	}
+
+	patterns := []string{endpoint}
+	if related, ok := cacheInvalidations[routePattern(endpoint, EndpointAuthMap)]; ok {
+		patterns = append(patterns, related(strings.Split(endpoint, "/"))...)
+	}
+	dc.InvalidateCache(patterns...)
+}
+
+// isMatchOrBeneath reports whether path matches the route pattern, or is beneath an endpoint matching it.
+func isMatchOrBeneath(pattern, path string) bool {
+	n := strings.Count(pattern, "/")
+	parts := strings.SplitN(path, "/", n+2)
+	if len(parts) < n+1 {
+		return false
+	}
+	return isMatch(pattern, strings.Join(parts[:n+1], "/"))
+}
+
+// cacheLen is implemented by caches that can tell how many responses they hold, like *MemoryCache.
+type cacheLen interface {
+	Len() int
+}
+
+// recordCacheLookup counts a lookup of the Cache, reported by Diagnostics.
+func (dc *DiscogsClient) recordCacheLookup(hit bool) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if hit {
+		dc.cacheHits++
+	} else {
+		dc.cacheMisses++
+	}
+}
@@ -0,0 +1,69 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache(t *testing.T) {
+	cache := discogs.NewMemoryCache(20 * time.Millisecond)
+	cache.Set("/releases/1?", []byte(`{}`))
+
+	body, ok := cache.Get("/releases/1?")
+	assert.True(t, ok)
+	assert.Equal(t, []byte(`{}`), body)
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok = cache.Get("/releases/1?")
+	assert.False(t, ok)
+}
+
+func TestDiscogsClient_Cache(t *testing.T) {
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests[req.Method+" "+req.URL.Path]++
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token, Cache: discogs.NewMemoryCache(0)})
+	client.Host = server.URL
+
+	get := func(endpoint string) {
+		assert.NoError(t, client.Get(ctx, endpoint, nil, nil, nil))
+	}
+
+	get("/releases/1")
+	get("/releases/1")
+	get("/releases/1/stats")
+	get("/releases/10")
+	get("/users/user/collection/fields")
+	get("/users/user/collection/folders/1/releases")
+	get("/users/other/collection/fields")
+	assert.Equal(t, 1, requests["GET /releases/1"], "the second request is served from the cache")
+
+	// Adding to the collection invalidates the collection and profile of the user only
+	_, err := client.AddToCollectionFolder(ctx, "user", 1, 2)
+	assert.NoError(t, err)
+	get("/users/user/collection/fields")
+	get("/users/user/collection/folders/1/releases")
+	get("/users/other/collection/fields")
+	assert.Equal(t, 2, requests["GET /users/user/collection/fields"])
+	assert.Equal(t, 2, requests["GET /users/user/collection/folders/1/releases"])
+	assert.Equal(t, 1, requests["GET /users/other/collection/fields"])
+
+	// Invalidating a release invalidates the endpoints beneath it, but not other releases
+	client.InvalidateCache("/releases/1")
+	get("/releases/1")
+	get("/releases/1/stats")
+	get("/releases/10")
+	assert.Equal(t, 2, requests["GET /releases/1"])
+	assert.Equal(t, 2, requests["GET /releases/1/stats"])
+	assert.Equal(t, 1, requests["GET /releases/10"])
+}
@@ -0,0 +1,316 @@
+package discogs
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/google/go-querystring/query"
+)
+
+// CollectionItems fetches a page of the items in a folder of a user's collection by sending a GET request to the
+// /users/{username}/collection/folders/{folder_id}/releases endpoint. The folderID specifies the folder, where
+// FolderAll contains every item, and options allows for sorting and pagination. The context.Context provides control
+// over the request's lifecycle. It returns a pointer to a CollectionItemsResponse struct containing the items, or an
+// error if the request fails or the folder is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:user-collection,header:user-collection-collection-items-by-folder
+func (dc *DiscogsClient) CollectionItems(ctx context.Context, username string, folderID int64, options *CollectionItemsOptions, opts ...RequestOption) (*CollectionItemsResponse, error) {
+	endpoint := "/users/" + url.PathEscape(username) + "/collection/folders/" + strconv.FormatInt(folderID, 10) + "/releases"
+	var res CollectionItemsResponse
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var authType AuthType
+	if folderID == FolderAll {
+		authType = dc.optionalAuth(ctx)
+	}
+
+	if err := dc.requestWithAuth(WithRequestOptions(ctx, opts...), http.MethodGet, endpoint, authType, params, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceFolder, strconv.FormatInt(folderID, 10))
+	}
+
+	return &res, nil
+}
+
+// PublicCollection fetches a page of the items in a user's public collection by sending an unauthenticated GET
+// request to the /users/{username}/collection/folders/0/releases endpoint, so read-only crawlers do not need
+// credentials. Unlike CollectionItems with FolderAll, the access token is never sent, so the items are the ones any
+// other user sees. The options allow for sorting and pagination. The context.Context provides control over the
+// request's lifecycle. It returns a pointer to a CollectionItemsResponse struct containing the items, or an error if
+// the request fails, the user is not found or the collection is private.
+//
+// Documentation: https://www.discogs.com/developers#page:user-collection,header:user-collection-collection-items-by-folder
+func (dc *DiscogsClient) PublicCollection(ctx context.Context, username string, options *CollectionItemsOptions, opts ...RequestOption) (*CollectionItemsResponse, error) {
+	endpoint := "/users/" + url.PathEscape(username) + "/collection/folders/0/releases"
+	var res CollectionItemsResponse
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dc.requestWithAuth(WithRequestOptions(ctx, opts...), http.MethodGet, endpoint, AuthTypeNone, params, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceUser, username)
+	}
+
+	return &res, nil
+}
+
+// PublicCollectionPages returns a PageFetcher that fetches pages of the items in a user's public collection, for use
+// with NewIterator or ForEachPage. The pagination parameters of options are overridden by the page being fetched.
+func (dc *DiscogsClient) PublicCollectionPages(username string, options *CollectionItemsOptions) PageFetcher[CollectionItem] {
+	return func(ctx context.Context, page PaginationParams) ([]CollectionItem, *Pagination, error) {
+		var pageOptions CollectionItemsOptions
+		if options != nil {
+			pageOptions = *options
+		}
+		pageOptions.Page = page.Page
+		if page.PerPage != nil {
+			pageOptions.PerPage = page.PerPage
+		}
+
+		res, err := dc.PublicCollection(ctx, username, &pageOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Releases, res.Pagination, nil
+	}
+}
+
+// CollectionItemsPages returns a PageFetcher that fetches pages of the items in a folder of a user's collection, for
+// use with NewIterator or ForEachPage. The pagination parameters of options are overridden by the page being fetched.
+func (dc *DiscogsClient) CollectionItemsPages(username string, folderID int64, options *CollectionItemsOptions) PageFetcher[CollectionItem] {
+	return func(ctx context.Context, page PaginationParams) ([]CollectionItem, *Pagination, error) {
+		var pageOptions CollectionItemsOptions
+		if options != nil {
+			pageOptions = *options
+		}
+		pageOptions.Page = page.Page
+		if page.PerPage != nil {
+			pageOptions.PerPage = page.PerPage
+		}
+
+		res, err := dc.CollectionItems(ctx, username, folderID, &pageOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Releases, res.Pagination, nil
+	}
+}
+
+// CollectionFields fetches the custom fields of a user's collection by sending a GET request to the
+// /users/{username}/collection/fields endpoint. Only public fields are returned unless the authenticated user owns
+// the collection. The context.Context provides control over the request's lifecycle. It returns a pointer to a
+// CollectionFieldsResponse struct containing the fields, or an error if the request fails or the user is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:user-collection,header:user-collection-list-custom-fields
+func (dc *DiscogsClient) CollectionFields(ctx context.Context, username string, opts ...RequestOption) (*CollectionFieldsResponse, error) {
+	endpoint := "/users/" + url.PathEscape(username) + "/collection/fields"
+	var res CollectionFieldsResponse
+
+	if err := dc.requestWithAuth(WithRequestOptions(ctx, opts...), http.MethodGet, endpoint, dc.optionalAuth(ctx), nil, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceUser, username)
+	}
+
+	return &res, nil
+}
+
+// AddToCollectionFolder adds an instance of a release to a folder of the authenticated user's collection by sending a
+// POST request to the /users/{username}/collection/folders/{folder_id}/releases/{release_id} endpoint. A release can
+// be added to a collection several times, creating a new instance each time. The context.Context provides control
+// over the request's lifecycle. It returns a pointer to an AddToFolderResponse struct containing the ID of the new
+// instance, or an error if the request fails or the release is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:user-collection,header:user-collection-add-to-collection-folder
+func (dc *DiscogsClient) AddToCollectionFolder(ctx context.Context, username string, folderID, releaseID int64, opts ...RequestOption) (*AddToFolderResponse, error) {
+	endpoint := "/users/" + url.PathEscape(username) + "/collection/folders/" + strconv.FormatInt(folderID, 10) +
+		"/releases/" + strconv.FormatInt(releaseID, 10)
+	var res AddToFolderResponse
+
+	if err := dc.Post(WithRequestOptions(ctx, opts...), endpoint, nil, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceRelease, strconv.FormatInt(releaseID, 10))
+	}
+
+	return &res, nil
+}
+
+// CollectionFolder fetches a folder of a user's collection by sending a GET request to the
+// /users/{username}/collection/folders/{folder_id} endpoint. Folders other than FolderAll can only be fetched by the
+// authenticated user owning the collection. The context.Context provides control over the request's lifecycle. It
+// returns a pointer to a CollectionFolderResponse struct containing the folder, or an error if the request fails or
+// the folder is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:user-collection,header:user-collection-collection-folder
+func (dc *DiscogsClient) CollectionFolder(ctx context.Context, username string, folderID int64, opts ...RequestOption) (*CollectionFolderResponse, error) {
+	endpoint := "/users/" + url.PathEscape(username) + "/collection/folders/" + strconv.FormatInt(folderID, 10)
+	var res CollectionFolderResponse
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceFolder, strconv.FormatInt(folderID, 10))
+	}
+
+	return &res, nil
+}
+
+// RenameCollectionFolder renames a folder of the authenticated user's collection by sending a POST request to the
+// /users/{username}/collection/folders/{folder_id} endpoint. The context.Context provides control over the request's
+// lifecycle. It returns a pointer to a CollectionFolderResponse struct containing the renamed folder, or an error if
+// the request fails or the folder is not found. It returns an ErrInvalidOption before sending the request if the
+// folder is FolderAll or FolderUncategorized, which cannot be renamed, or if name is empty.
+//
+// Documentation: https://www.discogs.com/developers#page:user-collection,header:user-collection-collection-folder
+func (dc *DiscogsClient) RenameCollectionFolder(ctx context.Context, username string, folderID int64, name string, opts ...RequestOption) (*CollectionFolderResponse, error) {
+	if err := checkEditableFolder(folderID); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, &ErrInvalidOption{Option: "name", Value: name}
+	}
+
+	endpoint := "/users/" + url.PathEscape(username) + "/collection/folders/" + strconv.FormatInt(folderID, 10)
+	var res CollectionFolderResponse
+
+	body := struct {
+		Name string `json:"name"`
+	}{name}
+	if err := dc.Post(WithRequestOptions(ctx, opts...), endpoint, nil, nil, body, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceFolder, strconv.FormatInt(folderID, 10))
+	}
+
+	return &res, nil
+}
+
+// DeleteCollectionFolder deletes a folder of the authenticated user's collection by sending a DELETE request to the
+// /users/{username}/collection/folders/{folder_id} endpoint. Discogs only deletes empty folders. The context.Context
+// provides control over the request's lifecycle. It returns an error if the request fails or the folder is not
+// found. It returns an ErrInvalidOption before sending the request if the folder is FolderAll or
+// FolderUncategorized, which cannot be deleted.
+//
+// Documentation: https://www.discogs.com/developers#page:user-collection,header:user-collection-collection-folder
+func (dc *DiscogsClient) DeleteCollectionFolder(ctx context.Context, username string, folderID int64, opts ...RequestOption) error {
+	if err := checkEditableFolder(folderID); err != nil {
+		return err
+	}
+
+	endpoint := "/users/" + url.PathEscape(username) + "/collection/folders/" + strconv.FormatInt(folderID, 10)
+
+	if err := dc.Delete(WithRequestOptions(ctx, opts...), endpoint, nil, nil, nil); err != nil {
+		return wrapNotFound(err, ResourceFolder, strconv.FormatInt(folderID, 10))
+	}
+
+	return nil
+}
+
+// EditCollectionItem changes the rating of an instance of a release in the authenticated user's collection, or moves
+// it to another folder, by sending a POST request to the
+// /users/{username}/collection/folders/{folder_id}/releases/{release_id}/instances/{instance_id} endpoint. The
+// folderID is the folder the instance is currently in. The context.Context provides control over the request's
+// lifecycle. It returns an error if the request fails or the instance is not found. It returns an ErrInvalidOption
+// before sending the request if the rating is not between 0 and 5, or if the instance is moved to FolderAll.
+//
+// Documentation: https://www.discogs.com/developers#page:user-collection,header:user-collection-change-rating-of-release
+func (dc *DiscogsClient) EditCollectionItem(ctx context.Context, username string, folderID, releaseID, instanceID int64, options *EditCollectionItemOptions, opts ...RequestOption) error {
+	if options != nil && options.Rating != nil && (*options.Rating < 0 || *options.Rating > 5) {
+		return &ErrInvalidOption{Option: "rating", Value: strconv.Itoa(*options.Rating)}
+	}
+	if options != nil && options.FolderID != nil && *options.FolderID == FolderAll {
+		return &ErrInvalidOption{Option: "folder_id", Value: strconv.FormatInt(*options.FolderID, 10)}
+	}
+
+	if err := dc.Post(WithRequestOptions(ctx, opts...), instanceEndpoint(username, folderID, releaseID, instanceID), nil, nil, options, nil); err != nil {
+		return wrapNotFound(err, ResourceInstance, strconv.FormatInt(instanceID, 10))
+	}
+
+	return nil
+}
+
+// RemoveFromCollectionFolder removes an instance of a release from a folder of the authenticated user's collection by
+// sending a DELETE request to the
+// /users/{username}/collection/folders/{folder_id}/releases/{release_id}/instances/{instance_id} endpoint. The
+// context.Context provides control over the request's lifecycle. It returns an error if the request fails or the
+// instance is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:user-collection,header:user-collection-delete-instance-from-folder
+func (dc *DiscogsClient) RemoveFromCollectionFolder(ctx context.Context, username string, folderID, releaseID, instanceID int64, opts ...RequestOption) error {
+	if err := dc.Delete(WithRequestOptions(ctx, opts...), instanceEndpoint(username, folderID, releaseID, instanceID), nil, nil, nil); err != nil {
+		return wrapNotFound(err, ResourceInstance, strconv.FormatInt(instanceID, 10))
+	}
+
+	return nil
+}
+
+// instanceEndpoint returns the endpoint of an instance of a release in a collection folder.
+func instanceEndpoint(username string, folderID, releaseID, instanceID int64) string {
+	return "/users/" + url.PathEscape(username) + "/collection/folders/" + strconv.FormatInt(folderID, 10) +
+		"/releases/" + strconv.FormatInt(releaseID, 10) + "/instances/" + strconv.FormatInt(instanceID, 10)
+}
+
+// EditCollectionItemField sets the value of a custom field of an instance of a release in the authenticated user's
+// collection by sending a POST request to the
+// /users/{username}/collection/folders/{folder_id}/releases/{release_id}/instances/{instance_id}/fields/{field_id}
+// endpoint. The context.Context provides control over the request's lifecycle. It returns an error if the request
+// fails or the instance is not found.
+//
+// The field definitions are fetched with CollectionFields first, so it returns an ErrInvalidOption before editing if
+// the field does not exist, or if it is a dropdown field and value is not one of its options. Discogs would otherwise
+// store the value as is.
+//
+// Documentation: https://www.discogs.com/developers#page:user-collection,header:user-collection-edit-fields-instance
+func (dc *DiscogsClient) EditCollectionItemField(ctx context.Context, username string, folderID, releaseID, instanceID, fieldID int64, value string, opts ...RequestOption) error {
+	fields, err := dc.CollectionFields(ctx, username)
+	if err != nil {
+		return err
+	}
+	if err := fields.CheckValue(fieldID, value); err != nil {
+		return err
+	}
+
+	return dc.SetCollectionItemField(ctx, username, folderID, releaseID, instanceID, fieldID, value, opts...)
+}
+
+// SetCollectionItemField sets the value of a custom field of an instance like EditCollectionItemField, without
+// fetching the field definitions first, so editing many fields costs a single CollectionFields request. The value
+// should be checked with CollectionFieldsResponse.CheckValue, since Discogs stores it as is.
+//
+// Documentation: https://www.discogs.com/developers#page:user-collection,header:user-collection-edit-fields-instance
+func (dc *DiscogsClient) SetCollectionItemField(ctx context.Context, username string, folderID, releaseID, instanceID, fieldID int64, value string, opts ...RequestOption) error {
+	endpoint := instanceEndpoint(username, folderID, releaseID, instanceID) + "/fields/" + strconv.FormatInt(fieldID, 10)
+
+	body := struct {
+		Value string `json:"value"`
+	}{value}
+	if err := dc.Post(WithRequestOptions(ctx, opts...), endpoint, nil, nil, body, nil); err != nil {
+		return wrapNotFound(err, ResourceInstance, strconv.FormatInt(instanceID, 10))
+	}
+
+	return nil
+}
+
+// CheckValue returns an ErrInvalidOption if no field has the given ID, or if the field is a dropdown field and value
+// is not one of its options.
+func (r *CollectionFieldsResponse) CheckValue(fieldID int64, value string) error {
+	fields := r.Fields
+	i := slices.IndexFunc(fields, func(field CollectionField) bool { return field.ID == fieldID })
+	if i < 0 {
+		return &ErrInvalidOption{Option: "field_id", Value: strconv.FormatInt(fieldID, 10)}
+	}
+	if fields[i].Type == FieldTypeDropdown && !slices.Contains(fields[i].Options, value) {
+		return &ErrInvalidOption{Option: "value", Value: value}
+	}
+	return nil
+}
+
+// checkEditableFolder returns an ErrInvalidOption if the folder is one of the special folders FolderAll and
+// FolderUncategorized, which cannot be renamed or deleted.
+func checkEditableFolder(folderID int64) error {
+	if folderID == FolderAll || folderID == FolderUncategorized {
+		return &ErrInvalidOption{Option: "folder_id", Value: strconv.FormatInt(folderID, 10)}
+	}
+	return nil
+}
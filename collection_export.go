@@ -0,0 +1,177 @@
+package discogs
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// collectionCSVColumns are the columns of the collection exports of Discogs that do not depend on the custom fields.
+// Every custom field adds a "Collection <name>" column after them, such as "Collection Media Condition".
+var collectionCSVColumns = []string{
+	"Catalog#", "Artist", "Title", "Label", "Format", "Rating", "Released", "release_id", "CollectionFolder",
+	"Date Added",
+}
+
+// collectionDateAddedLayout is the layout of the "Date Added" column of the collection exports of Discogs.
+const collectionDateAddedLayout = "2006-01-02 15:04:05"
+
+// ExportCollectionCSVOptions represents the options for exporting a collection to CSV.
+type ExportCollectionCSVOptions struct {
+	// FolderID is the folder to export. The whole collection is exported if unset, since it is FolderAll.
+	FolderID int64
+	// Sort and SortOrder sort the exported items. The order of Discogs is used if unset.
+	Sort      CollectionSort
+	SortOrder SortOrder
+}
+
+// ExportCollectionCSV streams the items of a user's collection to w as CSV, using the column layout of the
+// collection exports of the Discogs website, so existing spreadsheets and import tools keep working. Every custom
+// field of the collection adds a column, in the order of the fields. The items are paged through with
+// CollectionItemsPages and written one page at a time, so large collections are not held in memory. If options is
+// nil, the whole collection is exported.
+//
+// Only public custom fields, and the folders of items in FolderAll, are visible to users other than the owner of the
+// collection. Folders whose name cannot be fetched, because they are private or were deleted, are written as their
+// ID. It returns the number of items written, and stops at the first error.
+//
+// Example:
+//
+//	f, err := os.Create("collection.csv")
+//	...
+//	n, err := client.ExportCollectionCSV(ctx, "username", f, nil)
+func (dc *DiscogsClient) ExportCollectionCSV(ctx context.Context, username string, w io.Writer, options *ExportCollectionCSVOptions) (int, error) {
+	var opts ExportCollectionCSVOptions
+	if options != nil {
+		opts = *options
+	}
+
+	fields, err := dc.CollectionFields(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+	customFields := slices.Clone(fields.Fields)
+	slices.SortStableFunc(customFields, func(a, b CollectionField) int { return cmp.Compare(a.Position, b.Position) })
+
+	header := slices.Clone(collectionCSVColumns)
+	for _, field := range customFields {
+		header = append(header, "Collection "+field.Name)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return 0, err
+	}
+
+	folderNames := map[int64]string{FolderUncategorized: "Uncategorized"}
+	folderName := func(ctx context.Context, folderID int64) (string, error) {
+		if name, ok := folderNames[folderID]; ok {
+			return name, nil
+		}
+		name := strconv.FormatInt(folderID, 10)
+		folder, err := dc.CollectionFolder(ctx, username, folderID)
+		var httpErr *HTTPError
+		switch {
+		case err == nil:
+			name = folder.Name
+		case errors.As(err, &httpErr) && (httpErr.StatusCode == http.StatusUnauthorized ||
+			httpErr.StatusCode == http.StatusForbidden || httpErr.StatusCode == http.StatusNotFound):
+			// Fall back to the ID of folders the user cannot see
+		default:
+			return "", err
+		}
+		folderNames[folderID] = name
+		return name, nil
+	}
+
+	var written int
+	itemOptions := &CollectionItemsOptions{Sort: opts.Sort, SortOrder: opts.SortOrder}
+	err = ForEachPage(ctx, dc.CollectionItemsPages(username, opts.FolderID, itemOptions), &IteratorOptions{PerPage: DefaultCrawlPerPage}, func(items []CollectionItem, _ *Pagination) error {
+		for _, item := range items {
+			folder, err := folderName(ctx, item.FolderID)
+			if err != nil {
+				return err
+			}
+			if err := cw.Write(collectionCSVRecord(&item, folder, customFields)); err != nil {
+				return err
+			}
+			written++
+		}
+		cw.Flush()
+		return cw.Error()
+	})
+	if err != nil {
+		return written, err
+	}
+
+	cw.Flush()
+	return written, cw.Error()
+}
+
+// collectionCSVRecord returns the CSV record of a collection item, in the folder with the given name.
+func collectionCSVRecord(item *CollectionItem, folder string, fields []CollectionField) []string {
+	var info BasicInformation
+	if item.BasicInformation != nil {
+		info = *item.BasicInformation
+	}
+
+	catNos := make([]string, 0, len(info.Labels))
+	labels := make([]string, 0, len(info.Labels))
+	for _, label := range info.Labels {
+		catNos = append(catNos, label.CatNo)
+		labels = append(labels, label.Name)
+	}
+
+	var rating, released, dateAdded string
+	if item.Rating > 0 {
+		rating = strconv.FormatInt(item.Rating, 10)
+	}
+	if info.Year != nil && *info.Year != 0 {
+		released = strconv.FormatInt(*info.Year, 10)
+	}
+	if item.DateAdded != nil {
+		dateAdded = item.DateAdded.Format(collectionDateAddedLayout)
+	}
+
+	record := []string{
+		strings.Join(catNos, ", "),
+		info.ArtistNames(),
+		info.Title,
+		strings.Join(labels, ", "),
+		formatDescriptions(info.Formats),
+		rating,
+		released,
+		strconv.FormatInt(item.ID, 10),
+		folder,
+		dateAdded,
+	}
+	for _, field := range fields {
+		var value string
+		if i := slices.IndexFunc(item.Notes, func(note FieldValue) bool { return note.FieldID == field.ID }); i >= 0 {
+			value = item.Notes[i].Value
+		}
+		record = append(record, value)
+	}
+	return record
+}
+
+// formatDescriptions returns the formats with their quantity and descriptions, the way Discogs displays them.
+//
+// Example: "2xVinyl, LP, Album + CD".
+func formatDescriptions(formats []Format) string {
+	parts := make([]string, 0, len(formats))
+	for _, format := range formats {
+		name := format.Name
+		if format.Qty != "" && format.Qty != "1" {
+			name = format.Qty + "x" + name
+		}
+		parts = append(parts, strings.Join(append([]string{name}, format.Descriptions...), ", "))
+	}
+	return strings.Join(parts, " + ")
+}
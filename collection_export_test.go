@@ -0,0 +1,61 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_ExportCollectionCSV(t *testing.T) {
+	var folderRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/users/user/collection/fields":
+			_, _ = rw.Write([]byte(`{"fields":[
+				{"id":3,"name":"Notes","type":"textarea","position":3},
+				{"id":1,"name":"Media Condition","type":"dropdown","position":1}
+			]}`))
+		case "/users/user/collection/folders/2":
+			folderRequests++
+			_, _ = rw.Write([]byte(`{"id":2,"name":"Jazz"}`))
+		case "/users/user/collection/folders/5":
+			rw.WriteHeader(http.StatusForbidden)
+		case "/users/user/collection/folders/0/releases":
+			assert.Equal(t, "added", req.URL.Query().Get("sort"))
+			_, _ = rw.Write([]byte(`{"pagination":{"page":1,"pages":1},"releases":[
+				{"id":10,"folder_id":2,"rating":5,"date_added":"2020-01-02T03:04:05-08:00",
+				 "basic_information":{"title":"Bookends","year":1968,
+					"artists":[{"name":"Simon","join":"&"},{"name":"Garfunkel"}],
+					"labels":[{"name":"Columbia","catno":"KCS 9529"}],
+					"formats":[{"name":"Vinyl","qty":"2","descriptions":["LP","Album"]},{"name":"CD","qty":"1"}]},
+				 "notes":[{"field_id":3,"value":"Signed, \"mint\""},{"field_id":1,"value":"Mint (M)"}]},
+				{"id":11,"folder_id":2,"basic_information":{"title":"Untitled"}},
+				{"id":12,"folder_id":1,"basic_information":{"title":"Other"}},
+				{"id":13,"folder_id":5,"basic_information":{"title":"Private"}}
+			]}`))
+		default:
+			t.Errorf("unexpected request to %s", req.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	var sb strings.Builder
+	n, err := client.ExportCollectionCSV(ctx, "user", &sb, &discogs.ExportCollectionCSVOptions{Sort: discogs.CollectionSortAdded})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, 1, folderRequests)
+	assert.Equal(t, `Catalog#,Artist,Title,Label,Format,Rating,Released,release_id,CollectionFolder,Date Added,Collection Media Condition,Collection Notes
+KCS 9529,Simon & Garfunkel,Bookends,Columbia,"2xVinyl, LP, Album + CD",5,1968,10,Jazz,2020-01-02 03:04:05,Mint (M),"Signed, ""mint"""
+,,Untitled,,,,,11,Jazz,,,
+,,Other,,,,,12,Uncategorized,,,
+,,Private,,,,,13,5,,,
+`, sb.String())
+}
@@ -0,0 +1,175 @@
+package discogs_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_CollectionFolder(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		requests = append(requests, req.Method+" "+req.URL.Path+" "+string(body))
+
+		switch {
+		case req.URL.Path == "/users/user/collection/folders/3":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"message":"Folder not found."}`))
+		case req.Method == http.MethodDelete:
+			rw.WriteHeader(http.StatusNoContent)
+		case req.Method == http.MethodPost:
+			_, _ = rw.Write([]byte(`{"id":2,"name":"Renamed","count":5}`))
+		default:
+			_, _ = rw.Write([]byte(`{"id":2,"name":"Folder","count":5}`))
+		}
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	folder, err := client.CollectionFolder(ctx, "user", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &discogs.CollectionFolderResponse{ID: 2, Name: "Folder", Count: 5}, folder)
+
+	folder, err = client.RenameCollectionFolder(ctx, "user", 2, "Renamed")
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed", folder.Name)
+
+	assert.NoError(t, client.DeleteCollectionFolder(ctx, "user", 2))
+
+	err = client.DeleteCollectionFolder(ctx, "user", 3)
+	assert.True(t, discogs.IsNotFound(err))
+
+	// The special folders are rejected without sending a request
+	_, err = client.RenameCollectionFolder(ctx, "user", discogs.FolderUncategorized, "Renamed")
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "folder_id", Value: "1"}, err)
+	err = client.DeleteCollectionFolder(ctx, "user", discogs.FolderAll)
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "folder_id", Value: "0"}, err)
+	_, err = client.RenameCollectionFolder(ctx, "user", 2, "")
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "name", Value: ""}, err)
+
+	assert.Equal(t, []string{
+		"GET /users/user/collection/folders/2 ",
+		`POST /users/user/collection/folders/2 {"name":"Renamed"}`,
+		"DELETE /users/user/collection/folders/2 ",
+		"DELETE /users/user/collection/folders/3 ",
+	}, requests)
+}
+
+func TestDiscogsClient_EditCollectionItemField(t *testing.T) {
+	const fieldsJSON = `{"fields":[
+		{"id":1,"name":"Media Condition","type":"dropdown","options":["Mint (M)","Near Mint (NM or M-)"]},
+		{"id":3,"name":"Notes","type":"textarea","lines":3}
+	]}`
+
+	type args struct {
+		fieldID int64
+		value   string
+	}
+	tests := []struct {
+		name string
+		args args
+		want error
+		// edited is the body of the edit request, if it is sent.
+		edited string
+	}{
+		{"dropdown option", args{1, "Mint (M)"}, nil, `{"value":"Mint (M)"}`},
+		{"textarea", args{3, "Signed"}, nil, `{"value":"Signed"}`},
+		{"unknown dropdown option", args{1, "Mint"}, &discogs.ErrInvalidOption{Option: "value", Value: "Mint"}, ""},
+		{"unknown field", args{2, "Signed"}, &discogs.ErrInvalidOption{Option: "field_id", Value: "2"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var edited string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/users/user/collection/fields" {
+					_, _ = rw.Write([]byte(fieldsJSON))
+					return
+				}
+
+				assert.Equal(t, http.MethodPost, req.Method)
+				assert.Equal(t, "/users/user/collection/folders/1/releases/2/instances/3/fields/"+strconv.FormatInt(tt.args.fieldID, 10), req.URL.Path)
+				body, _ := io.ReadAll(req.Body)
+				edited = string(body)
+				rw.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			token := "token"
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+			client.Host = server.URL
+
+			err := client.EditCollectionItemField(ctx, "user", 1, 2, 3, tt.args.fieldID, tt.args.value)
+			assert.Equal(t, tt.want, err)
+			assert.Equal(t, tt.edited, edited)
+		})
+	}
+}
+
+func TestDiscogsClient_PublicCollection(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.URL.Path+" "+req.Header.Get(discogs.AuthHeader))
+
+		switch req.URL.Path {
+		case "/users/private/collection/folders/0/releases":
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = rw.Write([]byte(`{"message":"You don't have permission to access this resource."}`))
+		case "/users/user/collection/fields":
+			_, _ = rw.Write([]byte(`{"fields":[{"id":1,"name":"Media Condition","type":"dropdown","public":true}]}`))
+		default:
+			_, _ = rw.Write([]byte(`{"pagination":{"page":1,"pages":1,"per_page":50,"items":1},"releases":[{"id":10,"instance_id":20}]}`))
+		}
+	}))
+	defer server.Close()
+
+	// Public collections can be browsed without credentials
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	res, err := client.PublicCollection(ctx, "user", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []discogs.CollectionItem{{ID: 10, InstanceID: 20}}, res.Releases)
+
+	_, err = client.CollectionItems(ctx, "user", discogs.FolderAll, nil)
+	assert.NoError(t, err)
+
+	fields, err := client.CollectionFields(ctx, "user")
+	assert.NoError(t, err)
+	assert.Len(t, fields.Fields, 1)
+
+	_, err = client.PublicCollection(ctx, "private", nil)
+	assert.Error(t, err)
+
+	// The other folders still require credentials
+	_, err = client.CollectionItems(ctx, "user", 2, nil)
+	assert.IsType(t, &discogs.ErrMissingCredentials{}, err)
+
+	// The owner still sends their token, except through PublicCollection
+	token := "token"
+	client = discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	_, err = client.CollectionItems(ctx, "user", discogs.FolderAll, nil)
+	assert.NoError(t, err)
+	_, err = client.PublicCollection(ctx, "user", nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/users/user/collection/folders/0/releases ",
+		"/users/user/collection/folders/0/releases ",
+		"/users/user/collection/fields ",
+		"/users/private/collection/folders/0/releases ",
+		"/users/user/collection/folders/0/releases Bearer token",
+		"/users/user/collection/folders/0/releases ",
+	}, requests)
+}
@@ -0,0 +1,123 @@
+package discogs
+
+// CollectionSort represents a field the items of a collection folder can be sorted by.
+type CollectionSort string
+
+// CollectionSort constants representing the fields the items of a collection folder can be sorted by.
+const (
+	CollectionSortLabel  CollectionSort = "label"
+	CollectionSortArtist CollectionSort = "artist"
+	CollectionSortTitle  CollectionSort = "title"
+	CollectionSortCatNo  CollectionSort = "catno"
+	CollectionSortFormat CollectionSort = "format"
+	CollectionSortRating CollectionSort = "rating"
+	CollectionSortAdded  CollectionSort = "added"
+	CollectionSortYear   CollectionSort = "year"
+)
+
+// FolderAll is the ID of the folder containing every item of a collection. It is the only folder that can be viewed
+// by other users, if the collection is public.
+const FolderAll int64 = 0
+
+// FolderUncategorized is the ID of the default folder of a collection, which releases are added to unless another
+// folder is chosen.
+const FolderUncategorized int64 = 1
+
+// CollectionFolderResponse represents the response from the Discogs API for a collection folder.
+type CollectionFolderResponse struct {
+	RawResponse
+	ExtraFields
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Count       int64  `json:"count"`
+	ResourceURL string `json:"resource_url"`
+}
+
+// CollectionItemsOptions represents the options for retrieving the items of a collection folder.
+type CollectionItemsOptions struct {
+	PaginationParams
+	Sort      CollectionSort `url:"sort,omitempty"`
+	SortOrder SortOrder      `url:"sort_order,omitempty"`
+}
+
+// CollectionItemsResponse represents the response from the Discogs API for the items of a collection folder.
+type CollectionItemsResponse struct {
+	RawResponse
+	ExtraFields
+	Pagination *Pagination      `json:"pagination,omitempty"`
+	Releases   []CollectionItem `json:"releases"`
+}
+
+// CollectionItem represents an instance of a release in a collection.
+type CollectionItem struct {
+	ID               int64             `json:"id"`
+	InstanceID       int64             `json:"instance_id"`
+	FolderID         int64             `json:"folder_id"`
+	Rating           int64             `json:"rating"`
+	DateAdded        *Timestamp        `json:"date_added,omitempty"`
+	BasicInformation *BasicInformation `json:"basic_information,omitempty"`
+	Notes            []FieldValue      `json:"notes"`
+}
+
+// BasicInformation represents the summary of a release included in collection and wantlist items.
+type BasicInformation struct {
+	ID          int64          `json:"id"`
+	MasterID    *int64         `json:"master_id,omitempty"`
+	Title       string         `json:"title"`
+	Year        *int64         `json:"year,omitempty"`
+	Artists     []ArtistCredit `json:"artists"`
+	Labels      []LabelCredit  `json:"labels"`
+	Formats     []Format       `json:"formats"`
+	Genres      []string       `json:"genres"`
+	Styles      []string       `json:"styles"`
+	Thumb       string         `json:"thumb"`
+	CoverImage  string         `json:"cover_image"`
+	ResourceURL string         `json:"resource_url"`
+}
+
+// FieldValue represents the value of a custom field of a collection item.
+type FieldValue struct {
+	FieldID int64  `json:"field_id"`
+	Value   string `json:"value"`
+}
+
+// CollectionFieldsResponse represents the response from the Discogs API for the custom fields of a collection.
+type CollectionFieldsResponse struct {
+	RawResponse
+	ExtraFields
+	Fields []CollectionField `json:"fields"`
+}
+
+// EditCollectionItemOptions represents the changes to an instance of a release in a collection. Unset fields are left
+// unchanged.
+type EditCollectionItemOptions struct {
+	// Rating is the rating of the instance, from 1 to 5, or 0 to remove the rating.
+	Rating *int `json:"rating,omitempty"`
+	// FolderID moves the instance to another folder.
+	FolderID *int64 `json:"folder_id,omitempty"`
+}
+
+// Collection field types.
+const (
+	FieldTypeDropdown = "dropdown"
+	FieldTypeTextarea = "textarea"
+)
+
+// CollectionField represents a custom field of a collection, such as the media condition of its items.
+type CollectionField struct {
+	ID       int64    `json:"id"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"` // Either FieldTypeDropdown or FieldTypeTextarea.
+	Position int64    `json:"position"`
+	Public   bool     `json:"public"`
+	Options  []string `json:"options,omitempty"` // Only set for dropdown fields.
+	Lines    *int64   `json:"lines,omitempty"`   // Only set for textarea fields.
+}
+
+// AddToFolderResponse represents the response from the Discogs API for adding a release to a collection folder.
+type AddToFolderResponse struct {
+	RawResponse
+	ExtraFields
+	InstanceID  int64  `json:"instance_id"`
+	ResourceURL string `json:"resource_url"`
+}
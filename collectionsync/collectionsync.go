@@ -0,0 +1,398 @@
+// Package collectionsync keeps a local snapshot of a Discogs collection in sync with the remote collection, which is
+// the core of collection apps that work offline. A Syncer diffs the snapshot held by a Store against the remote
+// collection, and applies the diff in either direction: Pull updates the snapshot, Push updates the remote collection.
+//
+// Collection instances are matched by instance ID. Instances added to the snapshot while offline have no instance ID
+// yet; Push adds them to the remote collection and records their new instance ID in the snapshot.
+package collectionsync
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/couwuch/discogs"
+)
+
+// Item represents an instance of a release in a collection snapshot.
+type Item struct {
+	// InstanceID identifies the instance. It is 0 for instances added to the snapshot that were not pushed yet.
+	InstanceID int64 `json:"instance_id"`
+	ReleaseID  int64 `json:"release_id"`
+	FolderID   int64 `json:"folder_id"`
+	// Rating is the rating of the instance, from 1 to 5, or 0 if it is not rated.
+	Rating int `json:"rating"`
+	// Fields holds the values of the custom fields of the instance, keyed by field ID.
+	Fields map[int64]string `json:"fields,omitempty"`
+}
+
+// equal reports whether two items of the same instance have the same folder, rating and field values.
+func (i Item) equal(other Item) bool {
+	return i.FolderID == other.FolderID && i.Rating == other.Rating && maps.Equal(i.Fields, other.Fields)
+}
+
+// itemFromCollection returns the item of a remote collection instance.
+func itemFromCollection(item discogs.CollectionItem) Item {
+	res := Item{InstanceID: item.InstanceID, ReleaseID: item.ID, FolderID: item.FolderID, Rating: int(item.Rating)}
+	if len(item.Notes) > 0 {
+		res.Fields = make(map[int64]string, len(item.Notes))
+		for _, note := range item.Notes {
+			res.Fields[note.FieldID] = note.Value
+		}
+	}
+	return res
+}
+
+// A Store holds a collection snapshot. Implementations must be safe for concurrent use.
+type Store interface {
+	// Items returns all items of the snapshot.
+	Items(ctx context.Context) ([]Item, error)
+	// PutItem adds an item, or replaces the item with the same instance ID. Items without an instance ID are always
+	// added.
+	PutItem(ctx context.Context, item Item) error
+	// DeleteItem removes the item with the given instance ID and release ID. Both are given so that items without an
+	// instance ID can be told apart by their release. It does nothing if there is no such item.
+	DeleteItem(ctx context.Context, instanceID, releaseID int64) error
+}
+
+// MemoryStore is a Store that keeps the snapshot in memory.
+type MemoryStore struct {
+	mu    sync.Mutex
+	items []Item
+}
+
+// NewMemoryStore creates a new MemoryStore holding the given items.
+func NewMemoryStore(items ...Item) *MemoryStore {
+	return &MemoryStore{items: slices.Clone(items)}
+}
+
+// Items returns all items of the snapshot.
+func (s *MemoryStore) Items(_ context.Context) ([]Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.items), nil
+}
+
+// PutItem adds an item, or replaces the item with the same instance ID.
+func (s *MemoryStore) PutItem(_ context.Context, item Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if item.InstanceID != 0 {
+		if i := slices.IndexFunc(s.items, func(it Item) bool { return it.InstanceID == item.InstanceID }); i >= 0 {
+			s.items[i] = item
+			return nil
+		}
+	}
+	s.items = append(s.items, item)
+	return nil
+}
+
+// DeleteItem removes the first item with the given instance ID and release ID.
+func (s *MemoryStore) DeleteItem(_ context.Context, instanceID, releaseID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := slices.IndexFunc(s.items, func(it Item) bool { return it.InstanceID == instanceID && it.ReleaseID == releaseID }); i >= 0 {
+		s.items = slices.Delete(s.items, i, i+1)
+	}
+	return nil
+}
+
+// Change represents an instance whose folder, rating or field values differ between the snapshot and the remote
+// collection.
+type Change struct {
+	Local  Item
+	Remote Item
+}
+
+// Diff represents the differences between a collection snapshot and the remote collection.
+type Diff struct {
+	// Added are the instances of the remote collection missing from the snapshot.
+	Added []Item
+	// Removed are the instances of the snapshot missing from the remote collection, including the instances added to
+	// the snapshot that were not pushed yet.
+	Removed []Item
+	// Changed are the instances that differ between the snapshot and the remote collection.
+	Changed []Change
+}
+
+// Empty reports whether the snapshot and the remote collection are in sync.
+func (d *Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// ApplyOptions represents the options for applying a Diff.
+type ApplyOptions struct {
+	// MaxWrites is the maximum number of remote writes a Push sends, so that a large push can be spread over several
+	// calls and rate limit windows. Every call to Push diffs again, so it resumes where the previous one stopped. All
+	// writes are sent if 0. It does not apply to Pull, which only writes to the Store.
+	MaxWrites int
+	// DryRun computes the diff without applying it.
+	DryRun bool
+}
+
+// Result represents the outcome of a sync.
+type Result struct {
+	Diff *Diff
+	// Writes is the number of writes sent to the remote collection by a Push.
+	Writes int
+	// Complete reports whether the whole diff was applied. It is false for dry runs and for pushes stopped by
+	// ApplyOptions.MaxWrites.
+	Complete bool
+}
+
+// A Syncer syncs the collection snapshot held by a Store with the remote collection of a user. Every request goes
+// through the client, so it respects the rate limit.
+type Syncer struct {
+	client   *discogs.DiscogsClient
+	username string
+	store    Store
+}
+
+// New creates a new Syncer for the collection of a user. Pushing requires the client to be authenticated as that
+// user.
+func New(client *discogs.DiscogsClient, username string, store Store) *Syncer {
+	return &Syncer{client: client, username: username, store: store}
+}
+
+// Diff fetches the remote collection and compares it with the snapshot.
+func (s *Syncer) Diff(ctx context.Context) (*Diff, error) {
+	var remote []Item
+	pages := s.client.CollectionItemsPages(s.username, discogs.FolderAll, nil)
+	err := discogs.ForEachPage(ctx, pages, &discogs.IteratorOptions{PerPage: discogs.DefaultCrawlPerPage}, func(items []discogs.CollectionItem, _ *discogs.Pagination) error {
+		for _, item := range items {
+			remote = append(remote, itemFromCollection(item))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := s.store.Items(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff(local, remote), nil
+}
+
+// diff compares the local items with the remote items. The items of the diff are ordered by instance ID, and then by
+// release ID.
+func diff(local, remote []Item) *Diff {
+	remoteByID := make(map[int64]Item, len(remote))
+	for _, item := range remote {
+		remoteByID[item.InstanceID] = item
+	}
+
+	var d Diff
+	seen := make(map[int64]bool, len(local))
+	for _, item := range local {
+		remoteItem, ok := remoteByID[item.InstanceID]
+		switch {
+		case item.InstanceID == 0 || !ok:
+			d.Removed = append(d.Removed, item)
+		case !item.equal(remoteItem):
+			d.Changed = append(d.Changed, Change{Local: item, Remote: remoteItem})
+		}
+		seen[item.InstanceID] = true
+	}
+	for _, item := range remote {
+		if !seen[item.InstanceID] {
+			d.Added = append(d.Added, item)
+		}
+	}
+
+	byInstance := func(a, b Item) int {
+		return cmp.Or(cmp.Compare(a.InstanceID, b.InstanceID), cmp.Compare(a.ReleaseID, b.ReleaseID))
+	}
+	slices.SortFunc(d.Added, byInstance)
+	slices.SortFunc(d.Removed, byInstance)
+	slices.SortFunc(d.Changed, func(a, b Change) int { return byInstance(a.Local, b.Local) })
+	return &d
+}
+
+// Pull diffs the snapshot against the remote collection and updates the snapshot to match it. If options is nil, the
+// default options are used.
+func (s *Syncer) Pull(ctx context.Context, options *ApplyOptions) (*Result, error) {
+	var opts ApplyOptions
+	if options != nil {
+		opts = *options
+	}
+
+	d, err := s.Diff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Diff: d}
+	if opts.DryRun {
+		return res, nil
+	}
+
+	for _, item := range d.Added {
+		if err := s.store.PutItem(ctx, item); err != nil {
+			return res, err
+		}
+	}
+	for _, item := range d.Removed {
+		if err := s.store.DeleteItem(ctx, item.InstanceID, item.ReleaseID); err != nil {
+			return res, err
+		}
+	}
+	for _, change := range d.Changed {
+		if err := s.store.PutItem(ctx, change.Remote); err != nil {
+			return res, err
+		}
+	}
+
+	res.Complete = true
+	return res, nil
+}
+
+// Push diffs the snapshot against the remote collection and updates the remote collection to match it: instances
+// missing from the snapshot are removed, instances missing from the remote collection are added, and changed
+// instances are moved, rated and have their fields edited. Instances added remotely get a new instance ID, which is
+// recorded in the snapshot. If options is nil, the default options are used.
+//
+// Writes are sent one at a time through the client, and the snapshot is updated after every write, so a push that
+// fails midway can be resumed by pushing again.
+func (s *Syncer) Push(ctx context.Context, options *ApplyOptions) (*Result, error) {
+	var opts ApplyOptions
+	if options != nil {
+		opts = *options
+	}
+
+	d, err := s.Diff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Diff: d}
+	if opts.DryRun {
+		return res, nil
+	}
+
+	// write sends a write unless MaxWrites is reached, and reports whether it was sent.
+	write := func(fn func() error) (bool, error) {
+		if opts.MaxWrites > 0 && res.Writes >= opts.MaxWrites {
+			return false, nil
+		}
+		res.Writes++
+		return true, fn()
+	}
+
+	// The field definitions are fetched on the first field edit only, and validate the values of all edits
+	var fields *discogs.CollectionFieldsResponse
+	editField := func(item Item, fieldID int64, value string) error {
+		if fields == nil {
+			var err error
+			if fields, err = s.client.CollectionFields(ctx, s.username); err != nil {
+				return err
+			}
+		}
+		if err := fields.CheckValue(fieldID, value); err != nil {
+			return err
+		}
+		return s.client.SetCollectionItemField(ctx, s.username, item.FolderID, item.ReleaseID, item.InstanceID, fieldID, value)
+	}
+
+	for _, item := range d.Added {
+		sent, err := write(func() error {
+			return s.client.RemoveFromCollectionFolder(ctx, s.username, item.FolderID, item.ReleaseID, item.InstanceID)
+		})
+		if !sent || err != nil {
+			return res, err
+		}
+	}
+
+	for _, item := range d.Removed {
+		if ok, err := s.pushAdd(ctx, item, write, editField); !ok || err != nil {
+			return res, err
+		}
+	}
+
+	for _, change := range d.Changed {
+		if ok, err := s.pushChange(ctx, change, write, editField); !ok || err != nil {
+			return res, err
+		}
+	}
+
+	res.Complete = true
+	return res, nil
+}
+
+// pushAdd adds a local item to the remote collection, along with its rating and field values, and records its new
+// instance ID in the snapshot. It reports whether all writes were sent.
+func (s *Syncer) pushAdd(ctx context.Context, item Item, write func(func() error) (bool, error), editField func(Item, int64, string) error) (bool, error) {
+	var added *discogs.AddToFolderResponse
+	sent, err := write(func() (err error) {
+		added, err = s.client.AddToCollectionFolder(ctx, s.username, item.FolderID, item.ReleaseID)
+		return err
+	})
+	if !sent || err != nil {
+		return false, err
+	}
+
+	// Record the new instance right away, so it is not added twice if the push is resumed; its rating and field values
+	// are then pushed as a change
+	if err := s.store.DeleteItem(ctx, item.InstanceID, item.ReleaseID); err != nil {
+		return false, err
+	}
+	local := item
+	local.InstanceID = added.InstanceID
+	if err := s.store.PutItem(ctx, local); err != nil {
+		return false, err
+	}
+
+	remote := Item{InstanceID: added.InstanceID, ReleaseID: item.ReleaseID, FolderID: item.FolderID}
+	return s.pushChange(ctx, Change{Local: local, Remote: remote}, write, editField)
+}
+
+// pushChange applies the folder, rating and field values of a local item to the remote instance. It reports whether
+// all writes were sent. The field values are edited with editField.
+func (s *Syncer) pushChange(ctx context.Context, change Change, write func(func() error) (bool, error), editField func(Item, int64, string) error) (bool, error) {
+	local, remote := change.Local, change.Remote
+
+	var edit discogs.EditCollectionItemOptions
+	if local.Rating != remote.Rating {
+		edit.Rating = discogs.Int(local.Rating)
+	}
+	if local.FolderID != remote.FolderID {
+		edit.FolderID = discogs.Int64(local.FolderID)
+	}
+	if edit.Rating != nil || edit.FolderID != nil {
+		sent, err := write(func() error {
+			return s.client.EditCollectionItem(ctx, s.username, remote.FolderID, remote.ReleaseID, remote.InstanceID, &edit)
+		})
+		if !sent || err != nil {
+			return false, err
+		}
+	}
+
+	// Fields missing from the snapshot are cleared remotely
+	var fieldIDs []int64
+	for fieldID := range local.Fields {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	for fieldID := range remote.Fields {
+		if _, ok := local.Fields[fieldID]; !ok {
+			fieldIDs = append(fieldIDs, fieldID)
+		}
+	}
+	slices.Sort(fieldIDs)
+
+	for _, fieldID := range fieldIDs {
+		value := local.Fields[fieldID]
+		if remote.Fields[fieldID] == value {
+			continue
+		}
+		sent, err := write(func() error {
+			return editField(local, fieldID, value)
+		})
+		if !sent || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
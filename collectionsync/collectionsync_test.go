@@ -0,0 +1,206 @@
+package collectionsync_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/couwuch/discogs/collectionsync"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+// remoteCollection mocks the collection endpoints of a user, holding the remote instances keyed by instance ID.
+type remoteCollection struct {
+	mu       sync.Mutex
+	items    map[int64]discogs.CollectionItem
+	nextID   int64
+	requests []string
+	// fieldLookups is the number of requests for the field definitions.
+	fieldLookups int
+}
+
+func (c *remoteCollection) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+	if req.Method != http.MethodGet {
+		c.requests = append(c.requests, req.Method+" "+req.URL.Path+" "+string(body))
+	}
+
+	var folderID, releaseID, instanceID, fieldID int64
+	switch {
+	case req.URL.Path == "/users/user/collection/fields":
+		c.fieldLookups++
+		_, _ = rw.Write([]byte(`{"fields":[
+			{"id":1,"name":"Media Condition","type":"dropdown","options":["Mint (M)"]},
+			{"id":2,"name":"Notes","type":"textarea"}
+		]}`))
+	case req.URL.Path == "/users/user/collection/folders/0/releases":
+		var items []discogs.CollectionItem
+		for id := int64(1); id < c.nextID; id++ {
+			if item, ok := c.items[id]; ok {
+				items = append(items, item)
+			}
+		}
+		_ = json.NewEncoder(rw).Encode(discogs.CollectionItemsResponse{Pagination: &discogs.Pagination{Page: 1, Pages: 1}, Releases: items})
+	case scan(req.URL.Path, "/users/user/collection/folders/%d/releases/%d/instances/%d/fields/%d", &folderID, &releaseID, &instanceID, &fieldID):
+		var edit struct{ Value string }
+		_ = json.Unmarshal(body, &edit)
+		item := c.items[instanceID]
+		item.Notes = append(item.Notes, discogs.FieldValue{FieldID: fieldID, Value: edit.Value})
+		c.items[instanceID] = item
+	case scan(req.URL.Path, "/users/user/collection/folders/%d/releases/%d/instances/%d", &folderID, &releaseID, &instanceID):
+		if req.Method == http.MethodDelete {
+			delete(c.items, instanceID)
+			return
+		}
+		var edit discogs.EditCollectionItemOptions
+		_ = json.Unmarshal(body, &edit)
+		item := c.items[instanceID]
+		if edit.Rating != nil {
+			item.Rating = int64(*edit.Rating)
+		}
+		if edit.FolderID != nil {
+			item.FolderID = *edit.FolderID
+		}
+		c.items[instanceID] = item
+	case scan(req.URL.Path, "/users/user/collection/folders/%d/releases/%d", &folderID, &releaseID):
+		c.items[c.nextID] = discogs.CollectionItem{ID: releaseID, InstanceID: c.nextID, FolderID: folderID}
+		_ = json.NewEncoder(rw).Encode(discogs.AddToFolderResponse{InstanceID: c.nextID})
+		c.nextID++
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// scan reports whether path matches format exactly, storing the matched numbers in args.
+func scan(path, format string, args ...any) bool {
+	n, err := fmt.Sscanf(path, format, args...)
+	return err == nil && n == len(args)
+}
+
+func newRemote(t *testing.T, items ...discogs.CollectionItem) (*remoteCollection, *discogs.DiscogsClient) {
+	remote := &remoteCollection{items: make(map[int64]discogs.CollectionItem), nextID: 1}
+	for _, item := range items {
+		remote.items[item.InstanceID] = item
+		remote.nextID = max(remote.nextID, item.InstanceID+1)
+	}
+
+	server := httptest.NewServer(remote)
+	t.Cleanup(server.Close)
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+	return remote, client
+}
+
+func TestSyncer_Pull(t *testing.T) {
+	_, client := newRemote(t,
+		discogs.CollectionItem{ID: 10, InstanceID: 1, FolderID: 1, Rating: 4},
+		discogs.CollectionItem{ID: 20, InstanceID: 2, FolderID: 1, Notes: []discogs.FieldValue{{FieldID: 1, Value: "Mint (M)"}}},
+	)
+
+	store := collectionsync.NewMemoryStore(
+		collectionsync.Item{InstanceID: 1, ReleaseID: 10, FolderID: 1},
+		collectionsync.Item{InstanceID: 5, ReleaseID: 50, FolderID: 1},
+	)
+	syncer := collectionsync.New(client, "user", store)
+
+	res, err := syncer.Pull(ctx, &collectionsync.ApplyOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.False(t, res.Complete)
+	assert.Equal(t, &collectionsync.Diff{
+		Added:   []collectionsync.Item{{InstanceID: 2, ReleaseID: 20, FolderID: 1, Fields: map[int64]string{1: "Mint (M)"}}},
+		Removed: []collectionsync.Item{{InstanceID: 5, ReleaseID: 50, FolderID: 1}},
+		Changed: []collectionsync.Change{{
+			Local:  collectionsync.Item{InstanceID: 1, ReleaseID: 10, FolderID: 1},
+			Remote: collectionsync.Item{InstanceID: 1, ReleaseID: 10, FolderID: 1, Rating: 4},
+		}},
+	}, res.Diff)
+
+	res, err = syncer.Pull(ctx, nil)
+	assert.NoError(t, err)
+	assert.True(t, res.Complete)
+
+	d, err := syncer.Diff(ctx)
+	assert.NoError(t, err)
+	assert.True(t, d.Empty())
+}
+
+func TestSyncer_Push(t *testing.T) {
+	remote, client := newRemote(t,
+		discogs.CollectionItem{ID: 10, InstanceID: 1, FolderID: 1},
+		discogs.CollectionItem{ID: 20, InstanceID: 2, FolderID: 1},
+	)
+
+	store := collectionsync.NewMemoryStore(
+		collectionsync.Item{InstanceID: 1, ReleaseID: 10, FolderID: 1, Rating: 4},
+		collectionsync.Item{ReleaseID: 30, FolderID: 1, Fields: map[int64]string{1: "Mint (M)"}},
+	)
+	syncer := collectionsync.New(client, "user", store)
+
+	// The push is spread over two calls
+	res, err := syncer.Push(ctx, &collectionsync.ApplyOptions{MaxWrites: 2})
+	assert.NoError(t, err)
+	assert.False(t, res.Complete)
+	assert.Equal(t, 2, res.Writes)
+
+	res, err = syncer.Push(ctx, nil)
+	assert.NoError(t, err)
+	assert.True(t, res.Complete)
+	assert.Equal(t, 2, res.Writes)
+
+	assert.Equal(t, []string{
+		"DELETE /users/user/collection/folders/1/releases/20/instances/2 ",
+		"POST /users/user/collection/folders/1/releases/30 ",
+		// The second push diffs again, so the new instance comes after instance 1
+		`POST /users/user/collection/folders/1/releases/10/instances/1 {"rating":4}`,
+		`POST /users/user/collection/folders/1/releases/30/instances/3/fields/1 {"value":"Mint (M)"}`,
+	}, remote.requests)
+
+	items, err := store.Items(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []collectionsync.Item{
+		{InstanceID: 1, ReleaseID: 10, FolderID: 1, Rating: 4},
+		{InstanceID: 3, ReleaseID: 30, FolderID: 1, Fields: map[int64]string{1: "Mint (M)"}},
+	}, items)
+
+	d, err := syncer.Diff(ctx)
+	assert.NoError(t, err)
+	assert.True(t, d.Empty())
+}
+
+func TestSyncer_PushFields(t *testing.T) {
+	remote, client := newRemote(t,
+		discogs.CollectionItem{ID: 10, InstanceID: 1, FolderID: 1},
+		discogs.CollectionItem{ID: 20, InstanceID: 2, FolderID: 1},
+	)
+
+	store := collectionsync.NewMemoryStore(
+		collectionsync.Item{InstanceID: 1, ReleaseID: 10, FolderID: 1, Fields: map[int64]string{1: "Mint (M)", 2: "Signed"}},
+		collectionsync.Item{InstanceID: 2, ReleaseID: 20, FolderID: 1, Fields: map[int64]string{2: "Sealed"}},
+	)
+	syncer := collectionsync.New(client, "user", store)
+
+	res, err := syncer.Push(ctx, nil)
+	assert.NoError(t, err)
+	assert.True(t, res.Complete)
+	assert.Equal(t, 3, res.Writes)
+	assert.Equal(t, 1, remote.fieldLookups, "the field definitions must be fetched once per push")
+
+	// Invalid values are rejected before any edit is sent
+	assert.NoError(t, store.PutItem(ctx, collectionsync.Item{InstanceID: 1, ReleaseID: 10, FolderID: 1, Fields: map[int64]string{1: "Mint"}}))
+	_, err = syncer.Push(ctx, nil)
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "value", Value: "Mint"}, err)
+	assert.Len(t, remote.requests, 3)
+}
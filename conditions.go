@@ -0,0 +1,149 @@
+package discogs
+
+import (
+	"slices"
+	"strings"
+)
+
+// Condition represents the grade of the media of a marketplace item, following the Goldmine grading standard used by
+// Discogs.
+type Condition string
+
+// Condition constants representing the media grades accepted by the Discogs marketplace, from best to worst.
+const (
+	ConditionMint         Condition = "Mint (M)"
+	ConditionNearMint     Condition = "Near Mint (NM or M-)"
+	ConditionVeryGoodPlus Condition = "Very Good Plus (VG+)"
+	ConditionVeryGood     Condition = "Very Good (VG)"
+	ConditionGoodPlus     Condition = "Good Plus (G+)"
+	ConditionGood         Condition = "Good (G)"
+	ConditionFair         Condition = "Fair (F)"
+	ConditionPoor         Condition = "Poor (P)"
+)
+
+// SleeveCondition represents the grade of the sleeve of a marketplace item. Besides the media grades, a sleeve can be
+// generic, missing or left ungraded.
+type SleeveCondition string
+
+// SleeveCondition constants representing the sleeve grades accepted by the Discogs marketplace, from best to worst,
+// followed by the sleeves that are not graded.
+const (
+	SleeveConditionMint         SleeveCondition = "Mint (M)"
+	SleeveConditionNearMint     SleeveCondition = "Near Mint (NM or M-)"
+	SleeveConditionVeryGoodPlus SleeveCondition = "Very Good Plus (VG+)"
+	SleeveConditionVeryGood     SleeveCondition = "Very Good (VG)"
+	SleeveConditionGoodPlus     SleeveCondition = "Good Plus (G+)"
+	SleeveConditionGood         SleeveCondition = "Good (G)"
+	SleeveConditionFair         SleeveCondition = "Fair (F)"
+	SleeveConditionPoor         SleeveCondition = "Poor (P)"
+	SleeveConditionGeneric      SleeveCondition = "Generic"
+	SleeveConditionNotGraded    SleeveCondition = "Not Graded"
+	SleeveConditionNoCover      SleeveCondition = "No Cover"
+)
+
+// conditions lists the media grades from best to worst.
+var conditions = []Condition{
+	ConditionMint, ConditionNearMint, ConditionVeryGoodPlus, ConditionVeryGood, ConditionGoodPlus, ConditionGood,
+	ConditionFair, ConditionPoor,
+}
+
+// sleeveConditions lists the sleeve grades from best to worst, followed by the sleeves that are not graded.
+var sleeveConditions = []SleeveCondition{
+	SleeveConditionMint, SleeveConditionNearMint, SleeveConditionVeryGoodPlus, SleeveConditionVeryGood,
+	SleeveConditionGoodPlus, SleeveConditionGood, SleeveConditionFair, SleeveConditionPoor, SleeveConditionGeneric,
+	SleeveConditionNotGraded, SleeveConditionNoCover,
+}
+
+// Conditions returns the media grades accepted by the Discogs marketplace, from best to worst.
+func Conditions() []Condition {
+	return slices.Clone(conditions)
+}
+
+// SleeveConditions returns the sleeve grades accepted by the Discogs marketplace, from best to worst, followed by the
+// sleeves that are not graded.
+func SleeveConditions() []SleeveCondition {
+	return slices.Clone(sleeveConditions)
+}
+
+// IsValid reports whether the condition is a media grade accepted by the Discogs marketplace.
+func (c Condition) IsValid() bool {
+	return c.Rank() >= 0
+}
+
+// Rank returns the rank of the condition, where higher is better, or -1 if the condition is not known, so conditions
+// can be compared: ConditionMint has the highest rank and ConditionPoor a rank of 0.
+func (c Condition) Rank() int {
+	i := slices.Index(conditions, c)
+	if i < 0 {
+		return -1
+	}
+	return len(conditions) - 1 - i
+}
+
+// Abbreviation returns the short form of the condition, such as "VG+", or an empty string if the condition is not
+// known.
+func (c Condition) Abbreviation() string {
+	if !c.IsValid() {
+		return ""
+	}
+	return conditionAbbreviations(string(c))[0]
+}
+
+// IsValid reports whether the condition is a sleeve grade accepted by the Discogs marketplace.
+func (c SleeveCondition) IsValid() bool {
+	return slices.Contains(sleeveConditions, c)
+}
+
+// IsGraded reports whether the sleeve condition is a grade, as opposed to a generic, missing or ungraded sleeve.
+func (c SleeveCondition) IsGraded() bool {
+	return Condition(c).IsValid()
+}
+
+// ParseCondition returns the media grade matching s, which is either the name of the grade, such as
+// "Very Good Plus (VG+)", or its abbreviation, such as "VG+", "NM" or "M-". The match ignores case and surrounding
+// spaces, so conditions entered by hand, such as in custom fields or CSV files, can be normalized. It returns an
+// ErrInvalidOption if s does not match a grade.
+func ParseCondition(s string) (Condition, error) {
+	key := strings.ToLower(strings.TrimSpace(s))
+	for _, condition := range conditions {
+		if conditionMatches(string(condition), key) {
+			return condition, nil
+		}
+	}
+	return "", &ErrInvalidOption{Option: "condition", Value: s}
+}
+
+// ParseSleeveCondition returns the sleeve grade matching s, the same way ParseCondition does for media grades. It
+// returns an ErrInvalidOption if s does not match a grade.
+func ParseSleeveCondition(s string) (SleeveCondition, error) {
+	key := strings.ToLower(strings.TrimSpace(s))
+	for _, condition := range sleeveConditions {
+		if conditionMatches(string(condition), key) {
+			return condition, nil
+		}
+	}
+	return "", &ErrInvalidOption{Option: "sleeve condition", Value: s}
+}
+
+// conditionMatches reports whether the lowercase key is the name of a grade or one of its abbreviations.
+func conditionMatches(name, key string) bool {
+	if strings.ToLower(name) == key {
+		return true
+	}
+	for _, abbreviation := range conditionAbbreviations(name) {
+		if strings.ToLower(abbreviation) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionAbbreviations returns the abbreviations of a grade, which are listed in parentheses after its name, such
+// as "NM" and "M-" for "Near Mint (NM or M-)". Grades without abbreviations return nil.
+func conditionAbbreviations(name string) []string {
+	_, rest, ok := strings.Cut(name, "(")
+	if !ok {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(rest, ")"), " or ")
+}
@@ -0,0 +1,79 @@
+package discogs_test
+
+import (
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCondition(t *testing.T) {
+	type want struct {
+		condition discogs.Condition
+		err       error
+	}
+	tests := []struct {
+		name string
+		args string
+		want want
+	}{
+		{
+			"ParseCondition name",
+			"Very Good Plus (VG+)",
+			want{discogs.ConditionVeryGoodPlus, nil},
+		},
+		{
+			"ParseCondition abbreviation",
+			" vg+ ",
+			want{discogs.ConditionVeryGoodPlus, nil},
+		},
+		{
+			"ParseCondition alternative abbreviation",
+			"M-",
+			want{discogs.ConditionNearMint, nil},
+		},
+		{
+			"ParseCondition sleeve only",
+			"Generic",
+			want{"", &discogs.ErrInvalidOption{Option: "condition", Value: "Generic"}},
+		},
+		{
+			"ParseCondition unknown",
+			"Very Good Plus",
+			want{"", &discogs.ErrInvalidOption{Option: "condition", Value: "Very Good Plus"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := discogs.ParseCondition(tt.args)
+			assert.Equal(t, tt.want.err, err)
+			assert.Equal(t, tt.want.condition, condition)
+		})
+	}
+}
+
+func TestParseSleeveCondition(t *testing.T) {
+	condition, err := discogs.ParseSleeveCondition("not graded")
+	assert.NoError(t, err)
+	assert.Equal(t, discogs.SleeveConditionNotGraded, condition)
+	assert.False(t, condition.IsGraded())
+
+	condition, err = discogs.ParseSleeveCondition("NM")
+	assert.NoError(t, err)
+	assert.Equal(t, discogs.SleeveConditionNearMint, condition)
+	assert.True(t, condition.IsGraded())
+
+	_, err = discogs.ParseSleeveCondition("Torn")
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "sleeve condition", Value: "Torn"}, err)
+}
+
+func TestCondition_Rank(t *testing.T) {
+	assert.Greater(t, discogs.ConditionMint.Rank(), discogs.ConditionNearMint.Rank())
+	assert.Greater(t, discogs.ConditionVeryGood.Rank(), discogs.ConditionGoodPlus.Rank())
+	assert.Equal(t, 0, discogs.ConditionPoor.Rank())
+	assert.Equal(t, -1, discogs.Condition("Very Good Plus").Rank())
+	assert.Equal(t, "NM", discogs.ConditionNearMint.Abbreviation())
+	assert.Len(t, discogs.Conditions(), 8)
+	assert.Len(t, discogs.SleeveConditions(), 11)
+}
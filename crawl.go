@@ -0,0 +1,205 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// DefaultCrawlPerPage is the default number of items requested per page by a crawl. It is the maximum allowed by the
+// Discogs API, so crawls take as few requests as possible.
+const DefaultCrawlPerPage = 100
+
+// CrawlCheckpoint records the progress of a crawl so it can be resumed later. It can be serialized as JSON.
+type CrawlCheckpoint struct {
+	// Page is the page containing the next item to process.
+	Page int `json:"page"`
+	// Offset is the index of the next item to process within Page.
+	Offset int `json:"offset"`
+	// PerPage is the page size of the crawl. A crawl must be resumed with the same page size to land on the same items.
+	PerPage int `json:"per_page"`
+}
+
+// ArtistCrawlOptions represents the options for crawling the discography of an artist.
+type ArtistCrawlOptions struct {
+	// Roles only includes releases the artist has one of the given roles on. All releases are included if empty.
+	Roles []ArtistRole
+	// ResolveMasters resolves master releases to their main release, setting the ReleaseID of their entries.
+	ResolveMasters bool
+	// Sort and SortOrder set the order the releases are crawled in. The order must not change when resuming a crawl.
+	Sort      ArtistReleaseSort
+	SortOrder SortOrder
+	// PerPage is the number of releases requested per page. DefaultCrawlPerPage is used if unset, or the page size of
+	// Checkpoint when resuming.
+	PerPage int
+	// Checkpoint resumes a previous crawl from the given checkpoint, if set.
+	Checkpoint *CrawlCheckpoint
+	// OnCheckpoint is called with the progress of the crawl after every processed release, including releases that
+	// are filtered out. The latest checkpoint can be persisted and passed as Checkpoint to resume the crawl.
+	OnCheckpoint func(checkpoint CrawlCheckpoint)
+}
+
+// DiscographyEntry represents a release or master release found while crawling a discography.
+type DiscographyEntry struct {
+	ArtistRelease
+	// ReleaseID is the ID of a specific release of the entry. It is the ID of the entry itself for releases, and the
+	// main release of master releases if ResolveMasters is set. It is zero for unresolved master releases.
+	ReleaseID int64
+}
+
+// CrawlArtistDiscography walks all pages of the releases of an artist and streams them to fn one at a time, so even
+// discographies with tens of thousands of credits are processed with flat memory usage. Releases can be filtered by
+// the role of the artist, and master releases can be resolved to their main release. If options is nil, the default
+// options are used.
+//
+// The crawl stops when fn returns an error, which is returned by CrawlArtistDiscography unless it is ErrStopPaging.
+// Long crawls can be resumed after an interruption by persisting the checkpoints passed to OnCheckpoint.
+func (dc *DiscogsClient) CrawlArtistDiscography(ctx context.Context, artistID int64, options *ArtistCrawlOptions, fn func(entry DiscographyEntry) error) error {
+	var opts ArtistCrawlOptions
+	if options != nil {
+		opts = *options
+	}
+
+	fetch := dc.ArtistReleasesPages(artistID, &ArtistReleasesOptions{Sort: opts.Sort, SortOrder: opts.SortOrder})
+	return crawl(ctx, fetch, opts.PerPage, opts.Checkpoint, opts.OnCheckpoint, func(release ArtistRelease) error {
+		if len(opts.Roles) > 0 && !slices.Contains(opts.Roles, release.Role) {
+			return nil
+		}
+
+		entry := DiscographyEntry{ArtistRelease: release}
+		switch {
+		case release.Type != TypeMaster:
+			entry.ReleaseID = release.ID
+		case opts.ResolveMasters && release.MainRelease != nil:
+			entry.ReleaseID = *release.MainRelease
+		case opts.ResolveMasters:
+			master, err := dc.Master(ctx, release.ID)
+			if err != nil {
+				return err
+			}
+			entry.ReleaseID = master.MainRelease
+		}
+
+		return fn(entry)
+	})
+}
+
+// LabelCrawlOptions represents the options for crawling the catalog of a label.
+type LabelCrawlOptions struct {
+	// IncludeSublabels also crawls the sublabels of the label, recursively.
+	IncludeSublabels bool
+	// MaxDepth limits how deep sublabels are recursed into, where 1 only includes the direct sublabels of the label.
+	// Sublabels are recursed into without limit if unset.
+	MaxDepth int
+	// PerPage is the number of releases requested per page. DefaultCrawlPerPage is used if unset.
+	PerPage int
+}
+
+// LabelCatalogEntry represents a release found while crawling the catalog of a label.
+type LabelCatalogEntry struct {
+	LabelRelease
+	// LabelID and LabelName identify the label or sublabel the release was found under. Releases appearing under
+	// multiple imprints are only reported for the first one crawled.
+	LabelID   int64
+	LabelName string
+}
+
+// CrawlLabelCatalog enumerates the releases of a label and streams them to fn one at a time. If IncludeSublabels is
+// set, the sublabels of the label are crawled as well, breadth first, and releases appearing under multiple imprints
+// are only reported once. Every label is crawled at most once, even if the label hierarchy contains cycles. If options
+// is nil, the default options are used.
+//
+// The crawl stops when fn returns an error, which is returned by CrawlLabelCatalog unless it is ErrStopPaging.
+func (dc *DiscogsClient) CrawlLabelCatalog(ctx context.Context, labelID int64, options *LabelCrawlOptions, fn func(entry LabelCatalogEntry) error) error {
+	var opts LabelCrawlOptions
+	if options != nil {
+		opts = *options
+	}
+
+	type queued struct {
+		id    int64
+		depth int
+	}
+	queue := []queued{{labelID, 0}}
+	visitedLabels := map[int64]bool{labelID: true}
+	seenReleases := make(map[int64]bool)
+	stopped := false
+
+	for len(queue) > 0 && !stopped {
+		current := queue[0]
+		queue = queue[1:]
+
+		label, err := dc.Label(ctx, current.id)
+		if err != nil {
+			return err
+		}
+
+		if opts.IncludeSublabels && (opts.MaxDepth <= 0 || current.depth < opts.MaxDepth) {
+			for _, sublabel := range label.Sublabels {
+				if !visitedLabels[sublabel.ID] {
+					visitedLabels[sublabel.ID] = true
+					queue = append(queue, queued{sublabel.ID, current.depth + 1})
+				}
+			}
+		}
+
+		err = crawl(ctx, dc.LabelReleasesPages(current.id), opts.PerPage, nil, nil, func(release LabelRelease) error {
+			if seenReleases[release.ID] {
+				return nil
+			}
+			seenReleases[release.ID] = true
+
+			err := fn(LabelCatalogEntry{LabelRelease: release, LabelID: current.id, LabelName: label.Name})
+			if errors.Is(err, ErrStopPaging) {
+				stopped = true
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// crawl fetches every page using fetch, starting at checkpoint, and passes each item to fn. The progress is reported
+// to onCheckpoint after every item. It stops when fn returns an error, which is returned unless it is ErrStopPaging.
+func crawl[T any](ctx context.Context, fetch PageFetcher[T], perPage int, checkpoint *CrawlCheckpoint, onCheckpoint func(CrawlCheckpoint), fn func(item T) error) error {
+	progress := CrawlCheckpoint{Page: 1, PerPage: perPage}
+	if checkpoint != nil {
+		progress = *checkpoint
+		progress.Page = max(progress.Page, 1)
+	}
+	if progress.PerPage <= 0 {
+		progress.PerPage = DefaultCrawlPerPage
+	}
+
+	for ; ; progress.Page, progress.Offset = progress.Page+1, 0 {
+		items, pagination, err := fetch(ctx, PaginationParams{Page: Int(progress.Page), PerPage: Int(progress.PerPage)})
+		if err != nil {
+			return err
+		}
+
+		for ; progress.Offset < len(items); progress.Offset++ {
+			if err := fn(items[progress.Offset]); err != nil {
+				if errors.Is(err, ErrStopPaging) {
+					return nil
+				}
+				return err
+			}
+
+			if onCheckpoint != nil {
+				next := CrawlCheckpoint{Page: progress.Page, Offset: progress.Offset + 1, PerPage: progress.PerPage}
+				if next.Offset == len(items) {
+					next.Page, next.Offset = next.Page+1, 0
+				}
+				onCheckpoint(next)
+			}
+		}
+
+		if pagination == nil || int64(progress.Page) >= pagination.Pages || len(items) == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+// artistReleases are the releases served by newArtistReleasesServer.
+var artistReleases = []discogs.ArtistRelease{
+	{ID: 1, Type: discogs.TypeRelease, Role: discogs.ArtistRoleMain},
+	{ID: 2, Type: discogs.TypeMaster, Role: discogs.ArtistRoleMain, MainRelease: discogs.Int64(20)},
+	{ID: 3, Type: discogs.TypeRelease, Role: discogs.ArtistRoleAppearance},
+	{ID: 4, Type: discogs.TypeMaster, Role: discogs.ArtistRoleMain},
+	{ID: 5, Type: discogs.TypeRelease, Role: discogs.ArtistRoleTrackAppearance},
+}
+
+// newArtistReleasesServer creates a mock server paging over artistReleases. Master 4 resolves to main release 40.
+func newArtistReleasesServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var res interface{}
+		switch req.URL.Path {
+		case "/artists/1/releases":
+			page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+			perPage, _ := strconv.Atoi(req.URL.Query().Get("per_page"))
+			start, end := min((page-1)*perPage, len(artistReleases)), min(page*perPage, len(artistReleases))
+			res = discogs.ArtistReleasesResponse{
+				Pagination: &discogs.Pagination{Page: int64(page), Pages: int64((len(artistReleases) + perPage - 1) / perPage), PerPage: int64(perPage)},
+				Releases:   artistReleases[start:end],
+			}
+		case "/masters/4":
+			res = discogs.MasterResponse{ID: 4, MainRelease: 40}
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err := json.NewEncoder(rw).Encode(res); err != nil {
+			assert.FailNow(t, "failed to write the response body: %w", err)
+		}
+	}))
+}
+
+func TestDiscogsClient_CrawlArtistDiscography(t *testing.T) {
+	type want struct {
+		releaseIDs []int64
+		checkpoint discogs.CrawlCheckpoint
+	}
+	tests := []struct {
+		name    string
+		options *discogs.ArtistCrawlOptions
+		want    want
+	}{
+		{
+			"Crawl all releases",
+			&discogs.ArtistCrawlOptions{PerPage: 2},
+			want{[]int64{1, 0, 3, 0, 5}, discogs.CrawlCheckpoint{Page: 4, PerPage: 2}},
+		},
+		{
+			"Crawl resolving masters",
+			&discogs.ArtistCrawlOptions{PerPage: 2, ResolveMasters: true},
+			want{[]int64{1, 20, 3, 40, 5}, discogs.CrawlCheckpoint{Page: 4, PerPage: 2}},
+		},
+		{
+			"Crawl filtering by role",
+			&discogs.ArtistCrawlOptions{PerPage: 2, Roles: []discogs.ArtistRole{discogs.ArtistRoleAppearance, discogs.ArtistRoleTrackAppearance}},
+			want{[]int64{3, 5}, discogs.CrawlCheckpoint{Page: 4, PerPage: 2}},
+		},
+		{
+			"Crawl resuming from checkpoint",
+			&discogs.ArtistCrawlOptions{Checkpoint: &discogs.CrawlCheckpoint{Page: 2, Offset: 1, PerPage: 2}},
+			want{[]int64{0, 5}, discogs.CrawlCheckpoint{Page: 4, PerPage: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newArtistReleasesServer(t)
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+			client.Host = server.URL
+
+			var checkpoint discogs.CrawlCheckpoint
+			tt.options.OnCheckpoint = func(c discogs.CrawlCheckpoint) { checkpoint = c }
+
+			var releaseIDs []int64
+			err := client.CrawlArtistDiscography(ctx, 1, tt.options, func(entry discogs.DiscographyEntry) error {
+				releaseIDs = append(releaseIDs, entry.ReleaseID)
+				return nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.releaseIDs, releaseIDs)
+			assert.Equal(t, tt.want.checkpoint, checkpoint)
+		})
+	}
+}
+
+func TestDiscogsClient_CrawlArtistDiscography_Stop(t *testing.T) {
+	server := newArtistReleasesServer(t)
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	var checkpoint discogs.CrawlCheckpoint
+	options := &discogs.ArtistCrawlOptions{PerPage: 2, OnCheckpoint: func(c discogs.CrawlCheckpoint) { checkpoint = c }}
+
+	var ids []int64
+	err := client.CrawlArtistDiscography(ctx, 1, options, func(entry discogs.DiscographyEntry) error {
+		if entry.ID == 3 {
+			return discogs.ErrStopPaging
+		}
+		ids = append(ids, entry.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, ids)
+
+	// Resuming picks up at the release the crawl stopped at
+	ids = nil
+	options.Checkpoint = &checkpoint
+	err = client.CrawlArtistDiscography(ctx, 1, options, func(entry discogs.DiscographyEntry) error {
+		ids = append(ids, entry.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 4, 5}, ids)
+}
+
+func TestDiscogsClient_CrawlLabelCatalog(t *testing.T) {
+	// Label 1 has sublabels 2 and 3, label 2 has sublabel 4 and lists label 1 as a sublabel to form a cycle
+	sublabels := map[string][]discogs.LabelRef{
+		"1": {{ID: 2}, {ID: 3}},
+		"2": {{ID: 1}, {ID: 4}},
+	}
+	releases := map[string][]discogs.LabelRelease{
+		"1": {{ID: 10}, {ID: 11}, {ID: 12}},
+		"2": {{ID: 11}, {ID: 20}},
+		"3": {{ID: 30}},
+		"4": {{ID: 40}, {ID: 10}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var res interface{}
+		var id string
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/releases"):
+			id = strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/labels/"), "/releases")
+			page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+			perPage, _ := strconv.Atoi(req.URL.Query().Get("per_page"))
+			all := releases[id]
+			start, end := min((page-1)*perPage, len(all)), min(page*perPage, len(all))
+			res = discogs.LabelReleasesResponse{
+				Pagination: &discogs.Pagination{Page: int64(page), Pages: int64((len(all) + perPage - 1) / perPage)},
+				Releases:   all[start:end],
+			}
+		default:
+			id = strings.TrimPrefix(req.URL.Path, "/labels/")
+			labelID, _ := strconv.ParseInt(id, 10, 64)
+			res = discogs.LabelResponse{ID: labelID, Name: "Label " + id, Sublabels: sublabels[id]}
+		}
+
+		if err := json.NewEncoder(rw).Encode(res); err != nil {
+			assert.FailNow(t, "failed to write the response body: %w", err)
+		}
+	}))
+	defer server.Close()
+
+	type want struct {
+		releaseIDs []int64
+		labelIDs   []int64
+	}
+	tests := []struct {
+		name    string
+		options *discogs.LabelCrawlOptions
+		want    want
+	}{
+		{
+			"Crawl label only",
+			&discogs.LabelCrawlOptions{PerPage: 2},
+			want{[]int64{10, 11, 12}, []int64{1, 1, 1}},
+		},
+		{
+			"Crawl sublabels deduplicating releases",
+			&discogs.LabelCrawlOptions{PerPage: 2, IncludeSublabels: true},
+			want{[]int64{10, 11, 12, 20, 30, 40}, []int64{1, 1, 1, 2, 3, 4}},
+		},
+		{
+			"Crawl sublabels with max depth",
+			&discogs.LabelCrawlOptions{IncludeSublabels: true, MaxDepth: 1},
+			want{[]int64{10, 11, 12, 20, 30}, []int64{1, 1, 1, 2, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+			client.Host = server.URL
+
+			var releaseIDs, labelIDs []int64
+			err := client.CrawlLabelCatalog(ctx, 1, tt.options, func(entry discogs.LabelCatalogEntry) error {
+				releaseIDs = append(releaseIDs, entry.ID)
+				labelIDs = append(labelIDs, entry.LabelID)
+				return nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.releaseIDs, releaseIDs)
+			assert.Equal(t, tt.want.labelIDs, labelIDs)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package discogs
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrCredentialsNotFound indicates that a CredentialStore holds no token set for a user.
+var ErrCredentialsNotFound = errors.New("credentials not found")
+
+// TokenSet represents the access token of a user, either a personal access token or an OAuth access token along with
+// its secret.
+type TokenSet struct {
+	AccessToken       string `json:"access_token"`
+	AccessTokenSecret string `json:"access_token_secret,omitempty"`
+}
+
+// A CredentialStore persists the access tokens of users between runs, so they only go through the OAuth flow once. A
+// StoreCredentialProvider authenticates a client with them. Implementations must be safe for concurrent use.
+type CredentialStore interface {
+	// Get returns the token set of a user, or ErrCredentialsNotFound if there is none.
+	Get(ctx context.Context, user string) (*TokenSet, error)
+	// Set stores the token set of a user, replacing any existing one.
+	Set(ctx context.Context, user string, tokens *TokenSet) error
+	// Delete removes the token set of a user, such as after the user revoked the access. It does nothing if there is
+	// none.
+	Delete(ctx context.Context, user string) error
+}
+
+// FileCredentialStore is a CredentialStore keeping the token sets of all users in a single file, encrypted with
+// AES-256-GCM, so tokens are not readable by anyone who can read the file but not the key. The file is rewritten on
+// every change, which suits the few users of a desktop or command line application.
+type FileCredentialStore struct {
+	path string
+	aead cipher.AEAD
+
+	mu sync.Mutex
+}
+
+// NewFileCredentialStore creates a new FileCredentialStore storing the token sets in the file at path, which is
+// created on the first Set. The key must be 32 random bytes, kept apart from the file, such as in the keychain of the
+// operating system.
+func NewFileCredentialStore(path string, key []byte) (*FileCredentialStore, error) {
+	if len(key) != 32 {
+		return nil, &ErrInvalidOption{Option: "key length", Value: fmt.Sprint(len(key))}
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &FileCredentialStore{path: path, aead: aead}, nil
+}
+
+// Get returns the token set of a user, or ErrCredentialsNotFound if there is none. It returns an error if the file
+// cannot be decrypted with the key.
+func (s *FileCredentialStore) Get(_ context.Context, user string) (*TokenSet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	set, ok := tokens[user]
+	if !ok {
+		return nil, ErrCredentialsNotFound
+	}
+	return &set, nil
+}
+
+// Set stores the token set of a user, replacing any existing one.
+func (s *FileCredentialStore) Set(_ context.Context, user string, tokens *TokenSet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	all[user] = *tokens
+	return s.save(all)
+}
+
+// Delete removes the token set of a user. It does nothing if there is none.
+func (s *FileCredentialStore) Delete(_ context.Context, user string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[user]; !ok {
+		return nil
+	}
+	delete(all, user)
+	return s.save(all)
+}
+
+// load decrypts the token sets of the file, keyed by user. A missing file holds no token set. The caller must hold
+// s.mu.
+func (s *FileCredentialStore) load() (map[string]TokenSet, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]TokenSet), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("failed to decrypt credentials: file too short")
+	}
+	plaintext, err := s.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credentials: %w", err)
+	}
+
+	tokens := make(map[string]TokenSet)
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
+	}
+	return tokens, nil
+}
+
+// save encrypts the token sets with a fresh nonce and replaces the file, through a temporary file so a crash never
+// leaves a partial file behind. The file is only readable by its owner. The caller must hold s.mu.
+func (s *FileCredentialStore) save(tokens map[string]TokenSet) error {
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data := s.aead.Seal(nonce, nonce, plaintext, nil)
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// StoreCredentialProvider is a CredentialProvider authenticating the requests of a client with the token set a
+// CredentialStore holds for a user, along with the consumer key and secret of the application. The store is read for
+// every request, so a token set stored at the end of the OAuth flow, or deleted after the user revoked the access, is
+// used from the next request on.
+//
+// Until the store holds a token set for the user, only the consumer key and secret are provided, so the client can
+// run the OAuth flow itself with GetRequestToken and GetAccessToken.
+type StoreCredentialProvider struct {
+	store          CredentialStore
+	user           string
+	consumerKey    string
+	consumerSecret string
+}
+
+// NewStoreCredentialProvider creates a new StoreCredentialProvider providing the token set of user from store. The
+// consumer key and secret may be empty for personal access tokens.
+func NewStoreCredentialProvider(store CredentialStore, user, consumerKey, consumerSecret string) *StoreCredentialProvider {
+	return &StoreCredentialProvider{store: store, user: user, consumerKey: consumerKey, consumerSecret: consumerSecret}
+}
+
+// Credentials returns the consumer key and secret along with the token set of the user, if the store holds one.
+func (p *StoreCredentialProvider) Credentials(ctx context.Context) (Credentials, error) {
+	creds := Credentials{ConsumerKey: p.consumerKey, ConsumerSecret: p.consumerSecret}
+
+	tokens, err := p.store.Get(ctx, p.user)
+	if errors.Is(err, ErrCredentialsNotFound) {
+		return creds, nil
+	}
+	if err != nil {
+		return Credentials{}, err
+	}
+	creds.AccessToken = tokens.AccessToken
+	creds.AccessTokenSecret = tokens.AccessTokenSecret
+	return creds, nil
+}
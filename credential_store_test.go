@@ -0,0 +1,99 @@
+package discogs_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCredentialStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	encryptionKey := bytes.Repeat([]byte{1}, 32)
+
+	store, err := discogs.NewFileCredentialStore(path, encryptionKey)
+	assert.NoError(t, err)
+
+	_, err = store.Get(ctx, "alice")
+	assert.ErrorIs(t, err, discogs.ErrCredentialsNotFound)
+
+	alice := &discogs.TokenSet{AccessToken: "alice-token", AccessTokenSecret: "alice-secret"}
+	assert.NoError(t, store.Set(ctx, "alice", alice))
+	assert.NoError(t, store.Set(ctx, "bob", &discogs.TokenSet{AccessToken: "bob-token"}))
+
+	// The tokens are not stored in clear, and are read back by another store with the same key
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "alice-token")
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	reopened, err := discogs.NewFileCredentialStore(path, encryptionKey)
+	assert.NoError(t, err)
+	got, err := reopened.Get(ctx, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, alice, got)
+
+	assert.NoError(t, reopened.Delete(ctx, "alice"))
+	_, err = store.Get(ctx, "alice")
+	assert.ErrorIs(t, err, discogs.ErrCredentialsNotFound)
+	got, err = store.Get(ctx, "bob")
+	assert.NoError(t, err)
+	assert.Equal(t, "bob-token", got.AccessToken)
+
+	wrongKey, err := discogs.NewFileCredentialStore(path, bytes.Repeat([]byte{2}, 32))
+	assert.NoError(t, err)
+	_, err = wrongKey.Get(ctx, "bob")
+	assert.Error(t, err)
+
+	_, err = discogs.NewFileCredentialStore(path, []byte("short"))
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "key length", Value: "5"}, err)
+}
+
+func TestStoreCredentialProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		params := oauthParams(t, req.Header.Get(discogs.AuthHeader))
+		switch req.URL.Path {
+		case "/oauth/access_token":
+			assert.Equal(t, "request-token", params["oauth_token"])
+			_, _ = rw.Write([]byte("oauth_token=access-token&oauth_token_secret=access-secret"))
+		case "/oauth/identity":
+			assert.Equal(t, "access-token", params["oauth_token"])
+			assert.Equal(t, secret+"&access-secret", params["oauth_signature"])
+			_, _ = rw.Write([]byte(`{"id":1,"username":"alice"}`))
+		}
+	}))
+	defer server.Close()
+
+	store, err := discogs.NewFileCredentialStore(filepath.Join(t.TempDir(), "credentials"), bytes.Repeat([]byte{1}, 32))
+	assert.NoError(t, err)
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+		CredentialProvider: discogs.NewStoreCredentialProvider(store, "alice", key, secret),
+	})
+	client.Host = server.URL
+
+	// Without a stored token set, the client runs the OAuth flow with the consumer key and secret only
+	_, err = client.Identity(ctx)
+	assert.IsType(t, &discogs.ErrMissingCredentials{}, err)
+
+	tokens, err := client.GetAccessToken(ctx, &discogs.RequestToken{Token: "request-token", Secret: "request-secret"}, "verifier")
+	assert.NoError(t, err)
+	assert.NoError(t, store.Set(ctx, "alice", tokens))
+
+	// The stored token set authenticates the next requests, even of a client created later
+	client = discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+		CredentialProvider: discogs.NewStoreCredentialProvider(store, "alice", key, secret),
+	})
+	client.Host = server.URL
+	identity, err := client.Identity(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", identity.Username)
+}
@@ -0,0 +1,63 @@
+package discogs
+
+import "context"
+
+// Credentials represents the credentials a request is authenticated with. Fields that are empty are not set.
+type Credentials struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	// AccessToken is either a personal access token or, along with AccessTokenSecret, an OAuth access token.
+	AccessToken       string
+	AccessTokenSecret string
+}
+
+// A CredentialProvider provides the credentials of a DiscogsClient. It is consulted for every request, so credentials
+// can be rotated, loaded lazily from a vault or refreshed when short-lived, without reconstructing the client. It
+// must be safe for concurrent use, and should cache the credentials if getting them is slow.
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialProviderFunc is an adapter to use an ordinary function as a CredentialProvider.
+type CredentialProviderFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials calls f(ctx).
+func (f CredentialProviderFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// credentials returns the credentials to authenticate a request with: those of the CredentialProvider if set, and
+// those of the DiscogsConfig otherwise.
+func (dc *DiscogsClient) credentials(ctx context.Context) (Credentials, error) {
+	if dc.Config.CredentialProvider != nil {
+		return dc.Config.CredentialProvider.Credentials(ctx)
+	}
+	return Credentials{
+		ConsumerKey:       StringValue(dc.Config.ConsumerKey),
+		ConsumerSecret:    StringValue(dc.Config.ConsumerSecret),
+		AccessToken:       StringValue(dc.Config.AccessToken),
+		AccessTokenSecret: StringValue(dc.Config.AccessTokenSecret),
+	}, nil
+}
+
+// hasKeySecret reports whether both the consumer key and secret are set.
+func (c *Credentials) hasKeySecret() bool {
+	return c.ConsumerKey != "" && c.ConsumerSecret != ""
+}
+
+// signsOAuth reports whether the credentials can sign requests with OAuth 1.0a.
+func (c *Credentials) signsOAuth() bool {
+	return c.hasKeySecret() && c.AccessToken != "" && c.AccessTokenSecret != ""
+}
+
+// authType returns the most privileged type of authentication the credentials can provide.
+func (c *Credentials) authType() AuthType {
+	switch {
+	case c.AccessToken != "":
+		return AuthTypePAT
+	case c.hasKeySecret():
+		return AuthTypeKeySecret
+	default:
+		return AuthTypeNone
+	}
+}
@@ -0,0 +1,63 @@
+package discogs_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_CredentialProvider(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		headers = append(headers, req.Header.Get(discogs.AuthHeader))
+		_, _ = rw.Write([]byte(`{"id":1,"username":"me"}`))
+	}))
+	defer server.Close()
+
+	// Every request gets a new token, as if it was rotated
+	var calls atomic.Int64
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+		CredentialProvider: discogs.CredentialProviderFunc(func(ctx context.Context) (discogs.Credentials, error) {
+			n := calls.Add(1)
+			return discogs.Credentials{ConsumerKey: key, ConsumerSecret: secret, AccessToken: "token-" + strconv.FormatInt(n, 10)}, nil
+		}),
+	})
+	client.Host = server.URL
+
+	_, err := client.Identity(ctx)
+	assert.NoError(t, err)
+	_, err = client.Identity(ctx)
+	assert.NoError(t, err)
+	_, err = client.Search(ctx, &discogs.SearchOptions{Query: "nirvana"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Bearer token-1",
+		"Bearer token-2",
+		"Discogs key=key, secret=secret",
+	}, headers)
+	assert.Equal(t, discogs.AuthTypeUnknown, client.Diagnostics().AuthType)
+
+	providerErr := errors.New("vault unavailable")
+	client.Config.CredentialProvider = discogs.CredentialProviderFunc(func(ctx context.Context) (discogs.Credentials, error) {
+		return discogs.Credentials{}, providerErr
+	})
+
+	_, err = client.Identity(ctx)
+	assert.ErrorIs(t, err, providerErr)
+	// Public collections are only requested anonymously if the provider says there is no token
+	_, err = client.CollectionItems(ctx, "me", discogs.FolderAll, nil)
+	assert.ErrorIs(t, err, providerErr)
+	assert.Len(t, headers, 3)
+
+	res := client.SelfTest(ctx)
+	assert.Equal(t, discogs.SelfTestCredentials, res.Problem)
+	assert.ErrorIs(t, res.Err, providerErr)
+}
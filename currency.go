@@ -0,0 +1,120 @@
+package discogs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Currency represents a currency code used in the Discogs API.
+type Currency string
+
+// Currency constants representing various supported currencies.
+const (
+	CurrencyUSD Currency = "USD"
+	CurrencyGBP Currency = "GBP"
+	CurrencyEUR Currency = "EUR"
+	CurrencyCAD Currency = "CAD"
+	CurrencyAUD Currency = "AUD"
+	CurrencyJPY Currency = "JPY"
+	CurrencyCHF Currency = "CHF"
+	CurrencyMXN Currency = "MXN"
+	CurrencyBRL Currency = "BRL"
+	CurrencyNZD Currency = "NZD"
+	CurrencySEK Currency = "SEK"
+	CurrencyZAR Currency = "ZAR"
+)
+
+// currencyInfo contains the display information of a supported currency.
+type currencyInfo struct {
+	symbol   string
+	decimals int
+}
+
+// currencies maps every currency accepted by the Discogs API to its display information.
+var currencies = map[Currency]currencyInfo{
+	CurrencyUSD: {"$", 2},
+	CurrencyGBP: {"£", 2},
+	CurrencyEUR: {"€", 2},
+	CurrencyCAD: {"CA$", 2},
+	CurrencyAUD: {"A$", 2},
+	CurrencyJPY: {"¥", 0},
+	CurrencyCHF: {"CHF ", 2},
+	CurrencyMXN: {"MX$", 2},
+	CurrencyBRL: {"R$", 2},
+	CurrencyNZD: {"NZ$", 2},
+	CurrencySEK: {"SEK ", 2},
+	CurrencyZAR: {"R", 2},
+}
+
+// Currencies returns all currencies accepted by the Discogs API.
+func Currencies() []Currency {
+	return []Currency{
+		CurrencyUSD, CurrencyGBP, CurrencyEUR, CurrencyCAD, CurrencyAUD, CurrencyJPY,
+		CurrencyCHF, CurrencyMXN, CurrencyBRL, CurrencyNZD, CurrencySEK, CurrencyZAR,
+	}
+}
+
+// ErrInvalidCurrency indicates that a currency is not accepted by the Discogs API.
+type ErrInvalidCurrency struct {
+	Currency string
+}
+
+func (e *ErrInvalidCurrency) Error() string {
+	return fmt.Sprintf("invalid currency: %q", e.Currency)
+}
+
+// ParseCurrency parses a currency code, ignoring case and surrounding whitespace. It returns an ErrInvalidCurrency
+// if the currency is not accepted by the Discogs API.
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !currency.IsValid() {
+		return "", &ErrInvalidCurrency{Currency: code}
+	}
+	return currency, nil
+}
+
+// IsValid reports whether the currency is accepted by the Discogs API.
+func (c Currency) IsValid() bool {
+	_, ok := currencies[c]
+	return ok
+}
+
+// Symbol returns the symbol used when displaying amounts in the currency, or the currency code itself if the
+// currency is not valid.
+func (c Currency) Symbol() string {
+	if info, ok := currencies[c]; ok {
+		return info.symbol
+	}
+	return string(c)
+}
+
+// Decimals returns the number of decimal places used when displaying amounts in the currency.
+func (c Currency) Decimals() int {
+	if info, ok := currencies[c]; ok {
+		return info.decimals
+	}
+	return 2
+}
+
+// FormatAmount formats an amount in the currency for display.
+//
+// Example: CurrencyEUR.FormatAmount(1250) returns "€12.50".
+func (c Currency) FormatAmount(amount Amount) string {
+	formatted := amount.format(c.Decimals())
+	if c.IsValid() {
+		if negative := strings.HasPrefix(formatted, "-"); negative {
+			return "-" + c.Symbol() + formatted[1:]
+		}
+		return c.Symbol() + formatted
+	}
+	return formatted + " " + string(c)
+}
+
+// validateCurrency returns an ErrInvalidCurrency if the currency is set but not accepted by the Discogs API. The
+// API silently falls back to USD for unknown currencies, so they are caught before a request is sent.
+func validateCurrency(c Currency) error {
+	if c != "" && !c.IsValid() {
+		return &ErrInvalidCurrency{Currency: string(c)}
+	}
+	return nil
+}
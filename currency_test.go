@@ -0,0 +1,77 @@
+package discogs_test
+
+import (
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCurrency(t *testing.T) {
+	type want struct {
+		currency discogs.Currency
+		err      error
+	}
+	tests := []struct {
+		name string
+		args string
+		want want
+	}{
+		{
+			"ParseCurrency valid",
+			"EUR",
+			want{discogs.CurrencyEUR, nil},
+		},
+		{
+			"ParseCurrency lowercase with whitespace",
+			" jpy ",
+			want{discogs.CurrencyJPY, nil},
+		},
+		{
+			"ParseCurrency unsupported",
+			"DKK",
+			want{"", &discogs.ErrInvalidCurrency{"DKK"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := discogs.ParseCurrency(tt.args)
+
+			if tt.want.err != nil {
+				assert.EqualError(t, err, tt.want.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want.currency, got)
+		})
+	}
+}
+
+func TestCurrency_IsValid(t *testing.T) {
+	for _, currency := range discogs.Currencies() {
+		assert.True(t, currency.IsValid(), "expected %s to be valid", currency)
+	}
+	assert.False(t, discogs.Currency("usd").IsValid())
+	assert.False(t, discogs.Currency("").IsValid())
+}
+
+func TestCurrency_FormatAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency discogs.Currency
+		amount   discogs.Amount
+		want     string
+	}{
+		{"FormatAmount EUR", discogs.CurrencyEUR, 1250, "€12.50"},
+		{"FormatAmount JPY", discogs.CurrencyJPY, 150000, "¥1500"},
+		{"FormatAmount JPY rounded", discogs.CurrencyJPY, 150050, "¥1501"},
+		{"FormatAmount negative USD", discogs.CurrencyUSD, -320, "-$3.20"},
+		{"FormatAmount CHF", discogs.CurrencyCHF, 700, "CHF 7.00"},
+		{"FormatAmount unknown currency", discogs.Currency("DKK"), 700, "7.00 DKK"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.currency.FormatAmount(tt.amount))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package discogs
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrIteratorStarted is returned by Iterator.ResumeFrom if the iterator already fetched a page.
+var ErrIteratorStarted = errors.New("iterator already started")
+
+// Cursor serializes the checkpoint into an opaque cursor, which can be persisted and parsed with ParseCursor.
+func (c CrawlCheckpoint) Cursor() []byte {
+	cursor, _ := json.Marshal(c)
+	return cursor
+}
+
+// ParseCursor parses a cursor returned by CrawlCheckpoint.Cursor or Iterator.Cursor. It returns an ErrInvalidOption if
+// the cursor is malformed.
+func ParseCursor(cursor []byte) (CrawlCheckpoint, error) {
+	var checkpoint CrawlCheckpoint
+	if err := json.Unmarshal(cursor, &checkpoint); err != nil || checkpoint.Page < 1 || checkpoint.Offset < 0 || checkpoint.PerPage < 0 {
+		return CrawlCheckpoint{}, &ErrInvalidOption{Option: "cursor", Value: string(cursor)}
+	}
+	return checkpoint, nil
+}
+
+// Checkpoint returns the position of the iterator: the page and offset of the item the next call to Next advances
+// to.
+func (it *Iterator[T]) Checkpoint() CrawlCheckpoint {
+	checkpoint := CrawlCheckpoint{Page: it.page, Offset: it.skip, PerPage: it.options.PerPage}
+	if it.items != nil && it.index+1 < len(it.items) {
+		// The current page was fetched before the page number was advanced
+		checkpoint.Page, checkpoint.Offset = it.page-1, it.index+1
+	}
+	return checkpoint
+}
+
+// Cursor returns an opaque cursor recording the position of the iterator, so an interrupted iteration can be resumed
+// exactly where it left off by passing the cursor to ResumeFrom. The cursor is only valid for the same endpoint and
+// options, including the sort order.
+func (it *Iterator[T]) Cursor() []byte {
+	return it.Checkpoint().Cursor()
+}
+
+// ResumeFrom positions the iterator at a cursor returned by Cursor, so the next call to Next returns the item the
+// cursor was taken before. The page size of the cursor replaces the page size of the options. It must be called before
+// the first call to Next, otherwise it returns ErrIteratorStarted. It returns an ErrInvalidOption if the cursor is
+// malformed.
+func (it *Iterator[T]) ResumeFrom(cursor []byte) error {
+	if it.items != nil || it.done || it.err != nil {
+		return ErrIteratorStarted
+	}
+
+	checkpoint, err := ParseCursor(cursor)
+	if err != nil {
+		return err
+	}
+
+	it.page = checkpoint.Page
+	it.skip = checkpoint.Offset
+	it.options.PerPage = checkpoint.PerPage
+	return nil
+}
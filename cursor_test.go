@@ -0,0 +1,83 @@
+package discogs_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterator_ResumeFrom(t *testing.T) {
+	tests := []struct {
+		name           string
+		consumed       int
+		prefetch       bool
+		wantPages      []int
+		wantCheckpoint discogs.CrawlCheckpoint
+	}{
+		{
+			name:           "not started",
+			consumed:       0,
+			wantPages:      []int{1, 2, 3},
+			wantCheckpoint: discogs.CrawlCheckpoint{Page: 1, Offset: 0, PerPage: 4},
+		},
+		{
+			name:           "middle of a page",
+			consumed:       3,
+			wantPages:      []int{1, 2, 3},
+			wantCheckpoint: discogs.CrawlCheckpoint{Page: 1, Offset: 3, PerPage: 4},
+		},
+		{
+			name:           "end of a page",
+			consumed:       4,
+			prefetch:       true,
+			wantPages:      []int{2, 3},
+			wantCheckpoint: discogs.CrawlCheckpoint{Page: 2, Offset: 0, PerPage: 4},
+		},
+		{
+			name:           "last item",
+			consumed:       9,
+			wantPages:      []int{3},
+			wantCheckpoint: discogs.CrawlCheckpoint{Page: 3, Offset: 1, PerPage: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var fetched []int
+
+			it := discogs.NewIterator(pagedFetcher(10, 4, &fetched, &mu), &discogs.IteratorOptions{PerPage: 4, Prefetch: tt.prefetch})
+			var got []int
+			for len(got) < tt.consumed && it.Next(ctx) {
+				got = append(got, it.Value())
+			}
+			cursor := it.Cursor()
+			it.Close()
+			assert.Equal(t, tt.wantCheckpoint, it.Checkpoint())
+
+			fetched = nil
+			resumed := discogs.NewIterator(pagedFetcher(10, 4, &fetched, &mu), nil)
+			assert.NoError(t, resumed.ResumeFrom(cursor))
+			for resumed.Next(ctx) {
+				got = append(got, resumed.Value())
+			}
+
+			assert.NoError(t, resumed.Err())
+			assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, got)
+			assert.Equal(t, tt.wantPages, fetched)
+			assert.Equal(t, discogs.ErrIteratorStarted, resumed.ResumeFrom(cursor))
+		})
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	checkpoint := discogs.CrawlCheckpoint{Page: 3, Offset: 7, PerPage: 100}
+	got, err := discogs.ParseCursor(checkpoint.Cursor())
+	assert.NoError(t, err)
+	assert.Equal(t, checkpoint, got)
+
+	_, err = discogs.ParseCursor([]byte(`{"page":0}`))
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "cursor", Value: `{"page":0}`}, err)
+}
@@ -2,7 +2,7 @@ package discogs
 
 import (
 	"context"
-	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/google/go-querystring/query"
@@ -13,29 +13,26 @@ import (
 // The releaseID specifies the ID of the release to fetch, and options allows for
 // additional query parameters. The context.Context provides control over the request's lifecycle.
 // It returns a pointer to a ReleaseResponse struct containing the release details,
-// or an error if the request fails or the release is not found.
+// or an error if the request fails, the release is not found, or the currency in options is not valid.
 //
 // Documentation: https://www.discogs.com/developers#page:database,header:database-release
-func (dc *DiscogsClient) Release(ctx context.Context, releaseID int64, options *ReleaseOptions) (*ReleaseResponse, error) {
+func (dc *DiscogsClient) Release(ctx context.Context, releaseID int64, options *ReleaseOptions, opts ...RequestOption) (*ReleaseResponse, error) {
 	endpoint := "/releases/" + strconv.FormatInt(releaseID, 10)
 	var res ReleaseResponse
 
+	if options != nil {
+		if err := validateCurrency(options.CurrAbr); err != nil {
+			return nil, err
+		}
+	}
+
 	params, err := query.Values(options)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := dc.Get(ctx, endpoint, params, nil, &res); err != nil {
-		if httpErr, ok := err.(*HTTPError); ok {
-			if httpErr.StatusCode == http.StatusNotFound {
-				return nil, &ErrReleaseNotFound{
-					ReleaseID: int(releaseID),
-					HTTPError: httpErr,
-				}
-			}
-			return nil, httpErr
-		}
-		return nil, err
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceRelease, strconv.FormatInt(releaseID, 10))
 	}
 
 	return &res, nil
@@ -44,51 +41,323 @@ func (dc *DiscogsClient) Release(ctx context.Context, releaseID int64, options *
 // https://www.discogs.com/developers#page:database,header:database-release-rating-by-user
 // GET /releases/{release_id}/rating/{username}
 
-// PUT /releases/{release_id}/rating/{username}
+// UpdateReleaseRating rates a release on behalf of a user by sending a PUT request to the
+// /releases/{release_id}/rating/{username} endpoint. The rating must be between 1 and 5.
+// The context.Context provides control over the request's lifecycle.
+// It returns a pointer to a UserReleaseRatingResponse struct containing the updated rating,
+// or an ErrInvalidOption if the rating is out of range, or an error if the request fails or the release is not found.
+//
+// Rating a release requires OAuth or a personal access token of the user.
+//
+// Documentation: https://www.discogs.com/developers#page:database,header:database-release-rating-by-user-put
+func (dc *DiscogsClient) UpdateReleaseRating(ctx context.Context, releaseID int64, username string, rating int, opts ...RequestOption) (*UserReleaseRatingResponse, error) {
+	endpoint := "/releases/" + strconv.FormatInt(releaseID, 10) + "/rating/" + url.PathEscape(username)
+	var res UserReleaseRatingResponse
+
+	if rating < 1 || rating > 5 {
+		return nil, &ErrInvalidOption{Option: "rating", Value: strconv.Itoa(rating)}
+	}
+
+	body := struct {
+		Rating int `json:"rating"`
+	}{Rating: rating}
+
+	if err := dc.Put(WithRequestOptions(ctx, opts...), endpoint, nil, nil, body, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceRelease, strconv.FormatInt(releaseID, 10))
+	}
+
+	return &res, nil
+}
 
 // DELETE /releases/{release_id}/rating/{username}
 
-// https://www.discogs.com/developers#page:database,header:database-community-release-rating
-// GET /releases/{release_id}/rating
+// CommunityReleaseRating fetches the average rating of a release by the Discogs community
+// by sending a GET request to the /releases/{release_id}/rating endpoint.
+// The releaseID specifies the ID of the release. The context.Context provides control over the request's lifecycle.
+// It returns a pointer to a CommunityReleaseRatingResponse struct containing the rating,
+// or an error if the request fails or the release is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:database,header:database-community-release-rating
+func (dc *DiscogsClient) CommunityReleaseRating(ctx context.Context, releaseID int64, opts ...RequestOption) (*CommunityReleaseRatingResponse, error) {
+	endpoint := "/releases/" + strconv.FormatInt(releaseID, 10) + "/rating"
+	var res CommunityReleaseRatingResponse
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceRelease, strconv.FormatInt(releaseID, 10))
+	}
+
+	return &res, nil
+}
+
+// ReleaseStats fetches the number of users that have and want a release
+// by sending a GET request to the /releases/{release_id}/stats endpoint.
+// The releaseID specifies the ID of the release. The context.Context provides control over the request's lifecycle.
+// It returns a pointer to a ReleaseStatsResponse struct containing the statistics,
+// or an error if the request fails or the release is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:database,header:database-release-stats
+func (dc *DiscogsClient) ReleaseStats(ctx context.Context, releaseID int64, opts ...RequestOption) (*ReleaseStatsResponse, error) {
+	endpoint := "/releases/" + strconv.FormatInt(releaseID, 10) + "/stats"
+	var res ReleaseStatsResponse
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceRelease, strconv.FormatInt(releaseID, 10))
+	}
+
+	return &res, nil
+}
+
+// Master fetches information about a master release from the Discogs database
+// by sending a GET request to the /masters/{master_id} endpoint.
+// The masterID specifies the ID of the master release to fetch. The context.Context provides
+// control over the request's lifecycle. It returns a pointer to a MasterResponse struct containing
+// the master release details, or an error if the request fails or the master release is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:database,header:database-master-release
+func (dc *DiscogsClient) Master(ctx context.Context, masterID int64, opts ...RequestOption) (*MasterResponse, error) {
+	endpoint := "/masters/" + strconv.FormatInt(masterID, 10)
+	var res MasterResponse
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceMaster, strconv.FormatInt(masterID, 10))
+	}
+
+	return &res, nil
+}
+
+// MainRelease fetches the main release of a master release, by fetching the master release and then the release its
+// main_release field refers to. The options only apply to the request of the release. It returns an error if either
+// request fails, or if the master release or its main release is not found.
+func (dc *DiscogsClient) MainRelease(ctx context.Context, masterID int64, opts ...RequestOption) (*ReleaseResponse, error) {
+	master, err := dc.Master(ctx, masterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dc.Release(ctx, master.MainRelease, nil, opts...)
+}
+
+// MasterVersions fetches a page of the versions of a master release
+// by sending a GET request to the /masters/{master_id}/versions endpoint.
+// The masterID specifies the ID of the master release, and options allows for filtering, sorting and pagination.
+// The context.Context provides control over the request's lifecycle.
+// It returns a pointer to a MasterVersionsResponse struct containing the versions and the available filters,
+// or an error if the request fails or the master release is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:database,header:database-master-release-versions
+func (dc *DiscogsClient) MasterVersions(ctx context.Context, masterID int64, options *MasterVersionsOptions, opts ...RequestOption) (*MasterVersionsResponse, error) {
+	endpoint := "/masters/" + strconv.FormatInt(masterID, 10) + "/versions"
+	var res MasterVersionsResponse
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceMaster, strconv.FormatInt(masterID, 10))
+	}
+
+	return &res, nil
+}
+
+// MasterVersionsPages returns a PageFetcher that fetches pages of the versions of a master release, for use with
+// NewIterator or ForEachPage. The pagination parameters of options are overridden by the page being fetched.
+func (dc *DiscogsClient) MasterVersionsPages(masterID int64, options *MasterVersionsOptions) PageFetcher[MasterVersion] {
+	return func(ctx context.Context, page PaginationParams) ([]MasterVersion, *Pagination, error) {
+		var pageOptions MasterVersionsOptions
+		if options != nil {
+			pageOptions = *options
+		}
+		pageOptions.Page = page.Page
+		if page.PerPage != nil {
+			pageOptions.PerPage = page.PerPage
+		}
+
+		res, err := dc.MasterVersions(ctx, masterID, &pageOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Versions, res.Pagination, nil
+	}
+}
+
+// Artist fetches information about an artist from the Discogs database
+// by sending a GET request to the /artists/{artist_id} endpoint.
+// The artistID specifies the ID of the artist to fetch. The context.Context provides
+// control over the request's lifecycle. It returns a pointer to an ArtistResponse struct containing
+// the artist details, or an error if the request fails or the artist is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:database,header:database-artist
+func (dc *DiscogsClient) Artist(ctx context.Context, artistID int64, opts ...RequestOption) (*ArtistResponse, error) {
+	endpoint := "/artists/" + strconv.FormatInt(artistID, 10)
+	var res ArtistResponse
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceArtist, strconv.FormatInt(artistID, 10))
+	}
+
+	return &res, nil
+}
+
+// ArtistReleases fetches a page of the releases and master releases an artist is credited on
+// by sending a GET request to the /artists/{artist_id}/releases endpoint.
+// The artistID specifies the ID of the artist, and options allows for sorting and pagination.
+// The context.Context provides control over the request's lifecycle.
+// It returns a pointer to an ArtistReleasesResponse struct containing the releases,
+// or an error if the request fails or the artist is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:database,header:database-artist-releases
+func (dc *DiscogsClient) ArtistReleases(ctx context.Context, artistID int64, options *ArtistReleasesOptions, opts ...RequestOption) (*ArtistReleasesResponse, error) {
+	endpoint := "/artists/" + strconv.FormatInt(artistID, 10) + "/releases"
+	var res ArtistReleasesResponse
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceArtist, strconv.FormatInt(artistID, 10))
+	}
+
+	return &res, nil
+}
+
+// ArtistReleasesPages returns a PageFetcher that fetches pages of the releases of an artist, for use with NewIterator
+// or ForEachPage. The pagination parameters of options are overridden by the page being fetched.
+func (dc *DiscogsClient) ArtistReleasesPages(artistID int64, options *ArtistReleasesOptions) PageFetcher[ArtistRelease] {
+	return func(ctx context.Context, page PaginationParams) ([]ArtistRelease, *Pagination, error) {
+		var pageOptions ArtistReleasesOptions
+		if options != nil {
+			pageOptions = *options
+		}
+		pageOptions.Page = page.Page
+		if page.PerPage != nil {
+			pageOptions.PerPage = page.PerPage
+		}
+
+		res, err := dc.ArtistReleases(ctx, artistID, &pageOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Releases, res.Pagination, nil
+	}
+}
+
+// Label fetches information about a label from the Discogs database
+// by sending a GET request to the /labels/{label_id} endpoint.
+// The labelID specifies the ID of the label to fetch. The context.Context provides
+// control over the request's lifecycle. It returns a pointer to a LabelResponse struct containing
+// the label details, or an error if the request fails or the label is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:database,header:database-label
+func (dc *DiscogsClient) Label(ctx context.Context, labelID int64, opts ...RequestOption) (*LabelResponse, error) {
+	endpoint := "/labels/" + strconv.FormatInt(labelID, 10)
+	var res LabelResponse
 
-// https://www.discogs.com/developers#page:database,header:database-release-stats
-// GET /releases/{release_id}/stats
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceLabel, strconv.FormatInt(labelID, 10))
+	}
 
-// https://www.discogs.com/developers#page:database,header:database-master-release
-// GET /masters/{master_id}
+	return &res, nil
+}
 
-// https://www.discogs.com/developers#page:database,header:database-master-release-versions
-// GET /masters/{master_id}/versions{?page,per_page}
+// LabelReleases fetches a page of the releases of a label
+// by sending a GET request to the /labels/{label_id}/releases endpoint.
+// The labelID specifies the ID of the label, and options allows for pagination.
+// The context.Context provides control over the request's lifecycle.
+// It returns a pointer to a LabelReleasesResponse struct containing the releases,
+// or an error if the request fails or the label is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:database,header:database-all-label-releases
+func (dc *DiscogsClient) LabelReleases(ctx context.Context, labelID int64, options *PaginationParams, opts ...RequestOption) (*LabelReleasesResponse, error) {
+	endpoint := "/labels/" + strconv.FormatInt(labelID, 10) + "/releases"
+	var res LabelReleasesResponse
 
-// https://www.discogs.com/developers#page:database,header:database-artist
-// GET /artists/{artist_id}
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
 
-// https://www.discogs.com/developers#page:database,header:database-artist-releases
-// GET /artists/{artist_id}/releases{?sort,sort_order}
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceLabel, strconv.FormatInt(labelID, 10))
+	}
 
-// https://www.discogs.com/developers#page:database,header:database-label
-// GET /labels/{label_id}
+	return &res, nil
+}
 
-// https://www.discogs.com/developers#page:database,header:database-all-label-releases
-// GET /labels/{label_id}/releases{?page,per_page}
+// LabelReleasesPages returns a PageFetcher that fetches pages of the releases of a label, for use with NewIterator or
+// ForEachPage.
+func (dc *DiscogsClient) LabelReleasesPages(labelID int64) PageFetcher[LabelRelease] {
+	return func(ctx context.Context, page PaginationParams) ([]LabelRelease, *Pagination, error) {
+		res, err := dc.LabelReleases(ctx, labelID, &page)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Releases, res.Pagination, nil
+	}
+}
 
 // Search performs a search query against the Discogs database by sending a GET request
 // to the /database/search endpoint. The options parameter specifies the search options,
 // such as query and type. The context.Context provides control over the request's lifecycle.
 // It returns a pointer to a SearchResponse struct containing the search results,
-// or an error if the request fails.
-func (dc *DiscogsClient) Search(ctx context.Context, options *SearchOptions) (*SearchResponse, error) {
+// or an error if the request fails or the requested page is past MaxSearchResults.
+// It returns an ErrInvalidOption before sending the request if the genre is not spelled as in the Discogs taxonomy,
+// or if the style is a misspelling of a known style, since Discogs silently returns no results for them.
+func (dc *DiscogsClient) Search(ctx context.Context, options *SearchOptions, opts ...RequestOption) (*SearchResponse, error) {
 	endpoint := "/database/search"
 	var res SearchResponse
 
+	if err := checkSearchDepth(options); err != nil {
+		return nil, err
+	}
+	if options != nil {
+		if err := validateGenre(Genre(options.Genre)); err != nil {
+			return nil, err
+		}
+		if err := validateStyle(Style(options.Style)); err != nil {
+			return nil, err
+		}
+	}
+
 	params, err := query.Values(options)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := dc.Get(ctx, endpoint, params, nil, &res); err != nil {
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
+
+// SearchIter returns an Iterator over all results of a search query, fetching pages of results as needed.
+// The options parameter specifies the search options; its pagination parameters are managed by the iterator.
+// The iterOptions parameter configures the iteration, such as the page size and whether to prefetch pages.
+func (dc *DiscogsClient) SearchIter(options *SearchOptions, iterOptions *IteratorOptions) *Iterator[SearchResult] {
+	return NewIterator(dc.SearchPages(options), iterOptions)
+}
+
+// SearchPages returns a PageFetcher that fetches pages of results of a search query, for use with NewIterator or
+// ForEachPage. The pagination parameters of options are overridden by the page being fetched.
+func (dc *DiscogsClient) SearchPages(options *SearchOptions) PageFetcher[SearchResult] {
+	return func(ctx context.Context, page PaginationParams) ([]SearchResult, *Pagination, error) {
+		var pageOptions SearchOptions
+		if options != nil {
+			pageOptions = *options
+		}
+		pageOptions.Page = page.Page
+		if page.PerPage != nil {
+			pageOptions.PerPage = page.PerPage
+		}
+
+		res, err := dc.Search(ctx, &pageOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Results, res.Pagination, nil
+	}
+}
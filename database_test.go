@@ -41,7 +41,7 @@ func TestDatabase_Release(t *testing.T) {
 			mock{http.StatusNotFound, struct {
 				Message string `json:"message"`
 			}{"Release not found."}},
-			want{nil, &discogs.ErrReleaseNotFound{2, &discogs.HTTPError{http.StatusNotFound, `{"message":"Release not found."}`}}},
+			want{nil, &discogs.ErrNotFound{discogs.ResourceRelease, "2", &discogs.HTTPError{http.StatusNotFound, `{"message":"Release not found."}`}}},
 		},
 		{
 			"unexpected error",
@@ -51,6 +51,12 @@ func TestDatabase_Release(t *testing.T) {
 			}{"Internal server error."}},
 			want{nil, &discogs.HTTPError{http.StatusInternalServerError, `{"message":"Internal server error."}`}},
 		},
+		{
+			"invalid currency",
+			args{4, &discogs.ReleaseOptions{discogs.Currency("DKK")}},
+			mock{http.StatusOK, discogs.ReleaseResponse{Title: "Test Release", ID: 4}},
+			want{nil, &discogs.ErrInvalidCurrency{"DKK"}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -175,3 +181,190 @@ func TestDatabase_Search(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabase_Master(t *testing.T) {
+	type want struct {
+		res *discogs.MasterResponse
+		err error
+	}
+	type mock struct {
+		status int
+		res    interface{}
+	}
+	tests := []struct {
+		name string
+		args int64
+		mock mock
+		want want
+	}{
+		{
+			"successful master fetch",
+			1,
+			mock{http.StatusOK, discogs.MasterResponse{Title: "Test Master", ID: 1, MainRelease: 2}},
+			want{&discogs.MasterResponse{Title: "Test Master", ID: 1, MainRelease: 2}, nil},
+		},
+		{
+			"master not found",
+			2,
+			mock{http.StatusNotFound, struct {
+				Message string `json:"message"`
+			}{"Master Release not found."}},
+			want{nil, &discogs.ErrNotFound{discogs.ResourceMaster, "2", &discogs.HTTPError{http.StatusNotFound, `{"message":"Master Release not found."}`}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a mock server
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(tt.mock.status)
+
+				responseBody, err := json.Marshal(tt.mock.res)
+				if err != nil {
+					assert.FailNow(t, "unable to marshal json response: %w", err)
+				}
+
+				if _, err := rw.Write(responseBody); err != nil {
+					assert.FailNow(t, "failed to write the response body: %w", err)
+				}
+			}))
+			defer server.Close()
+
+			// Configure the DiscogsClient to use the mock server
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+			client.Host = server.URL
+
+			// Call the Master method
+			res, err := client.Master(ctx, tt.args)
+
+			// Check the error
+			if err != nil {
+				assert.EqualError(t, err, tt.want.err.Error())
+			} else {
+				assert.NoError(t, tt.want.err)
+			}
+
+			// Check the response
+			if res != nil {
+				assert.Equal(t, res, tt.want.res)
+			} else {
+				assert.Nil(t, tt.want.res)
+			}
+		})
+	}
+}
+
+func TestDatabase_MainRelease(t *testing.T) {
+	type want struct {
+		res *discogs.ReleaseResponse
+		err error
+	}
+	tests := []struct {
+		name string
+		args int64
+		want want
+	}{
+		{
+			"successful main release fetch",
+			1,
+			want{&discogs.ReleaseResponse{ID: 10, Title: "Main Release"}, nil},
+		},
+		{
+			"master not found",
+			2,
+			want{nil, &discogs.ErrNotFound{discogs.ResourceMaster, "2", &discogs.HTTPError{http.StatusNotFound, `{"message":"Master Release not found."}`}}},
+		},
+		{
+			"main release not found",
+			3,
+			want{nil, &discogs.ErrNotFound{discogs.ResourceRelease, "30", &discogs.HTTPError{http.StatusNotFound, `{"message":"Release not found."}`}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				switch req.URL.Path {
+				case "/masters/1":
+					_ = json.NewEncoder(rw).Encode(discogs.MasterResponse{ID: 1, MainRelease: 10})
+				case "/masters/3":
+					_ = json.NewEncoder(rw).Encode(discogs.MasterResponse{ID: 3, MainRelease: 30})
+				case "/releases/10":
+					_ = json.NewEncoder(rw).Encode(discogs.ReleaseResponse{ID: 10, Title: "Main Release"})
+				case "/masters/2":
+					rw.WriteHeader(http.StatusNotFound)
+					_, _ = rw.Write([]byte(`{"message":"Master Release not found."}`))
+				default:
+					rw.WriteHeader(http.StatusNotFound)
+					_, _ = rw.Write([]byte(`{"message":"Release not found."}`))
+				}
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+			client.Host = server.URL
+
+			res, err := client.MainRelease(ctx, tt.args)
+			assert.Equal(t, tt.want.err, err)
+			assert.Equal(t, tt.want.res, res)
+		})
+	}
+}
+
+func TestDatabase_UpdateReleaseRating(t *testing.T) {
+	type args struct {
+		releaseID int64
+		rating    int
+	}
+	type want struct {
+		res *discogs.UserReleaseRatingResponse
+		err error
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"successful rating update",
+			args{1, 5},
+			want{&discogs.UserReleaseRatingResponse{Username: "some user", ReleaseID: 1, Rating: 5}, nil},
+		},
+		{
+			"rating too low",
+			args{1, 0},
+			want{nil, &discogs.ErrInvalidOption{Option: "rating", Value: "0"}},
+		},
+		{
+			"rating too high",
+			args{1, 6},
+			want{nil, &discogs.ErrInvalidOption{Option: "rating", Value: "6"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, http.MethodPut, req.Method)
+				assert.Equal(t, "/releases/1/rating/some%20user", req.URL.EscapedPath())
+				assert.Equal(t, "Bearer token", req.Header.Get(discogs.AuthHeader))
+
+				var body struct {
+					Rating int `json:"rating"`
+				}
+				assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+
+				_ = json.NewEncoder(rw).Encode(discogs.UserReleaseRatingResponse{Username: "some user", ReleaseID: 1, Rating: body.Rating})
+			}))
+			defer server.Close()
+
+			token := "token"
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+			client.Host = server.URL
+
+			res, err := client.UpdateReleaseRating(ctx, tt.args.releaseID, "some user", tt.args.rating)
+			assert.Equal(t, tt.want.err, err)
+			assert.Equal(t, tt.want.res, res)
+		})
+	}
+}
@@ -1,29 +1,5 @@
 package discogs
 
-import (
-	"fmt"
-	"time"
-)
-
-// Currency represents a currency code used in the Discogs API.
-type Currency string
-
-// Currency constants representing various supported currencies.
-const (
-	CurrencyUSD Currency = "USD"
-	CurrencyGBP Currency = "GBP"
-	CurrencyEUR Currency = "EUR"
-	CurrencyCAD Currency = "CAD"
-	CurrencyAUD Currency = "AUD"
-	CurrencyJPY Currency = "JPY"
-	CurrencyCHF Currency = "CHF"
-	CurrencyMXN Currency = "MXN"
-	CurrencyBRL Currency = "BRL"
-	CurrencyNZD Currency = "NZD"
-	CurrencySEK Currency = "SEK"
-	CurrencyZAR Currency = "ZAR"
-)
-
 // Type represents a type of entity in the Discogs database.
 type Type string
 
@@ -35,17 +11,6 @@ const (
 	TypeLabel   = "label"
 )
 
-// ErrReleaseNotFound indicates that a release with the specified ID was not found.
-type ErrReleaseNotFound struct {
-	ReleaseID int
-	*HTTPError
-}
-
-// Error returns a formatted error message indicating that the release was not found.
-func (e *ErrReleaseNotFound) Error() string {
-	return fmt.Sprintf("Release ID %d not found: %s", e.ReleaseID, e.Message)
-}
-
 // PaginationParams represents the pagination parameters for API requests.
 type PaginationParams struct {
 	Page    *int `url:"page,omitempty"`
@@ -59,115 +24,419 @@ type ReleaseOptions struct {
 
 // ReleaseResponse represents the response from the Discogs API for a release.
 type ReleaseResponse struct {
-	Title   string `json:"title"`
-	ID      int64  `json:"id"`
-	Artists []struct {
-		ANV         string `json:"anv"`
-		ID          *int64 `json:"id"`
-		Join        string `json:"join"`
-		Name        string `json:"name"`
-		ResourceURL string `json:"resource_url"`
-		Role        string `json:"role"`
-		Tracks      string `json:"tracks"`
-	} `json:"artists"`
-	DataQuality string `json:"data_quality"`
+	RawResponse
+	ExtraFields
+	Title             string            `json:"title"`
+	ID                int64             `json:"id"`
+	Artists           []ArtistCredit    `json:"artists"`
+	DataQuality       string            `json:"data_quality"`
+	Thumb             string            `json:"thumb"`
+	Community         *ReleaseCommunity `json:"community,omitempty"`
+	Companies         []Company         `json:"companies"`
+	Country           string            `json:"country"`
+	DateAdded         *Timestamp        `json:"date_added,omitempty"`
+	DateChanged       *Timestamp        `json:"date_changed,omitempty"`
+	EstimatedWeight   *int64            `json:"estimated_weight,omitempty"`
+	ExtraArtists      []ArtistCredit    `json:"extraartists"`
+	FormatQuantity    *int64            `json:"format_quantity,omitempty"`
+	Formats           []Format          `json:"formats"`
+	Genres            []string          `json:"genres"`
+	Identifiers       []Identifier      `json:"identifiers"`
+	Images            []Image           `json:"images"`
+	Labels            []LabelCredit     `json:"labels"`
+	LowestPrice       *Amount           `json:"lowest_price,omitempty"`
+	MasterID          *int64            `json:"master_id,omitempty"`
+	MasterURL         string            `json:"master_url"`
+	Notes             string            `json:"notes"`
+	NumForSale        *int64            `json:"num_for_sale,omitempty"`
+	Released          string            `json:"released"`
+	ReleasedFormatted string            `json:"released_formatted"`
+	ResourceURL       string            `json:"resource_url"`
+	Series            []interface{}     `json:"series"`
+	Status            string            `json:"status"`
+	Styles            []string          `json:"styles"`
+	Tracklist         []Track           `json:"tracklist"`
+	URI               string            `json:"uri"`
+	Videos            []Video           `json:"videos"`
+	Year              *int64            `json:"year,omitempty"`
+}
+
+// ArtistCredit represents an artist credited on a release, either as a main artist or as an extra artist.
+type ArtistCredit struct {
+	ANV         string `json:"anv"`
+	ID          *int64 `json:"id,omitempty"`
+	Join        string `json:"join"`
+	Name        string `json:"name"`
+	ResourceURL string `json:"resource_url"`
+	Role        string `json:"role"`
+	Tracks      string `json:"tracks"`
+}
+
+// ReleaseCommunity represents the community data of a release, such as its rating and contributors.
+type ReleaseCommunity struct {
+	Contributors []CommunityUser  `json:"contributors"`
+	DataQuality  string           `json:"data_quality"`
+	Have         *int64           `json:"have,omitempty"`
+	Rating       *CommunityRating `json:"rating,omitempty"`
+	Status       *string          `json:"status,omitempty"`
+	Submitter    *CommunityUser   `json:"submitter,omitempty"`
+	Want         *int64           `json:"want,omitempty"`
+}
+
+// CommunityUser represents a user referenced in community data.
+type CommunityUser struct {
+	ResourceURL string `json:"resource_url"`
+	Username    string `json:"username"`
+}
+
+// CommunityRating represents the average rating given by the community.
+type CommunityRating struct {
+	Average *float64 `json:"average,omitempty"`
+	Count   *int64   `json:"count,omitempty"`
+}
+
+// Company represents a company credited on a release.
+type Company struct {
+	CatNo          string `json:"catno"`
+	EntityType     string `json:"entity_type"`
+	EntityTypeName string `json:"entity_type_name"`
+	ID             *int64 `json:"id,omitempty"`
+	Name           string `json:"name"`
+	ResourceURL    string `json:"resource_url"`
+}
+
+// Format represents a format of a release.
+type Format struct {
+	Descriptions []string `json:"descriptions"`
+	Name         string   `json:"name"`
+	Qty          string   `json:"qty"`
+}
+
+// Identifier represents an identifier of a release, such as a barcode or matrix number.
+type Identifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Image represents an image of a resource.
+type Image struct {
+	Height      *int64 `json:"height,omitempty"`
+	ResourceURL string `json:"resource_url"`
+	Type        string `json:"type"`
+	URI         string `json:"uri"`
+	URI150      string `json:"uri150"`
+	Width       *int64 `json:"width,omitempty"`
+}
+
+// LabelCredit represents a label credited on a release.
+type LabelCredit struct {
+	CatNo       string `json:"catno"`
+	EntityType  string `json:"entity_type"`
+	ID          *int64 `json:"id,omitempty"`
+	Name        string `json:"name"`
+	ResourceURL string `json:"resource_url"`
+}
+
+// Track represents a track in the tracklist of a release.
+type Track struct {
+	Duration string `json:"duration"`
+	Position string `json:"position"`
+	Title    string `json:"title"`
+	Type_    string `json:"type_"`
+}
+
+// Video represents a video linked to a release.
+type Video struct {
+	Description string `json:"description"`
+	Duration    *int64 `json:"duration,omitempty"`
+	Embed       *bool  `json:"embed,omitempty"`
+	Title       string `json:"title"`
+	URI         string `json:"uri"`
+}
+
+// CommunityReleaseRatingResponse represents the response from the Discogs API for the community rating of a release.
+type CommunityReleaseRatingResponse struct {
+	RawResponse
+	ExtraFields
+	ReleaseID int64            `json:"release_id"`
+	Rating    *CommunityRating `json:"rating,omitempty"`
+}
+
+// UserReleaseRatingResponse represents the response from the Discogs API for the rating of a release by a user.
+type UserReleaseRatingResponse struct {
+	RawResponse
+	ExtraFields
+	Username  string `json:"username"`
+	ReleaseID int64  `json:"release_id"`
+	Rating    int    `json:"rating"`
+}
+
+// ReleaseStatsResponse represents the response from the Discogs API for the statistics of a release.
+type ReleaseStatsResponse struct {
+	RawResponse
+	ExtraFields
+	NumHave     *int64 `json:"num_have,omitempty"`
+	NumWant     *int64 `json:"num_want,omitempty"`
+	IsOffensive *bool  `json:"is_offensive,omitempty"`
+}
+
+// MasterResponse represents the response from the Discogs API for a master release.
+type MasterResponse struct {
+	RawResponse
+	ExtraFields
+	ID                   int64          `json:"id"`
+	Title                string         `json:"title"`
+	Artists              []ArtistCredit `json:"artists"`
+	DataQuality          string         `json:"data_quality"`
+	Genres               []string       `json:"genres"`
+	Images               []Image        `json:"images"`
+	LowestPrice          *Amount        `json:"lowest_price,omitempty"`
+	MainRelease          int64          `json:"main_release"`
+	MainReleaseURL       string         `json:"main_release_url"`
+	MostRecentRelease    *int64         `json:"most_recent_release,omitempty"`
+	MostRecentReleaseURL string         `json:"most_recent_release_url"`
+	NumForSale           *int64         `json:"num_for_sale,omitempty"`
+	ResourceURL          string         `json:"resource_url"`
+	Styles               []string       `json:"styles"`
+	Tracklist            []Track        `json:"tracklist"`
+	URI                  string         `json:"uri"`
+	VersionsURL          string         `json:"versions_url"`
+	Videos               []Video        `json:"videos"`
+	Year                 *int64         `json:"year,omitempty"`
+}
+
+// ArtistResponse represents the response from the Discogs API for an artist.
+type ArtistResponse struct {
+	RawResponse
+	ExtraFields
+	ID             int64          `json:"id"`
+	Name           string         `json:"name"`
+	RealName       string         `json:"realname"`
+	Profile        string         `json:"profile"`
+	DataQuality    string         `json:"data_quality"`
+	Images         []Image        `json:"images"`
+	NameVariations []string       `json:"namevariations"`
+	Aliases        []ArtistRef    `json:"aliases"`
+	Members        []ArtistMember `json:"members"`
+	Groups         []ArtistMember `json:"groups"`
+	URLs           []string       `json:"urls"`
+	ReleasesURL    string         `json:"releases_url"`
+	ResourceURL    string         `json:"resource_url"`
+	URI            string         `json:"uri"`
+}
+
+// ArtistRef represents a reference to an alias of an artist.
+type ArtistRef struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	ResourceURL string `json:"resource_url"`
+}
+
+// ArtistMember represents a member of a group, or a group an artist is a member of.
+type ArtistMember struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Active      bool   `json:"active"`
+	ResourceURL string `json:"resource_url"`
+}
+
+// ArtistReleaseSort represents a field the releases of an artist can be sorted by.
+type ArtistReleaseSort string
+
+// ArtistReleaseSort constants representing the fields the releases of an artist can be sorted by.
+const (
+	ArtistReleaseSortYear   ArtistReleaseSort = "year"
+	ArtistReleaseSortTitle  ArtistReleaseSort = "title"
+	ArtistReleaseSortFormat ArtistReleaseSort = "format"
+)
+
+// ArtistRole represents the role of an artist on a release.
+type ArtistRole string
+
+// ArtistRole constants representing the most common roles of an artist on a release.
+const (
+	ArtistRoleMain              ArtistRole = "Main"
+	ArtistRoleAppearance        ArtistRole = "Appearance"
+	ArtistRoleTrackAppearance   ArtistRole = "TrackAppearance"
+	ArtistRoleUnofficialRelease ArtistRole = "UnofficialRelease"
+)
+
+// ArtistReleasesOptions represents the options for retrieving the releases of an artist.
+type ArtistReleasesOptions struct {
+	PaginationParams
+	Sort      ArtistReleaseSort `url:"sort,omitempty"`
+	SortOrder SortOrder         `url:"sort_order,omitempty"`
+}
+
+// ArtistReleasesResponse represents the response from the Discogs API for the releases of an artist.
+type ArtistReleasesResponse struct {
+	RawResponse
+	ExtraFields
+	Pagination *Pagination     `json:"pagination,omitempty"`
+	Releases   []ArtistRelease `json:"releases"`
+}
+
+// ArtistRelease represents a release or master release an artist is credited on.
+type ArtistRelease struct {
+	ID          int64            `json:"id"`
+	Type        string           `json:"type"`
+	MainRelease *int64           `json:"main_release,omitempty"` // Only set if Type is TypeMaster.
+	Artist      string           `json:"artist"`
+	Title       string           `json:"title"`
+	Year        *int64           `json:"year,omitempty"`
+	Role        ArtistRole       `json:"role"`
+	ResourceURL string           `json:"resource_url"`
+	Thumb       string           `json:"thumb"`
+	Status      string           `json:"status"`
+	Format      string           `json:"format"`
+	Label       string           `json:"label"`
+	TrackInfo   string           `json:"trackinfo"`
+	Stats       *CollectionStats `json:"stats,omitempty"`
+}
+
+// CollectionStats represents how many collections and wantlists a release is in, across the community and for the
+// authenticated user.
+type CollectionStats struct {
+	Community *CollectionStatsCounts `json:"community,omitempty"`
+	User      *CollectionStatsCounts `json:"user,omitempty"`
+}
+
+// CollectionStatsCounts represents the number of collections and wantlists a release is in.
+type CollectionStatsCounts struct {
+	InCollection *int64 `json:"in_collection,omitempty"`
+	InWantlist   *int64 `json:"in_wantlist,omitempty"`
+}
+
+// MasterVersionSort represents a field the versions of a master release can be sorted by.
+type MasterVersionSort string
+
+// MasterVersionSort constants representing the fields the versions of a master release can be sorted by.
+const (
+	MasterVersionSortReleased MasterVersionSort = "released"
+	MasterVersionSortTitle    MasterVersionSort = "title"
+	MasterVersionSortFormat   MasterVersionSort = "format"
+	MasterVersionSortLabel    MasterVersionSort = "label"
+	MasterVersionSortCatNo    MasterVersionSort = "catno"
+	MasterVersionSortCountry  MasterVersionSort = "country"
+)
+
+// Facet constants representing the IDs of the facets the versions of a master release can be filtered by.
+const (
+	FacetFormat   = "format"
+	FacetLabel    = "label"
+	FacetCountry  = "country"
+	FacetReleased = "released"
+)
+
+// MasterVersionsOptions represents the options for retrieving the versions of a master release.
+type MasterVersionsOptions struct {
+	PaginationParams
+	Format    string            `url:"format,omitempty"`
+	Label     string            `url:"label,omitempty"`
+	Released  string            `url:"released,omitempty"`
+	Country   string            `url:"country,omitempty"`
+	Sort      MasterVersionSort `url:"sort,omitempty"`
+	SortOrder SortOrder         `url:"sort_order,omitempty"`
+}
+
+// MasterVersionsResponse represents the response from the Discogs API for the versions of a master release.
+type MasterVersionsResponse struct {
+	RawResponse
+	ExtraFields
+	Pagination   *Pagination           `json:"pagination,omitempty"`
+	Filters      *MasterVersionFilters `json:"filters,omitempty"`
+	FilterFacets []FilterFacet         `json:"filter_facets"`
+	Versions     []MasterVersion       `json:"versions"`
+}
+
+// MasterVersionFilters represents the filters applied to the versions of a master release, and the values available
+// for further filtering along with the number of versions matching each value. Both are keyed by facet ID.
+type MasterVersionFilters struct {
+	Applied   map[string][]FacetValue     `json:"applied"`
+	Available map[string]map[string]int64 `json:"available"`
+}
+
+// FilterFacet represents a facet the versions of a master release can be filtered by, such as their format or
+// country, along with its available values.
+type FilterFacet struct {
+	ID                   string       `json:"id"`
+	Title                string       `json:"title"`
+	Values               []FacetValue `json:"values"`
+	AllowsMultipleValues bool         `json:"allows_multiple_values"`
+}
+
+// FacetValue represents a value of a facet and the number of versions matching it.
+type FacetValue struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// MasterVersion represents a version of a master release.
+type MasterVersion struct {
+	ID           int64            `json:"id"`
+	Title        string           `json:"title"`
+	Label        string           `json:"label"`
+	Country      string           `json:"country"`
+	CatNo        string           `json:"catno"`
+	Format       string           `json:"format"`
+	MajorFormats []string         `json:"major_formats"`
+	Released     string           `json:"released"`
+	Status       string           `json:"status"`
+	Thumb        string           `json:"thumb"`
+	ResourceURL  string           `json:"resource_url"`
+	Stats        *CollectionStats `json:"stats,omitempty"`
+}
+
+// LabelResponse represents the response from the Discogs API for a label.
+type LabelResponse struct {
+	RawResponse
+	ExtraFields
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Profile     string     `json:"profile"`
+	ContactInfo string     `json:"contact_info"`
+	DataQuality string     `json:"data_quality"`
+	Images      []Image    `json:"images"`
+	ParentLabel *LabelRef  `json:"parent_label,omitempty"`
+	Sublabels   []LabelRef `json:"sublabels"`
+	URLs        []string   `json:"urls"`
+	ReleasesURL string     `json:"releases_url"`
+	ResourceURL string     `json:"resource_url"`
+	URI         string     `json:"uri"`
+}
+
+// LabelRef represents a reference to a parent label or sublabel of a label.
+type LabelRef struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	ResourceURL string `json:"resource_url"`
+}
+
+// LabelReleasesResponse represents the response from the Discogs API for the releases of a label.
+type LabelReleasesResponse struct {
+	RawResponse
+	ExtraFields
+	Pagination *Pagination    `json:"pagination,omitempty"`
+	Releases   []LabelRelease `json:"releases"`
+}
+
+// LabelRelease represents a release of a label.
+type LabelRelease struct {
+	ID          int64  `json:"id"`
+	Artist      string `json:"artist"`
+	Title       string `json:"title"`
+	CatNo       string `json:"catno"`
+	Format      string `json:"format"`
+	Year        *int64 `json:"year,omitempty"`
+	Status      string `json:"status"`
 	Thumb       string `json:"thumb"`
-	Community   *struct {
-		Contributors []struct {
-			ResourceURL string `json:"resource_url"`
-			Username    string `json:"username"`
-		} `json:"contributors"`
-		DataQuality string `json:"data_quality"`
-		Have        *int64 `json:"have"`
-		Rating      *struct {
-			Average *float64 `json:"average"`
-			Count   *int64   `json:"count"`
-		} `json:"rating"`
-		Status    *string `json:"status"`
-		Submitter *struct {
-			ResourceURL string `json:"resource_url"`
-			Username    string `json:"username"`
-		} `json:"submitter"`
-		Want *int64 `json:"want"`
-	} `json:"community"`
-	Companies []struct {
-		CatNo          string `json:"catno"`
-		EntityType     string `json:"entity_type"`
-		EntityTypeName string `json:"entity_type_name"`
-		ID             *int64 `json:"id"`
-		Name           string `json:"name"`
-		ResourceURL    string `json:"resource_url"`
-	} `json:"companies"`
-	Country         string     `json:"country"`
-	DateAdded       *time.Time `json:"date_added"`
-	DateChanged     *time.Time `json:"date_changed"`
-	EstimatedWeight *int64     `json:"estimated_weight"`
-	ExtraArtists    []struct {
-		ANV         string `json:"anv"`
-		ID          *int64 `json:"id"`
-		Join        string `json:"join"`
-		Name        string `json:"name"`
-		ResourceURL string `json:"resource_url"`
-		Role        string `json:"role"`
-		Tracks      string `json:"tracks"`
-	} `json:"extraartists"`
-	FormatQuantity *int64 `json:"format_quantity"`
-	Formats        []struct {
-		Descriptions []string `json:"descriptions"`
-		Name         string   `json:"name"`
-		Qty          string   `json:"qty"`
-	} `json:"formats"`
-	Genres      []string `json:"genres"`
-	Identifiers []struct {
-		Type  string `json:"type"`
-		Value string `json:"value"`
-	} `json:"identifiers"`
-	Images []struct {
-		Height      *int64 `json:"height"`
-		ResourceURL string `json:"resource_url"`
-		Type        string `json:"type"`
-		URI         string `json:"uri"`
-		URI150      string `json:"uri150"`
-		Width       *int64 `json:"width"`
-	} `json:"images"`
-	Labels []struct {
-		CatNo       string `json:"catno"`
-		EntityType  string `json:"entity_type"`
-		ID          *int64 `json:"id"`
-		Name        string `json:"name"`
-		ResourceURL string `json:"resource_url"`
-	} `json:"labels"`
-	LowestPrice       *float64      `json:"lowest_price"`
-	MasterID          *int64        `json:"master_id"`
-	MasterURL         string        `json:"master_url"`
-	Notes             string        `json:"notes"`
-	NumForSale        *int64        `json:"num_for_sale"`
-	Released          string        `json:"released"`
-	ReleasedFormatted string        `json:"released_formatted"`
-	ResourceURL       string        `json:"resource_url"`
-	Series            []interface{} `json:"series"`
-	Status            string        `json:"status"`
-	Styles            []string      `json:"styles"`
-	Tracklist         []struct {
-		Duration string `json:"duration"`
-		Position string `json:"position"`
-		Title    string `json:"title"`
-		Type_    string `json:"type_"`
-	} `json:"tracklist"`
-	URI    string `json:"uri"`
-	Videos []struct {
-		Description string `json:"description"`
-		Duration    *int64 `json:"duration"`
-		Embed       *bool  `json:"embed"`
-		Title       string `json:"title"`
-		URI         string `json:"uri"`
-	} `json:"videos"`
-	Year *int64 `json:"year"`
+	ResourceURL string `json:"resource_url"`
 }
 
 // SearchOptions represents the options for performing a search query in the Discogs database.
 type SearchOptions struct {
-	Pagination   PaginationParams
+	PaginationParams
 	Query        string `url:"q,omitempty"`
 	Type         Type   `url:"type,omitempty"`
 	Title        string `url:"title,omitempty"`
@@ -190,27 +459,96 @@ type SearchOptions struct {
 
 // SearchResponse represents the response from the Discogs API for a search query.
 type SearchResponse struct {
-	Pagination *Pagination    `json:"pagination"`
+	RawResponse
+	ExtraFields
+	Pagination *Pagination    `json:"pagination,omitempty"`
 	Results    []SearchResult `json:"results"`
 }
 
 // SearchResult represents a single result from a search query.
 type SearchResult struct {
-	Style     []string `json:"style"`
-	Thumb     string   `json:"thumb"`
-	Title     string   `json:"title"`
-	Country   string   `json:"country"`
-	Format    []string `json:"format"`
-	URI       string   `json:"uri"`
-	Community struct {
-		Want *int64 `json:"want"`
-		Have *int64 `json:"have"`
-	} `json:"community"`
-	Label       []string `json:"label"`
-	CatNo       string   `json:"catno"`
-	Year        string   `json:"year"`
-	Genre       []string `json:"genre"`
-	ResourceURL string   `json:"resource_url"`
-	Type        Type     `json:"type"`
-	ID          *int64   `json:"id"`
+	Style       []string        `json:"style"`
+	Thumb       string          `json:"thumb"`
+	Title       string          `json:"title"`
+	Country     string          `json:"country"`
+	Format      []string        `json:"format"`
+	URI         string          `json:"uri"`
+	Community   SearchCommunity `json:"community"`
+	Label       []string        `json:"label"`
+	CatNo       string          `json:"catno"`
+	Barcode     []string        `json:"barcode"`
+	Year        string          `json:"year"`
+	Genre       []string        `json:"genre"`
+	ResourceURL string          `json:"resource_url"`
+	Type        Type            `json:"type"`
+	ID          *int64          `json:"id,omitempty"`
+	CoverImage  string          `json:"cover_image"`
+	MasterID    *int64          `json:"master_id,omitempty"` // Only set if Type is TypeRelease and the release has a master.
+	MasterURL   string          `json:"master_url"`
+}
+
+// ReleaseResult represents a search result of type TypeRelease, holding the fields Discogs returns for releases.
+type ReleaseResult struct {
+	ID          int64
+	Title       string
+	Year        string
+	Country     string
+	Format      []string
+	Label       []string
+	CatNo       string
+	Barcode     []string
+	Genre       []string
+	Style       []string
+	Community   SearchCommunity
+	MasterID    *int64 // Only set if the release has a master.
+	Thumb       string
+	CoverImage  string
+	URI         string
+	ResourceURL string
+}
+
+// MasterResult represents a search result of type TypeMaster, holding the fields Discogs returns for masters. The
+// format, label, catalog number and barcode fields are the ones of the main release.
+type MasterResult struct {
+	ID          int64
+	Title       string
+	Year        string
+	Country     string
+	Format      []string
+	Label       []string
+	CatNo       string
+	Barcode     []string
+	Genre       []string
+	Style       []string
+	Community   SearchCommunity
+	Thumb       string
+	CoverImage  string
+	URI         string
+	ResourceURL string
+}
+
+// ArtistResult represents a search result of type TypeArtist, holding the fields Discogs returns for artists.
+type ArtistResult struct {
+	ID          int64
+	Name        string
+	Thumb       string
+	CoverImage  string
+	URI         string
+	ResourceURL string
+}
+
+// LabelResult represents a search result of type TypeLabel, holding the fields Discogs returns for labels.
+type LabelResult struct {
+	ID          int64
+	Name        string
+	Thumb       string
+	CoverImage  string
+	URI         string
+	ResourceURL string
+}
+
+// SearchCommunity represents the community statistics of a search result.
+type SearchCommunity struct {
+	Want *int64 `json:"want,omitempty"`
+	Have *int64 `json:"have,omitempty"`
 }
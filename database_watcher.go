@@ -0,0 +1,246 @@
+package discogs
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DatabaseChange represents a release, artist or label that changed between two polls of a DatabaseWatcher. Resource
+// tells which of Release, Artist and Label is set; none is set if the entity was removed.
+type DatabaseChange struct {
+	Resource Resource
+	ID       int64
+	// Removed reports whether the entity is no longer found in the database.
+	Removed bool
+	Release *ReleaseResponse
+	Artist  *ArtistResponse
+	Label   *LabelResponse
+}
+
+// DatabaseWatcherOptions represents the options for a DatabaseWatcher.
+type DatabaseWatcherOptions struct {
+	// Interval is the interval between two polls. DefaultWatchInterval is used if unset.
+	Interval time.Duration
+	// Releases, Artists and Labels are the IDs of the entities initially watched.
+	Releases []int64
+	Artists  []int64
+	Labels   []int64
+	// OnChange is called for every entity changed since the previous poll.
+	OnChange func(change DatabaseChange)
+	// OnError is called when Run fails to poll an entity.
+	OnError func(err error)
+	// Feed receives an EventDatabaseChanged event for every change, after OnChange is called.
+	Feed *EventFeed
+}
+
+// watchKey identifies an entity watched by a DatabaseWatcher.
+type watchKey struct {
+	resource Resource
+	id       int64
+}
+
+// removedVersion is the version of an entity that is no longer found.
+const removedVersion = "removed"
+
+// A DatabaseWatcher periodically re-fetches a set of releases, artists and labels and reports the ones that changed
+// since the previous poll. Discogs has no webhooks, so polling is the only way to follow edits of the database.
+//
+// Releases are compared by their date_changed field, so changes of their community and marketplace statistics are
+// not reported. Artists and labels have no such field and are compared by a hash of their content. The first poll of
+// an entity only records its version. Every poll goes through the client, so it respects the rate limit: watching n
+// entities costs n requests per Interval.
+//
+// Changes are reported to OnChange and published to the Feed, whose subscriptions deliver them on a channel.
+//
+// A DatabaseWatcher is safe for concurrent use.
+type DatabaseWatcher struct {
+	client  *DiscogsClient
+	options DatabaseWatcherOptions
+
+	mu sync.Mutex
+	// versions holds the last known version of every watched entity, or the empty string before its first poll.
+	versions map[watchKey]string
+}
+
+// NewDatabaseWatcher creates a new DatabaseWatcher that polls entities using the DiscogsClient. If options is nil,
+// the default options are used and no entity is watched until Watch is called.
+func (dc *DiscogsClient) NewDatabaseWatcher(options *DatabaseWatcherOptions) *DatabaseWatcher {
+	w := &DatabaseWatcher{client: dc, versions: make(map[watchKey]string)}
+	if options != nil {
+		w.options = *options
+	}
+	if w.options.Interval <= 0 {
+		w.options.Interval = DefaultWatchInterval
+	}
+
+	for _, id := range w.options.Releases {
+		w.versions[watchKey{ResourceRelease, id}] = ""
+	}
+	for _, id := range w.options.Artists {
+		w.versions[watchKey{ResourceArtist, id}] = ""
+	}
+	for _, id := range w.options.Labels {
+		w.versions[watchKey{ResourceLabel, id}] = ""
+	}
+	return w
+}
+
+// Watch adds an entity to the watched entities. It does nothing if the entity is already watched. It returns an
+// ErrInvalidOption if resource is not ResourceRelease, ResourceArtist or ResourceLabel.
+func (w *DatabaseWatcher) Watch(resource Resource, id int64) error {
+	if err := validateWatchResource(resource); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.versions[watchKey{resource, id}]; !ok {
+		w.versions[watchKey{resource, id}] = ""
+	}
+	return nil
+}
+
+// Unwatch removes an entity from the watched entities.
+func (w *DatabaseWatcher) Unwatch(resource Resource, id int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.versions, watchKey{resource, id})
+}
+
+// validateWatchResource returns an ErrInvalidOption if a DatabaseWatcher cannot watch entities of resource.
+func validateWatchResource(resource Resource) error {
+	switch resource {
+	case ResourceRelease, ResourceArtist, ResourceLabel:
+		return nil
+	}
+	return &ErrInvalidOption{Option: "resource", Value: string(resource)}
+}
+
+// Run polls the watched entities every Interval until ctx is canceled, and then returns the context's error.
+func (w *DatabaseWatcher) Run(ctx context.Context) error {
+	return pollEvery(ctx, w.options.Interval, func(ctx context.Context) error {
+		_, err := w.Poll(ctx)
+		return err
+	}, w.options.OnError)
+}
+
+// Poll fetches every watched entity once, reports the ones that changed since the previous poll and returns them.
+// An entity that fails to be fetched keeps its version and is compared again at the next poll; the errors are joined
+// and returned after the other entities are polled.
+//
+// The watched entities are fetched, and the changes reported, without holding the lock of the watcher, so Watch and
+// Unwatch can be called during a poll, including from OnChange. Entities unwatched during a poll are not reported.
+func (w *DatabaseWatcher) Poll(ctx context.Context) ([]DatabaseChange, error) {
+	w.mu.Lock()
+	keys := make([]watchKey, 0, len(w.versions))
+	for key := range w.versions {
+		keys = append(keys, key)
+	}
+	w.mu.Unlock()
+
+	slices.SortFunc(keys, func(a, b watchKey) int {
+		if a.resource != b.resource {
+			return cmp.Compare(a.resource, b.resource)
+		}
+		return cmp.Compare(a.id, b.id)
+	})
+
+	type fetched struct {
+		change  DatabaseChange
+		version string
+	}
+	var results []fetched
+	var errs []error
+	for _, key := range keys {
+		change, version, err := w.fetch(ctx, key)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, fetched{change, version})
+	}
+
+	// Compare with the versions stored now, so changes already reported by a concurrent poll are not reported again
+	var changes []DatabaseChange
+	w.mu.Lock()
+	for _, result := range results {
+		key := watchKey{result.change.Resource, result.change.ID}
+		previous, ok := w.versions[key]
+		if !ok {
+			continue
+		}
+		w.versions[key] = result.version
+		if previous == "" || previous == result.version {
+			continue
+		}
+		changes = append(changes, result.change)
+	}
+	w.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return changes, err
+	}
+
+	for i := range changes {
+		if w.options.OnChange != nil {
+			w.options.OnChange(changes[i])
+		}
+		if err := w.options.Feed.Publish(ctx, Event{Kind: EventDatabaseChanged, Database: &changes[i]}); err != nil {
+			return changes, err
+		}
+	}
+
+	return changes, errors.Join(errs...)
+}
+
+// fetch fetches a watched entity and returns it as a change along with its version.
+func (w *DatabaseWatcher) fetch(ctx context.Context, key watchKey) (DatabaseChange, string, error) {
+	change := DatabaseChange{Resource: key.resource, ID: key.id}
+
+	var err error
+	var entity any
+	switch key.resource {
+	case ResourceRelease:
+		change.Release, err = w.client.Release(ctx, key.id, nil)
+		if err == nil && change.Release.DateChanged != nil {
+			return change, change.Release.DateChanged.Format(time.RFC3339), nil
+		}
+		entity = change.Release
+	case ResourceArtist:
+		change.Artist, err = w.client.Artist(ctx, key.id)
+		entity = change.Artist
+	case ResourceLabel:
+		change.Label, err = w.client.Label(ctx, key.id)
+		entity = change.Label
+	}
+
+	if IsNotFound(err) {
+		return DatabaseChange{Resource: key.resource, ID: key.id, Removed: true}, removedVersion, nil
+	}
+	if err != nil {
+		return change, "", err
+	}
+
+	version, err := contentHash(entity)
+	return change, version, err
+}
+
+// contentHash returns a hash of the JSON encoding of v.
+func contentHash(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,116 @@
+package discogs_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseWatcher_Poll(t *testing.T) {
+	var mu sync.Mutex
+	bodies := map[string]string{
+		"/releases/1": `{"id":1,"date_changed":"2024-01-01T00:00:00-08:00","community":{"have":1}}`,
+		"/artists/2":  `{"id":2,"name":"Artist"}`,
+		"/labels/3":   `{"id":3,"name":"Label"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, ok := bodies[req.URL.Path]
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			body = `{"message":"Not found."}`
+		}
+		_, _ = rw.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	feed := discogs.NewEventFeed()
+	sub := feed.Subscribe(4)
+	defer sub.Close()
+
+	watcher := client.NewDatabaseWatcher(&discogs.DatabaseWatcherOptions{
+		Releases: []int64{1},
+		Artists:  []int64{2},
+		Labels:   []int64{3},
+		Feed:     feed,
+	})
+
+	// The first poll only records the versions
+	changes, err := watcher.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	mu.Lock()
+	// Statistics of a release do not change its version, unlike edits of an artist
+	bodies["/releases/1"] = `{"id":1,"date_changed":"2024-01-01T00:00:00-08:00","community":{"have":2}}`
+	bodies["/artists/2"] = `{"id":2,"name":"Renamed Artist"}`
+	delete(bodies, "/labels/3")
+	mu.Unlock()
+
+	changes, err = watcher.Poll(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, discogs.ResourceArtist, changes[0].Resource)
+		assert.Equal(t, "Renamed Artist", changes[0].Artist.Name)
+		assert.Equal(t, discogs.DatabaseChange{Resource: discogs.ResourceLabel, ID: 3, Removed: true}, changes[1])
+	}
+
+	event := <-sub.Events()
+	assert.Equal(t, discogs.EventDatabaseChanged, event.Kind)
+	assert.Equal(t, int64(2), event.Database.ID)
+
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "resource", Value: "user"}, watcher.Watch(discogs.ResourceUser, 1))
+}
+
+func TestDatabaseWatcher_UnwatchFromOnChange(t *testing.T) {
+	var mu sync.Mutex
+	name := "Artist"
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		_, _ = fmt.Fprintf(rw, `{"id":2,"name":%q}`, name)
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	var watcher *discogs.DatabaseWatcher
+	watcher = client.NewDatabaseWatcher(&discogs.DatabaseWatcherOptions{
+		Artists: []int64{2},
+		OnChange: func(change discogs.DatabaseChange) {
+			// Would deadlock if the watcher was locked while reporting the changes
+			watcher.Unwatch(change.Resource, change.ID)
+		},
+	})
+
+	_, err := watcher.Poll(ctx)
+	assert.NoError(t, err)
+
+	mu.Lock()
+	name = "Renamed Artist"
+	mu.Unlock()
+
+	changes, err := watcher.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+
+	changes, err = watcher.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, requests, "an unwatched entity must not be polled")
+}
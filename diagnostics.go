@@ -0,0 +1,337 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// PingResponse represents the response from the root endpoint of the Discogs API.
+type PingResponse struct {
+	Hello         string         `json:"hello"`
+	APIVersion    string         `json:"api_version"`
+	Documentation string         `json:"documentation_url"`
+	Statistics    *APIStatistics `json:"statistics,omitempty"`
+}
+
+// APIStatistics represents the number of entities in the Discogs database.
+type APIStatistics struct {
+	Releases int64 `json:"releases"`
+	Artists  int64 `json:"artists"`
+	Labels   int64 `json:"labels"`
+}
+
+// Ping checks the connectivity to the Discogs API by sending a GET request to the root endpoint, which is cheap and
+// requires no authentication. It returns the API's welcome message, or an error if the API cannot be reached.
+func (dc *DiscogsClient) Ping(ctx context.Context, opts ...RequestOption) (*PingResponse, error) {
+	var res PingResponse
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), "/", nil, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Diagnostics represents a report of the state of a DiscogsClient, suitable for health endpoints and debugging.
+type Diagnostics struct {
+	Host    string
+	AppName string
+	// AuthType is the most privileged type of authentication the configured credentials can provide, or
+	// AuthTypeUnknown if they come from a CredentialProvider.
+	AuthType AuthType
+	// RateLimit is the current rate of the rate limiter, in requests per second.
+	RateLimit rate.Limit
+	// Burst is the current burst size of the rate limiter.
+	Burst int
+	// Tokens is the number of requests that can currently be sent without waiting.
+	Tokens float64
+	// MaxRequests is the user-defined maximum number of requests per minute, or 0 if unset.
+	MaxRequests int
+	// LastError is the last error returned by a request, or nil if no request has failed.
+	LastError error
+	// LastErrorAt is the time LastError occurred.
+	LastErrorAt time.Time
+	// CacheHits is the number of GET requests served from the Cache, and CacheMisses the number of those sent to
+	// Discogs because their response was not cached.
+	CacheHits   int
+	CacheMisses int
+	// CacheEntries is the number of responses held by the Cache, or -1 if there is no Cache or it cannot tell; only
+	// caches with a Len method, like MemoryCache, can.
+	CacheEntries int
+}
+
+// Diagnostics returns a report of the current state of the DiscogsClient, including the authentication in use, the
+// state of the rate limiter, the use of the cache, and the last error returned by a request.
+func (dc *DiscogsClient) Diagnostics() Diagnostics {
+	limiter := dc.hostLimiter()
+	cacheEntries := -1
+	if cache, ok := dc.Config.Cache.(cacheLen); ok {
+		cacheEntries = cache.Len()
+	}
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	return Diagnostics{
+		Host:        dc.Host,
+		AppName:     dc.Config.AppName,
+		AuthType:    dc.configuredAuthType(),
+		RateLimit:   limiterLimit(limiter),
+		Burst:       limiterBurst(limiter),
+		Tokens:      limiterTokens(limiter),
+		MaxRequests: dc.Config.MaxRequests,
+		LastError:   dc.lastErr,
+		LastErrorAt: dc.lastErrAt,
+
+		CacheHits:    dc.cacheHits,
+		CacheMisses:  dc.cacheMisses,
+		CacheEntries: cacheEntries,
+	}
+}
+
+// configuredAuthType returns the most privileged type of authentication the configured credentials can provide, or
+// AuthTypeUnknown if they come from a CredentialProvider, which is only consulted for requests.
+func (dc *DiscogsClient) configuredAuthType() AuthType {
+	if dc.Config.CredentialProvider != nil {
+		return AuthTypeUnknown
+	}
+	creds, _ := dc.credentials(context.Background())
+	return creds.authType()
+}
+
+// recordError stores err as the last error returned by a request.
+func (dc *DiscogsClient) recordError(err error) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	dc.lastErr = err
+	dc.lastErrAt = time.Now()
+}
+
+// SelfTestProblem represents the cause of a failed self-test.
+type SelfTestProblem string
+
+// SelfTestProblem constants representing the causes of a failed self-test.
+const (
+	// SelfTestOK indicates that the self-test succeeded.
+	SelfTestOK SelfTestProblem = ""
+	// SelfTestIncompleteKey indicates that only one of the consumer key and secret is configured.
+	SelfTestIncompleteKey SelfTestProblem = "incomplete_key"
+	// SelfTestCredentials indicates that the CredentialProvider failed to provide the credentials.
+	SelfTestCredentials SelfTestProblem = "credentials"
+	// SelfTestInvalidToken indicates that the access token was rejected.
+	SelfTestInvalidToken SelfTestProblem = "invalid_token"
+	// SelfTestInvalidKey indicates that the consumer key and secret were rejected.
+	SelfTestInvalidKey SelfTestProblem = "invalid_key"
+	// SelfTestRateLimited indicates that the rate limit is exhausted.
+	SelfTestRateLimited SelfTestProblem = "rate_limited"
+	// SelfTestServerError indicates that the API failed with a 5xx status code.
+	SelfTestServerError SelfTestProblem = "server_error"
+	// SelfTestUnexpectedResponse indicates that the API answered with an unexpected status code.
+	SelfTestUnexpectedResponse SelfTestProblem = "unexpected_response"
+	// SelfTestNetwork indicates that the API could not be reached.
+	SelfTestNetwork SelfTestProblem = "network"
+	// SelfTestCanceled indicates that the context was canceled before the self-test completed.
+	SelfTestCanceled SelfTestProblem = "canceled"
+)
+
+// SelfTestResult represents the diagnosis of a self-test.
+type SelfTestResult struct {
+	// Problem is the cause of the failure, or SelfTestOK if the self-test succeeded.
+	Problem SelfTestProblem
+	// AuthType is the type of authentication provided by the configured credentials.
+	AuthType AuthType
+	// Endpoint is the endpoint the self-test requested.
+	Endpoint string
+	// Username is the user authenticated by the access token, if any.
+	Username string
+	// RateLimit is the number of requests per minute granted by the API, or 0 if the response did not tell.
+	RateLimit int
+	// Latency is the duration of the request.
+	Latency time.Duration
+	// Err is the error the self-test failed with, or nil if it succeeded.
+	Err error
+}
+
+// OK reports whether the self-test succeeded.
+func (r *SelfTestResult) OK() bool {
+	return r.Problem == SelfTestOK
+}
+
+// SelfTest verifies the configuration of the client against the API, for setup wizards and support tooling. It
+// requests a safe read endpoint with the configured credentials: the identity of the user if an access token is
+// configured, a single search result if a consumer key and secret are, and the root endpoint otherwise. It reports
+// the granted rate limit and diagnoses failures, telling a rejected token from an incomplete key or a network error.
+func (dc *DiscogsClient) SelfTest(ctx context.Context) *SelfTestResult {
+	res := &SelfTestResult{}
+
+	creds, err := dc.credentials(ctx)
+	if err != nil {
+		res.Err = err
+		res.Problem = SelfTestCredentials
+		return res
+	}
+	res.AuthType = creds.authType()
+
+	if (creds.ConsumerKey == "") != (creds.ConsumerSecret == "") {
+		res.Problem = SelfTestIncompleteKey
+		res.Err = &ErrMissingCredentials{RequiredAuthType: AuthTypeKeySecret, Endpoint: "/database/search"}
+		return res
+	}
+
+	switch res.AuthType {
+	case AuthTypePAT:
+		res.Endpoint = "/oauth/identity"
+	case AuthTypeKeySecret:
+		res.Endpoint = "/database/search?q=discogs&per_page=1"
+	default:
+		res.Endpoint = "/"
+	}
+
+	start := time.Now()
+	response, err := dc.selfTestRequest(ctx, res.Endpoint, res.AuthType)
+	res.Latency = time.Since(start)
+	if err != nil {
+		res.Err = err
+		res.Problem = diagnoseSelfTest(ctx, res.AuthType, err)
+		return res
+	}
+	defer response.Body.Close()
+
+	if limit, err := strconv.Atoi(response.Header.Get(RateLimitHeader)); err == nil {
+		res.RateLimit = limit
+	}
+
+	if res.AuthType == AuthTypePAT {
+		var identity IdentityResponse
+		if err := json.NewDecoder(response.Body).Decode(&identity); err != nil {
+			res.Err = fmt.Errorf("failed to unmarshal response body: %w", err)
+			res.Problem = SelfTestUnexpectedResponse
+			return res
+		}
+		res.Username = identity.Username
+	}
+
+	return res
+}
+
+// selfTestRequest sends the GET request of a self-test, returning the response with its body unread.
+func (dc *DiscogsClient) selfTestRequest(ctx context.Context, endpoint string, authType AuthType) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dc.Host+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(withRoute(req.Context(), routePattern(req.URL.Path, EndpointAuthMap)))
+	req.Header.Set(UserAgentHeader, dc.Config.AppName)
+	if err := dc.addAuthHeaders(req, authType); err != nil {
+		return nil, err
+	}
+
+	return dc.stream(ctx, req)
+}
+
+// AuthValidation represents the credentials verified by ValidateAuth.
+type AuthValidation struct {
+	// AuthType is the authentication that succeeded: AuthTypeOAuth for requests signed with OAuth 1.0a, AuthTypePAT
+	// for an access token sent as is, and AuthTypeKeySecret for a consumer key and secret.
+	AuthType AuthType
+	// UserID and Username identify the user authenticated by the access token. They are not set for AuthTypeKeySecret,
+	// which does not authenticate a user.
+	UserID   int64
+	Username string
+}
+
+// ErrInvalidCredentials indicates that Discogs rejected the credentials of the client. Err is the HTTPError of the
+// rejected request.
+type ErrInvalidCredentials struct {
+	AuthType AuthType
+	Err      error
+}
+
+func (e *ErrInvalidCredentials) Error() string {
+	return fmt.Sprintf("invalid credentials of type %s: %v", e.AuthType, e.Err)
+}
+
+func (e *ErrInvalidCredentials) Unwrap() error {
+	return e.Err
+}
+
+// ValidateAuth verifies the credentials of the client with a cheap authenticated request, so applications can check
+// tokens at startup instead of failing mid-flow. An access token is verified by fetching the identity of its user, and
+// a consumer key and secret by searching for a single result. The request bypasses the Cache, so a revoked token is
+// never validated from a cached response. The context.Context provides control over the request's lifecycle.
+//
+// It returns an ErrInvalidCredentials if Discogs rejects the credentials, an ErrMissingCredentials if none are
+// configured, and the error of the request if it fails otherwise. Unlike SelfTest, it reports failures as errors.
+func (dc *DiscogsClient) ValidateAuth(ctx context.Context) (*AuthValidation, error) {
+	creds, err := dc.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AuthValidation{}
+	endpoint := "/oauth/identity"
+	switch {
+	case creds.signsOAuth():
+		res.AuthType = AuthTypeOAuth
+	case creds.AccessToken != "":
+		res.AuthType = AuthTypePAT
+	case creds.hasKeySecret():
+		res.AuthType = AuthTypeKeySecret
+		endpoint = "/database/search?q=discogs&per_page=1"
+	default:
+		return nil, &ErrMissingCredentials{RequiredAuthType: AuthTypeOAuth, Endpoint: endpoint}
+	}
+
+	response, err := dc.selfTestRequest(ctx, endpoint, res.AuthType)
+	if err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) &&
+			(httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden) {
+			return nil, &ErrInvalidCredentials{AuthType: res.AuthType, Err: err}
+		}
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if res.AuthType != AuthTypeKeySecret {
+		var identity IdentityResponse
+		if err := json.NewDecoder(response.Body).Decode(&identity); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+		}
+		res.UserID = identity.ID
+		res.Username = identity.Username
+	}
+
+	return res, nil
+}
+
+// diagnoseSelfTest returns the cause of a self-test failing with err.
+func diagnoseSelfTest(ctx context.Context, authType AuthType, err error) SelfTestProblem {
+	var httpErr *HTTPError
+	switch {
+	case ctx.Err() != nil:
+		return SelfTestCanceled
+	case !errors.As(err, &httpErr):
+		return SelfTestNetwork
+	case httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden:
+		if authType == AuthTypeKeySecret {
+			return SelfTestInvalidKey
+		}
+		return SelfTestInvalidToken
+	case httpErr.StatusCode == http.StatusTooManyRequests:
+		return SelfTestRateLimited
+	case httpErr.StatusCode >= http.StatusInternalServerError:
+		return SelfTestServerError
+	default:
+		return SelfTestUnexpectedResponse
+	}
+}
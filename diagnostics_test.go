@@ -0,0 +1,236 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_Ping(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   *discogs.PingResponse
+		err    error
+	}{
+		{
+			"Ping successful",
+			http.StatusOK,
+			`{"hello":"Welcome to the Discogs API.","api_version":"v2","statistics":{"releases":1,"artists":2,"labels":3}}`,
+			&discogs.PingResponse{
+				Hello:      "Welcome to the Discogs API.",
+				APIVersion: "v2",
+				Statistics: &discogs.APIStatistics{Releases: 1, Artists: 2, Labels: 3},
+			},
+			nil,
+		},
+		{
+			"Ping unavailable",
+			http.StatusServiceUnavailable,
+			`{"message":"Service unavailable."}`,
+			nil,
+			&discogs.HTTPError{http.StatusServiceUnavailable, `{"message":"Service unavailable."}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "/", req.URL.Path)
+				rw.WriteHeader(tt.status)
+				if _, err := rw.Write([]byte(tt.body)); err != nil {
+					assert.FailNow(t, "failed to write the response body: %w", err)
+				}
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+			client.Host = server.URL
+
+			res, err := client.Ping(ctx)
+
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestDiscogsClient_Diagnostics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AppName: "Test", ConsumerKey: &key, ConsumerSecret: &secret})
+	client.Host = server.URL
+
+	diagnostics := client.Diagnostics()
+	assert.Equal(t, server.URL, diagnostics.Host)
+	assert.Equal(t, "Test", diagnostics.AppName)
+	assert.Equal(t, discogs.AuthTypeKeySecret, diagnostics.AuthType)
+	assert.Equal(t, client.Limit(), diagnostics.RateLimit)
+	assert.Equal(t, discogs.RateLimitAuth, diagnostics.Burst)
+	assert.NoError(t, diagnostics.LastError)
+	assert.True(t, diagnostics.LastErrorAt.IsZero())
+
+	_, err := client.Ping(ctx)
+	assert.Error(t, err)
+
+	diagnostics = client.Diagnostics()
+	assert.Equal(t, err, diagnostics.LastError)
+	assert.False(t, diagnostics.LastErrorAt.IsZero())
+	assert.Equal(t, -1, diagnostics.CacheEntries)
+}
+
+func TestDiscogsClient_DiagnosticsCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{Cache: discogs.NewMemoryCache(0)})
+	client.Host = server.URL
+
+	for _, id := range []int64{1, 1, 2} {
+		_, err := client.Release(ctx, id, nil)
+		assert.NoError(t, err)
+	}
+
+	diagnostics := client.Diagnostics()
+	assert.Equal(t, 1, diagnostics.CacheHits)
+	assert.Equal(t, 2, diagnostics.CacheMisses)
+	assert.Equal(t, 2, diagnostics.CacheEntries)
+}
+
+func TestDiscogsClient_SelfTest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set(discogs.RateLimitHeader, "60")
+		switch req.Header.Get(discogs.AuthHeader) {
+		case "Bearer token":
+			assert.Equal(t, "/oauth/identity", req.URL.Path)
+			_, _ = rw.Write([]byte(`{"id":1,"username":"user"}`))
+		case "Bearer revoked", "Discogs key=key, secret=wrong":
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = rw.Write([]byte(`{"message":"You must authenticate to access this resource."}`))
+		default:
+			_, _ = rw.Write([]byte(`{"hello":"Welcome to the Discogs API."}`))
+		}
+	}))
+	defer server.Close()
+
+	token, revoked, wrong := "token", "revoked", "wrong"
+
+	tests := []struct {
+		name   string
+		config discogs.DiscogsConfig
+		host   string
+		want   discogs.SelfTestProblem
+	}{
+		{name: "token", config: discogs.DiscogsConfig{AccessToken: &token}, want: discogs.SelfTestOK},
+		{name: "no credentials", config: discogs.DiscogsConfig{}, want: discogs.SelfTestOK},
+		{name: "revoked token", config: discogs.DiscogsConfig{AccessToken: &revoked}, want: discogs.SelfTestInvalidToken},
+		{name: "wrong secret", config: discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &wrong}, want: discogs.SelfTestInvalidKey},
+		{name: "missing secret", config: discogs.DiscogsConfig{ConsumerKey: &key}, want: discogs.SelfTestIncompleteKey},
+		{name: "network", config: discogs.DiscogsConfig{}, host: "http://127.0.0.1:1", want: discogs.SelfTestNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := discogs.NewDiscogsClient(&tt.config)
+			client.Host = server.URL
+			if tt.host != "" {
+				client.Host = tt.host
+			}
+
+			got := client.SelfTest(ctx)
+			assert.Equal(t, tt.want, got.Problem)
+			assert.Equal(t, tt.want == discogs.SelfTestOK, got.OK())
+			if got.OK() {
+				assert.Equal(t, 60, got.RateLimit)
+				assert.NoError(t, got.Err)
+			} else {
+				assert.Error(t, got.Err)
+			}
+		})
+	}
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+	assert.Equal(t, "user", client.SelfTest(ctx).Username)
+}
+
+func TestDiscogsClient_ValidateAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		header := req.Header.Get(discogs.AuthHeader)
+		switch {
+		case header == "Bearer token" || strings.HasPrefix(header, "OAuth "):
+			assert.Equal(t, "/oauth/identity", req.URL.Path)
+			_, _ = rw.Write([]byte(`{"id":1,"username":"user"}`))
+		case header == "Discogs key=key, secret=secret":
+			assert.Equal(t, "/database/search", req.URL.Path)
+			_, _ = rw.Write([]byte(`{"pagination":{},"results":[]}`))
+		default:
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = rw.Write([]byte(`{"message":"You must authenticate to access this resource."}`))
+		}
+	}))
+	defer server.Close()
+
+	token, tokenSecret, revoked := "token", "token-secret", "revoked"
+
+	tests := []struct {
+		name    string
+		config  discogs.DiscogsConfig
+		want    *discogs.AuthValidation
+		wantErr error
+	}{
+		{
+			name:   "oauth",
+			config: discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret, AccessToken: &token, AccessTokenSecret: &tokenSecret},
+			want:   &discogs.AuthValidation{AuthType: discogs.AuthTypeOAuth, UserID: 1, Username: "user"},
+		},
+		{
+			name:   "token",
+			config: discogs.DiscogsConfig{AccessToken: &token},
+			want:   &discogs.AuthValidation{AuthType: discogs.AuthTypePAT, UserID: 1, Username: "user"},
+		},
+		{
+			name:   "key and secret",
+			config: discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret},
+			want:   &discogs.AuthValidation{AuthType: discogs.AuthTypeKeySecret},
+		},
+		{
+			name:    "revoked token",
+			config:  discogs.DiscogsConfig{AccessToken: &revoked},
+			wantErr: &discogs.ErrInvalidCredentials{},
+		},
+		{
+			name:    "no credentials",
+			config:  discogs.DiscogsConfig{},
+			wantErr: &discogs.ErrMissingCredentials{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := discogs.NewDiscogsClient(&tt.config)
+			client.Host = server.URL
+
+			got, err := client.ValidateAuth(ctx)
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -8,10 +8,13 @@
 // [Discogs developers page]: https://www.discogs.com/developers
 package discogs
 
+//go:generate go run gen-accessors.go
+
 import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -30,8 +33,12 @@ const (
 	AuthHeader      = "Authorization"
 	UserAgentHeader = "User-Agent"
 	RateLimitHeader = "X-Discogs-Ratelimit"
-	RateLimitUnauth = 25
-	RateLimitAuth   = 60
+	// RateLimitRemainingHeader is the header containing the number of requests remaining in the current window.
+	RateLimitRemainingHeader = "X-Discogs-Ratelimit-Remaining"
+	// RateLimitUsedHeader is the header containing the number of requests made in the current window.
+	RateLimitUsedHeader = "X-Discogs-Ratelimit-Used"
+	RateLimitUnauth     = 25
+	RateLimitAuth       = 60
 )
 
 // An HTTPError provides information on an error resulting from an HTTP request, including the StatusCode
@@ -48,20 +55,92 @@ func (e *HTTPError) Error() string {
 	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
 }
 
+// Resource represents a kind of resource exposed by the Discogs API. It is used to describe which resource an
+// error refers to.
+type Resource string
+
+// Resource constants representing the resources that can be looked up by ID.
+const (
+	ResourceRelease       Resource = "release"
+	ResourceMaster        Resource = "master"
+	ResourceArtist        Resource = "artist"
+	ResourceLabel         Resource = "label"
+	ResourceListing       Resource = "listing"
+	ResourceOrder         Resource = "order"
+	ResourceUser          Resource = "user"
+	ResourceFolder        Resource = "folder"
+	ResourceWantlistEntry Resource = "wantlist entry"
+	ResourceInstance      Resource = "collection instance"
+	ResourceExport        Resource = "inventory export"
+)
+
+// ErrNotFound indicates that a resource with the specified ID was not found. ID holds the identifier used for the
+// lookup, which is a numeric ID for most resources and a username for users.
+type ErrNotFound struct {
+	Resource Resource
+	ID       string
+	*HTTPError
+}
+
+// Error returns a formatted error message indicating that the resource was not found.
+//
+// Example: "release ID 2 not found: {"message": "Release not found."}".
+func (e *ErrNotFound) Error() string {
+	return fmt.Sprintf("%s ID %s not found: %s", e.Resource, e.ID, e.Message)
+}
+
+// Unwrap returns the underlying HTTPError.
+func (e *ErrNotFound) Unwrap() error {
+	return e.HTTPError
+}
+
+// IsNotFound reports whether any error in err's chain is an ErrNotFound.
+func IsNotFound(err error) bool {
+	var notFound *ErrNotFound
+	return errors.As(err, &notFound)
+}
+
+// wrapNotFound converts an HTTPError with a 404 status code into an ErrNotFound for the given resource and ID.
+// Any other error is returned unchanged.
+func wrapNotFound(err error, resource Resource, id string) error {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		return &ErrNotFound{
+			Resource:  resource,
+			ID:        id,
+			HTTPError: httpErr,
+		}
+	}
+	return err
+}
+
 // DiscogsClient is a wrapper for http.Client that includes the Host of the API and a Config for the client.
-// It also includes a rateLimiter to rate limit requests.
+// It also includes a registry of hosts to rate limit requests, keeping a separate rate limiter for each host requests
+// are sent to.
 type DiscogsClient struct {
 	*http.Client
 	Host   string
 	Config DiscogsConfig
 
-	rateLimiter *rate.Limiter
-	mu          sync.Mutex
+	hosts *hostRegistry
+	mu    sync.Mutex
+
+	lastErr   error
+	lastErrAt time.Time
+
+	cacheHits   int
+	cacheMisses int
+
+	rateLimitStatus RateLimitStatus
+	inFlight        chan struct{}
+	warmUp          map[string]int
+
+	stats       clientStats
+	retryBudget retryBudget
+	identity    identityCache
 }
 
 // DiscogsConfig contains configuration options for the Discogs client.
-//
-// TODO: add support for AccessToken and OAuth tokens.
 type DiscogsConfig struct {
 	// Provided as User-Agent string to identify the application to Discogs.
 	// Preferably follows [RFC 1945].
@@ -74,34 +153,219 @@ type DiscogsConfig struct {
 	ConsumerSecret *string
 	AccessToken    *string
 	MaxRequests    int
+
+	// AccessTokenSecret is the secret of an OAuth access token. If set along with AccessToken, ConsumerKey and
+	// ConsumerSecret, authenticated requests are signed with OAuth 1.0a; otherwise AccessToken is sent as a bearer
+	// token.
+	AccessTokenSecret *string
+
+	// KeySecretInQuery sends the consumer key and secret as the key and secret query parameters instead of the
+	// Authorization header, which some proxies and the image servers require. The secret is then part of the URL, so it
+	// may end up in the logs of proxies; it is redacted from the errors of this package.
+	KeySecretInQuery bool
+
+	// DefaultAuthType is the authentication type of endpoints that match no route of EndpointAuthMap when called with
+	// Get, Post, Put or Delete. Such calls fail with an ErrMatchNotFound if unset.
+	DefaultAuthType AuthType
+
+	// CredentialProvider provides the credentials of every request, replacing ConsumerKey, ConsumerSecret,
+	// AccessToken and AccessTokenSecret, so credentials can change without reconstructing the client.
+	CredentialProvider CredentialProvider
+
+	// OAuthSignatureMethod is the method used to sign OAuth 1.0a requests. OAuthSignaturePlaintext is used if unset.
+	OAuthSignatureMethod OAuthSignatureMethod
+
+	// HTTPClient is the http.Client that sends the requests, such as one configured with a proxy, a timeout or a
+	// corporate CA bundle. A new http.Client is used if unset.
+	HTTPClient *http.Client
+
+	// Transport sends the requests instead of the Transport of HTTPClient, such as one with mTLS, a proxy, or a recording
+	// transport for tests. HTTPClient is copied rather than modified. http.DefaultTransport is used if neither is set.
+	Transport http.RoundTripper
+
+	// RetainRawResponse stores the raw JSON body of each response in the Raw field of response types that embed
+	// RawResponse, so fields not yet modeled by this package can still be recovered.
+	RetainRawResponse bool
+
+	// CaptureUnknownFields stores JSON fields that are not mapped to a struct field in the Extra field of response
+	// types that embed ExtraFields, protecting against data loss when Discogs adds new fields.
+	CaptureUnknownFields bool
+
+	// HedgeDelay enables hedged GET requests when greater than 0. If a GET request has not completed after
+	// HedgeDelay, a second identical request is sent and whichever response arrives first is used.
+	HedgeDelay time.Duration
+
+	// DisableCustomHostRateLimit disables rate limiting for requests sent to a Host other than BaseURL, such as a
+	// mock server or a proxy that enforces its own limits. Otherwise, each host has its own rate limiter, so traffic
+	// to one host never throttles traffic to another.
+	DisableCustomHostRateLimit bool
+
+	// AdaptiveRateLimit adjusts the rate limit based on feedback from the API instead of pinning it to the
+	// X-Discogs-Ratelimit header. The rate is halved whenever a 429 response or a nearly exhausted
+	// X-Discogs-Ratelimit-Remaining header is observed, and is increased by one request per minute after every other
+	// response, up to the limit allowed by the API and MaxRequests.
+	AdaptiveRateLimit bool
+
+	// PaceRateLimit paces requests using the X-Discogs-Ratelimit-Remaining header: the full rate is used while plenty
+	// of requests remain in the current window, and the rate slows down as they run out, recovering as requests leave
+	// the window. It maximizes the throughput of bulk jobs without tripping 429 responses, and takes precedence over
+	// AdaptiveRateLimit.
+	PaceRateLimit bool
+
+	// WarmUpFraction starts the rate limit of each host at this fraction of the allowed rate, e.g. 0.2, and ramps it up
+	// as successful responses accumulate, reaching the full rate after WarmUpResponses of them. It helps newly created
+	// tokens, which Discogs seems to throttle more aggressively at first. Requests are not warmed up if 0.
+	WarmUpFraction float64
+
+	// WarmUpResponses is the number of successful responses from a host after which its warm-up ends.
+	// DefaultWarmUpResponses is used if unset.
+	WarmUpResponses int
+
+	// OnRateLimitWait is called when a request is delayed by the rate limiter, with the expected wait, so applications
+	// can tell throttling from a hang. It is called from the goroutine sending the request, before it waits; for
+	// Limiters that do not report their state, it is called after the wait with the time waited.
+	OnRateLimitWait func(d time.Duration)
+
+	// OnRateLimitUpdate is called when the rate limit of a host changes, such as after the API reported a different
+	// limit, with the old and new limits in requests per minute. The old limit is 0 for Limiters that do not report it.
+	OnRateLimitUpdate func(old, new int)
+
+	// NewLimiter creates the Limiter of a host, such as "https://api.discogs.com", allowing requestsPerMinute
+	// requests per minute, which follows MaxRequests and the authentication. A *rate.Limiter is used if unset.
+	//
+	// Example, disabling rate limiting:
+	//
+	//	func(host string, requestsPerMinute int) discogs.Limiter {
+	//		return rate.NewLimiter(rate.Inf, 0)
+	//	}
+	NewLimiter func(host string, requestsPerMinute int) Limiter
+
+	// MaxRetries is the maximum number of times a GET or HEAD request that failed with a 500, 502 or 503 status code is
+	// retried, see Backoff for the time waited in between. Writes are only retried if made with WithWriteVerifier.
+	// Retries are disabled if 0.
+	MaxRetries int
+
+	// MaxInFlight is the maximum number of requests in flight at once, regardless of the rate limit, since large bursts
+	// of concurrent requests can trigger the anti-abuse protection of Discogs. Further requests wait for one to
+	// complete, and streamed downloads count until their body is closed. The number is not limited if 0.
+	MaxInFlight int
+
+	// MaxTransportRetries is the maximum number of times a GET or HEAD request that failed with a transient network
+	// error, such as a connection reset or a DNS timeout, is retried, as are writes made with WithWriteVerifier. Such
+	// retries do not count towards MaxRetries.
+	// Once they are exhausted, the error wraps the network error in an ErrRetriesExhausted. Retries are disabled if 0.
+	MaxTransportRetries int
+
+	// MaxRateLimitRetries is the maximum number of times a request rejected with a 429 status code is retried, after
+	// waiting for the time given by the response, see ErrRateLimited. Requests of any method are retried, since
+	// Discogs does not process rejected requests. Such retries do not count towards MaxRetries or RetryBudget.
+	// Requests are not retried if 0, and fail with an ErrRateLimited.
+	MaxRateLimitRetries int
+
+	// RetryWait is the time waited before each retry if no Backoff is set. DefaultRetryWait is used if unset.
+	RetryWait time.Duration
+
+	// Backoff determines the time waited before each retry. If unset, RetryWait is waited before every retry.
+	//
+	// Example, doubling the wait up to 30 seconds with full jitter:
+	//
+	//	discogs.ExponentialBackoff{Initial: time.Second, Max: 30 * time.Second, Jitter: 1}
+	Backoff Backoff
+
+	// RetryBudget is the maximum fraction of requests that may be retries, e.g. 0.1 allows one retry per ten
+	// requests on average on top of a small reserve. It keeps bulk jobs from amplifying load during Discogs incidents.
+	// The number of retries is not limited by a budget if 0.
+	RetryBudget float64
+
+	// MaxRetryElapsedTime is the maximum time spent on a single call, including retries. No retry is started if it
+	// would exceed this time. The time is not limited if 0.
+	MaxRetryElapsedTime time.Duration
+
+	// ShouldRetry decides whether a failed GET or HEAD request is retried, replacing the default of retrying 500, 502
+	// and 503 status codes.
+	// It receives the response, which is nil if no response was received and whose body has already been read, and
+	// the error of the failed attempt. MaxRetries, RetryBudget and MaxRetryElapsedTime still apply.
+	//
+	// Example, retrying Discogs' intermittent "Query time exceeded" errors on search:
+	//
+	//	func(resp *http.Response, err error) bool {
+	//		var httpErr *discogs.HTTPError
+	//		return errors.As(err, &httpErr) && strings.Contains(httpErr.Message, "Query time exceeded")
+	//	}
+	ShouldRetry func(resp *http.Response, err error) bool
+
+	// RetryPolicy decides which failed requests are retried and how long to wait before each retry, replacing
+	// MaxRetries, ShouldRetry, Backoff and RetryWait. RetryBudget, MaxRetryElapsedTime and MaxRateLimitRetries still
+	// apply. A DefaultRetryPolicy built from the other retry settings is used if unset.
+	RetryPolicy RetryPolicy
+
+	// Cache stores the responses of successful GET requests, which are then served from the cache until they expire
+	// or are invalidated. Successful writes invalidate the cached responses they affect, see InvalidateCache. Cached
+	// responses depend on the credentials of the client, so a cache must not be shared by clients of different users.
+	// Responses are not cached if unset.
+	Cache ResponseCache
+
+	// MediaType is the media type requested in the Accept header, which determines the format of text fields such as
+	// release notes and profiles. It can be overridden per call with WithMediaType. The API default, MediaTypeDiscogs,
+	// is used if unset.
+	MediaType MediaType
+
+	// AuditLog records every write operation, such as editing listings or updating orders, with its payload and
+	// outcome, for an accountable trail of automated actions. Every attempt is recorded, so a retried write has one
+	// entry per attempt, and so are the requests sent with Do. Write operations are not recorded if unset.
+	AuditLog AuditSink
+}
+
+// RawResponse is embedded in response types to hold the raw JSON body of the response. Raw is only populated when
+// RetainRawResponse is enabled in the DiscogsConfig.
+type RawResponse struct {
+	Raw json.RawMessage `json:"-"`
+}
+
+// setRaw sets the raw JSON body of the response.
+func (r *RawResponse) setRaw(raw json.RawMessage) {
+	r.Raw = raw
+}
+
+// headerRetainer is implemented by response types that read some of their fields from the headers of the response,
+// such as the location of a created resource. Cached responses have no headers.
+type headerRetainer interface {
+	setHeader(header http.Header)
+}
+
+// rawRetainer is implemented by response types that can retain the raw JSON body of a response.
+type rawRetainer interface {
+	setRaw(raw json.RawMessage)
 }
 
 // NewDiscogsClient creates a new DiscogsClient with the provided configuration.
-// If AppName is not provided in the config, it defaults to DefaultAppName.
-// TODO: handle AccessToken
+// If AppName is not provided in the config, it defaults to DefaultAppName. Requests are sent with the HTTPClient and
+// Transport of the config, if set.
 func NewDiscogsClient(config *DiscogsConfig) *DiscogsClient {
 	if config.AppName == "" {
 		config.AppName = DefaultAppName
 	}
 
-	var limiter *rate.Limiter
-	if config.MaxRequests > 0 {
-		limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(config.MaxRequests)), config.MaxRequests)
-	} else {
-		var requestsPerMinute int
-		if config.ConsumerKey != nil && config.ConsumerSecret != nil {
-			requestsPerMinute = RateLimitAuth
-		} else {
-			requestsPerMinute = RateLimitUnauth
-		}
-		limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(requestsPerMinute)), requestsPerMinute)
+	client := &http.Client{}
+	if config.HTTPClient != nil {
+		client = config.HTTPClient
+	}
+	if config.Transport != nil {
+		// Copy the client so the transport of a client shared with other code is left unchanged
+		copied := *client
+		copied.Transport = config.Transport
+		client = &copied
 	}
 
 	return &DiscogsClient{
-		Client:      &http.Client{},
-		Host:        BaseURL,
-		Config:      *config,
-		rateLimiter: limiter,
+		Client: client,
+		Host:   BaseURL,
+		Config: *config,
+		hosts: &hostRegistry{
+			rateLimiters: map[string]Limiter{
+				hostKey(BaseURL): newRateLimiter(config, hostKey(BaseURL)),
+			},
+		},
 	}
 }
 
@@ -130,22 +394,73 @@ func (dc *DiscogsClient) Delete(ctx context.Context, endpoint string, params url
 }
 
 // request sends an HTTP request to the specified endpoint with the given parameters, headers, and body,
-// and unmarshals the response into the provided res interface. It respects the rate limit settings of the Discogs API
-// and any user-defined rate limits. It handles the request creation, including setting authentication headers.
+// and unmarshals the response into the provided res interface. The authentication type is determined by matching the
+// endpoint against EndpointAuthMap.
 func (dc *DiscogsClient) request(ctx context.Context, method, endpoint string, params url.Values, headers map[string]string, body, res interface{}) error {
+	return dc.requestWithAuth(ctx, method, endpoint, "", params, headers, body, res)
+}
+
+// An EndpointRequest represents a request to a Discogs endpoint, sent with SendRequest.
+type EndpointRequest struct {
+	// Method is the HTTP method of the request. GET is used if unset.
+	Method string
+	// Endpoint is the path of the endpoint, such as "/releases/1".
+	Endpoint string
+	// AuthType is the authentication the endpoint requires. If unset, it is determined by matching the endpoint
+	// against EndpointAuthMap, like Get, Post, Put and Delete do; otherwise the endpoint does not have to be listed in
+	// EndpointAuthMap, so endpoints this package does not support yet can be called.
+	AuthType AuthType
+	// Params are the query parameters of the request.
+	Params url.Values
+	// Headers are added to the headers of the request.
+	Headers map[string]string
+	// Body is encoded as the JSON body of the request, if not nil.
+	Body any
+}
+
+// SendRequest sends a request to a Discogs endpoint and unmarshals the response into the provided res interface. It
+// respects the rate limit settings of the Discogs API and any user-defined rate limits, and applies the retry and
+// cache settings of the DiscogsConfig and the RequestOptions of ctx, see WithRequestOptions.
+func (dc *DiscogsClient) SendRequest(ctx context.Context, req *EndpointRequest, res interface{}) error {
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return dc.requestWithAuth(ctx, method, req.Endpoint, req.AuthType, req.Params, req.Headers, req.Body, res)
+}
+
+// requestWithAuth sends an HTTP request to the specified endpoint with the given parameters, headers, and body,
+// authenticated with authType, and unmarshals the response into the provided res interface. If authType is empty, it
+// is determined by matching the endpoint against EndpointAuthMap.
+func (dc *DiscogsClient) requestWithAuth(ctx context.Context, method, endpoint string, authType AuthType, params url.Values, headers map[string]string, body, res interface{}) (err error) {
+	defer func() {
+		if err != nil {
+			dc.recordError(err)
+		}
+	}()
+
+	// The options only apply to this request, not to the requests made with ctx while sending it, such as the lookups
+	// of a WriteVerifier
+	options := requestOptionsFrom(ctx)
+	ctx = context.WithValue(ctx, requestOptionsKey{}, requestOptions{})
+	ctx, cancel := options.withTimeout(ctx)
+	defer cancel()
+
 	baseURL, err := url.Parse(dc.Host + endpoint)
 	if err != nil {
 		return err
 	}
 
-	if params == nil {
-		params = url.Values{}
+	params, err = options.query(params)
+	if err != nil {
+		return err
 	}
 	baseURL.RawQuery = params.Encode()
 
 	var reqBody io.Reader
+	var encoded []byte
 	if body != nil {
-		encoded, err := json.Marshal(body)
+		encoded, err = json.Marshal(body)
 		if err != nil {
 			return err
 		}
@@ -162,93 +477,218 @@ func (dc *DiscogsClient) request(ctx context.Context, method, endpoint string, p
 	for headerKey, headerValue := range headers {
 		req.Header.Set(headerKey, headerValue)
 	}
+	for headerKey, headerValue := range options.headers {
+		req.Header.Set(headerKey, headerValue)
+	}
 
-	// Determine authentication type based on the endpoint
-	authType, err := matchRoute(endpoint, EndpointAuthMap)
-	if err != nil {
-		return err
+	// Determine authentication type based on the endpoint, falling back to the default of unknown routes if set
+	if authType == "" {
+		authType, err = matchRoute(endpoint, EndpointAuthMap)
+		if err != nil && dc.Config.DefaultAuthType == "" {
+			return err
+		} else if err != nil {
+			authType = dc.Config.DefaultAuthType
+		}
 	}
 
+	// Attach the matched route pattern to the request so statistics are grouped by route
+	route := routePattern(endpoint, EndpointAuthMap)
+	req = req.WithContext(withRoute(req.Context(), route))
+
 	// Set the User-Agent header to AppName, as requested by Discogs API
 	req.Header.Set(UserAgentHeader, dc.Config.AppName)
 
+	// Request the configured media type, unless the caller set the Accept header
+	mediaType := dc.mediaType(ctx)
+	if mediaType != "" && req.Header.Get(AcceptHeader) == "" {
+		req.Header.Set(AcceptHeader, string(mediaType))
+	}
+
 	// Add authentication headers to the request
 	if err := dc.addAuthHeaders(req, authType); err != nil {
 		return err
 	}
 
-	return dc.Do(ctx, req, res)
+	// Serve GET requests from the cache if possible, keeping responses in different media types apart
+	var cacheKey string
+	if dc.Config.Cache != nil && method == http.MethodGet {
+		cacheKey = endpoint + "?" + baseURL.RawQuery
+		if accept := req.Header.Get(AcceptHeader); accept != "" {
+			cacheKey += "#" + accept
+		}
+		cached, ok := dc.Config.Cache.Get(cacheKey)
+		dc.recordCacheLookup(ok)
+		if ok {
+			return dc.decode(cached, res)
+		}
+	}
+
+	response, responseBody, err := dc.sendWithRetry(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if cacheKey != "" {
+		dc.Config.Cache.Set(cacheKey, responseBody)
+	} else if method != http.MethodGet {
+		dc.invalidateAfterWrite(endpoint)
+	}
+
+	if retainer, ok := res.(headerRetainer); ok {
+		retainer.setHeader(response.Header)
+	}
+	return dc.decode(responseBody, res)
 }
 
 // Do sends an HTTP request and unmarshals the response into the provided res interface.
 // It respects the rate limits by waiting until the rate limiter allows the request.
 // It also updates the rate limiter based on the X-Discogs-Ratelimit header from the API response.
+// Failed requests are retried as those of the endpoint methods, provided their body can be read again through
+// GetBody, as with the requests of http.NewRequest.
 // It returns an HTTPError if the response status code is not 2xx.
 func (dc *DiscogsClient) Do(ctx context.Context, req *http.Request, res interface{}) error {
-	err := dc.rateLimiter.Wait(ctx)
+	_, responseBody, err := dc.sendWithRetry(ctx, req)
 	if err != nil {
 		return err
 	}
 
+	return dc.decode(responseBody, res)
+}
+
+// send sends an HTTP request, respecting the rate limits, and returns the response along with its body. The body of
+// the returned response has already been read and closed. The response is nil if no response was received.
+// It returns an HTTPError if the response status code is not 2xx.
+func (dc *DiscogsClient) send(ctx context.Context, req *http.Request) (_ *http.Response, _ []byte, err error) {
+	err = dc.waitRateLimit(ctx, req.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+	release, err := dc.acquireInFlight(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+	signAttempt(req)
+
+	start := time.Now()
+	defer func() {
+		dc.stats.recordAttempt(req, time.Since(start), err)
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			dc.audit(req, start, err)
+		}
+	}()
+
 	response, err := dc.Client.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request failed: %w", redactSecret(err))
 	}
 	defer response.Body.Close()
 
-	dc.updateRateLimitFromHeader(response)
+	dc.recordRateLimitStatus(response)
+	dc.recordWarmUp(response)
+	dc.adjustRateLimit(response)
 
 	// Read the response body
 	responseBody, err := io.ReadAll(response.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return response, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Check for non-2xx status codes and return an HTTPError if necessary
+	// Check for non-2xx status codes and return an HTTPError, or an ErrRateLimited, if necessary
 	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		return &HTTPError{
-			StatusCode: response.StatusCode,
-			Message:    string(responseBody),
-		}
+		return response, nil, statusError(response, responseBody)
+	}
+
+	return response, responseBody, nil
+}
+
+// redactSecret removes the consumer secret from the URL of a transport error, in case it was sent as a query
+// parameter, so it does not end up in logs.
+func redactSecret(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	u, parseErr := url.Parse(urlErr.URL)
+	if parseErr != nil || !u.Query().Has("secret") {
+		return err
 	}
+	params := u.Query()
+	params.Set("secret", "REDACTED")
+	u.RawQuery = params.Encode()
+	return &url.Error{Op: urlErr.Op, URL: u.String(), Err: urlErr.Err}
+}
 
-	// Unmarshal the response body into the provided res interface, if not nil
+// decode unmarshals a response body into the provided res interface, if not nil.
+func (dc *DiscogsClient) decode(responseBody []byte, res interface{}) error {
 	if res != nil && len(responseBody) > 0 {
 		if err := json.Unmarshal(responseBody, res); err != nil {
 			return fmt.Errorf("failed to unmarshal response body: %w", err)
 		}
+
+		if retainer, ok := res.(rawRetainer); ok && dc.Config.RetainRawResponse {
+			retainer.setRaw(json.RawMessage(responseBody))
+		}
+
+		if capturer, ok := res.(extraCapturer); ok && dc.Config.CaptureUnknownFields {
+			capturer.setExtra(unknownFields(responseBody, res))
+		}
 	}
 
 	return nil
 }
 
 // SetMaxRequests allows the user to set a custom rate limit for the DiscogsClient.
-// It adjusts the rate limiter to the specified number of requests per minute.
+// It adjusts the rate limiters of all hosts to the specified number of requests per minute.
 func (dc *DiscogsClient) SetMaxRequests(requestsPerMinute int) {
+	var updates []rateLimitUpdate
+	defer func() { dc.reportRateLimitUpdates(updates...) }()
+
+	hosts := dc.hostRegistry()
+
 	dc.mu.Lock()
 	defer dc.mu.Unlock()
 
 	if requestsPerMinute > 0 {
 		dc.Config.MaxRequests = requestsPerMinute
 		limit := rate.Every(time.Minute / time.Duration(requestsPerMinute))
-		dc.rateLimiter.SetLimit(limit)
-		dc.rateLimiter.SetBurst(requestsPerMinute)
+
+		hosts.mu.Lock()
+		defer hosts.mu.Unlock()
+		for _, limiter := range hosts.rateLimiters {
+			updates = append(updates, setLimit(limiter, limit, requestsPerMinute))
+		}
 	}
 }
 
-// Limit returns the current rate limit of the DiscogsClient.
+// Limit returns the current rate limit of the DiscogsClient for its Host, or 0 if its Limiter does not report it.
 func (dc *DiscogsClient) Limit() rate.Limit {
-	return dc.rateLimiter.Limit()
+	return limiterLimit(dc.hostLimiter())
 }
 
-// Tokens returns the number of tokens (available requests) currently in the rate limiter's bucket.
+// Tokens returns the number of tokens (available requests) currently in the rate limiter's bucket for the Host of
+// the DiscogsClient, or 0 if its Limiter does not report it.
 func (dc *DiscogsClient) Tokens() float64 {
-	return dc.rateLimiter.Tokens()
+	return limiterTokens(dc.hostLimiter())
+}
+
+// adjustRateLimit adjusts the rate limiter of the host that sent res to its rate limit headers, pacing or adapting it
+// as configured.
+func (dc *DiscogsClient) adjustRateLimit(res *http.Response) {
+	switch {
+	case dc.Config.PaceRateLimit:
+		dc.paceRateLimit(res)
+	case dc.Config.AdaptiveRateLimit:
+		dc.adaptRateLimit(res)
+	default:
+		dc.updateRateLimitFromHeader(res)
+	}
 }
 
 // updateRateLimitFromHeader adjusts the rate limiter based on the X-Discogs-Ratelimit header from the API response.
 // It sets the rate limit to the minimum of the user-defined limit and the Discogs API limit. The rate limiter will never
-// be set to 0 (infinite requests).
+// be set to 0 (infinite requests), nor above the warm-up rate of the host if WarmUpFraction is set. Only the rate limiter
+// of the host that sent the response is adjusted.
 //
 // Note: The MaxRequests (per minute) in the config does not update based on the headers. Use SetMaxRequests to change the
 // MaxRequests value along with the rate limiter.
@@ -258,6 +698,14 @@ func (dc *DiscogsClient) updateRateLimitFromHeader(res *http.Response) {
 	if rateLimitHeader != "" {
 		rateLimit, err := strconv.Atoi(rateLimitHeader)
 		if err == nil {
+			limiter := dc.hostLimiter()
+			if res.Request != nil {
+				limiter = dc.limiterFor(res.Request.URL)
+			}
+
+			var update rateLimitUpdate
+			defer func() { dc.reportRateLimitUpdates(update) }()
+
 			dc.mu.Lock()
 			defer dc.mu.Unlock()
 
@@ -268,10 +716,12 @@ func (dc *DiscogsClient) updateRateLimitFromHeader(res *http.Response) {
 			} else {
 				effectiveLimit = rateLimit
 			}
+			if effectiveLimit > 0 {
+				effectiveLimit = int(dc.warmUpCeiling(res, float64(effectiveLimit)))
+			}
 
-			if effectiveLimit != 0 {
-				dc.rateLimiter.SetLimit(rate.Every(time.Minute / time.Duration(effectiveLimit)))
-				dc.rateLimiter.SetBurst(effectiveLimit)
+			if effectiveLimit != 0 && limiterLimit(limiter) != rate.Inf {
+				update = setLimit(limiter, rate.Every(time.Minute/time.Duration(effectiveLimit)), effectiveLimit)
 			}
 		}
 	}
@@ -304,18 +754,35 @@ func (e *ErrMissingCredentials) Error() string {
 //
 // [Discogs Auth Flow]: https://www.discogs.com/developers#page:authentication,header:authentication-discogs-auth-flow
 func (dc *DiscogsClient) addAuthHeaders(req *http.Request, authType AuthType) error {
+	if authType == AuthTypeNone {
+		return nil
+	}
+
+	creds, err := dc.credentials(req.Context())
+	if err != nil {
+		return err
+	}
+
 	switch authType {
-	case AuthTypeNone:
-		// No authentication required
 	case AuthTypeKeySecret:
-		if dc.Config.ConsumerKey != nil && dc.Config.ConsumerSecret != nil {
-			req.Header.Set(AuthHeader, fmt.Sprintf("Discogs key=%s, secret=%s", *dc.Config.ConsumerKey, *dc.Config.ConsumerSecret))
+		if creds.hasKeySecret() && dc.Config.KeySecretInQuery {
+			params := req.URL.Query()
+			params.Set("key", creds.ConsumerKey)
+			params.Set("secret", creds.ConsumerSecret)
+			req.URL.RawQuery = params.Encode()
+		} else if creds.hasKeySecret() {
+			req.Header.Set(AuthHeader, fmt.Sprintf("Discogs key=%s, secret=%s", creds.ConsumerKey, creds.ConsumerSecret))
 		} else {
 			return &ErrMissingCredentials{RequiredAuthType: authType, Endpoint: req.URL.Path}
 		}
 	case AuthTypeOAuth, AuthTypePAT:
-		if dc.Config.AccessToken != nil {
-			req.Header.Set(AuthHeader, fmt.Sprintf("Bearer %s", *dc.Config.AccessToken))
+		if creds.signsOAuth() {
+			// Every attempt is signed again before being sent, see signAttempt
+			signer := dc.oauthSigner(creds)
+			signer.sign(req, nil)
+			*req = *req.WithContext(context.WithValue(req.Context(), oauthSignerKey{}, signer))
+		} else if creds.AccessToken != "" {
+			req.Header.Set(AuthHeader, fmt.Sprintf("Bearer %s", creds.AccessToken))
 		} else {
 			return &ErrMissingCredentials{RequiredAuthType: authType, Endpoint: req.URL.Path}
 		}
@@ -323,6 +790,17 @@ func (dc *DiscogsClient) addAuthHeaders(req *http.Request, authType AuthType) er
 	return nil
 }
 
+// optionalAuth returns the authentication type of endpoints that do not require authentication for public data, such
+// as public collections, but return more to their owner: the access token is sent if set, and nothing otherwise.
+func (dc *DiscogsClient) optionalAuth(ctx context.Context) AuthType {
+	creds, err := dc.credentials(ctx)
+	// A failing CredentialProvider is reported when the request is authenticated
+	if err != nil || creds.AccessToken != "" {
+		return AuthTypeOAuth
+	}
+	return AuthTypeNone
+}
+
 // ErrMatchNotFound represents an error when no matching route is found for authentication.
 type ErrMatchNotFound struct {
 	Endpoint string
@@ -334,19 +812,100 @@ func (e *ErrMatchNotFound) Error() string {
 
 // endpointAuthMap maps API endpoints to their required authentication types.
 var EndpointAuthMap = map[string]AuthType{
-	"/test":                  AuthTypeNone,
-	"/releases/{release_id}": AuthTypeNone,
-	"/database/search":       AuthTypeKeySecret,
+	"/":                             AuthTypeNone,
+	"/oauth/identity":               AuthTypeOAuth,
+	"/oauth/request_token":          AuthTypeKeySecret,
+	"/oauth/access_token":           AuthTypeKeySecret,
+	"/test":                         AuthTypeNone,
+	"/releases/{release_id}":        AuthTypeNone,
+	"/releases/{release_id}/rating": AuthTypeNone,
+	"/releases/{release_id}/stats":  AuthTypeNone,
+	"/masters/{master_id}":          AuthTypeNone,
+	"/masters/{master_id}/versions": AuthTypeNone,
+	"/artists/{artist_id}":          AuthTypeNone,
+	"/artists/{artist_id}/releases": AuthTypeNone,
+	"/labels/{label_id}":            AuthTypeNone,
+	"/labels/{label_id}/releases":   AuthTypeNone,
+	"/database/search":              AuthTypeKeySecret,
+	"/inventory/export":             AuthTypeOAuth,
+	"/users/{username}":             AuthTypeNone,
+	"/users/{username}/collection/folders/{folder_id}/releases":              AuthTypeOAuth,
+	"/users/{username}/collection/folders/0/releases":                        AuthTypeNone,
+	"/users/{username}/collection/folders/{folder_id}":                       AuthTypeOAuth,
+	"/releases/{release_id}/rating/{username}":                               AuthTypeOAuth,
+	"/users/{username}/submissions":                                          AuthTypeNone,
+	"/users/{username}/contributions":                                        AuthTypeNone,
+	"/inventory/export/{export_id}":                                          AuthTypeOAuth,
+	"/inventory/export/{export_id}/download":                                 AuthTypeOAuth,
+	"/users/{username}/inventory":                                            AuthTypeNone,
+	"/users/{username}/wants":                                                AuthTypeOAuth,
+	"/users/{username}/wants/{release_id}":                                   AuthTypeOAuth,
+	"/users/{username}/collection/fields":                                    AuthTypeNone,
+	"/users/{username}/collection/folders/{folder_id}/releases/{release_id}": AuthTypeOAuth,
+	"/marketplace/orders":                                                    AuthTypeOAuth,
+	"/marketplace/orders/{order_id}":                                         AuthTypeOAuth,
+	"/marketplace/orders/{order_id}/messages":                                AuthTypeOAuth,
+	"/marketplace/listings/{listing_id}":                                     AuthTypeNone,
+	"/marketplace/stats/{release_id}":                                        AuthTypeNone,
+	"/marketplace/price_suggestions/{release_id}":                            AuthTypeOAuth,
+	"/lists/{list_id}":                                                       AuthTypeNone,
+	"/inventory/upload":                                                      AuthTypeOAuth,
+	"/inventory/upload/add":                                                  AuthTypeOAuth,
+	"/inventory/upload/change":                                               AuthTypeOAuth,
+	"/inventory/upload/delete":                                               AuthTypeOAuth,
+	"/inventory/upload/{upload_id}":                                          AuthTypeOAuth,
+	"/marketplace/listings":                                                  AuthTypeOAuth,
+	"/marketplace/fee/{price}":                                               AuthTypeNone,
+	"/marketplace/fee/{price}/{currency}":                                    AuthTypeNone,
+	"/users/{username}/lists":                                                AuthTypeNone,
+	"/users/{username}/collection/value":                                     AuthTypeOAuth,
+	"/users/{username}/collection/folders":                                   AuthTypeOAuth,
+	"/users/{username}/collection/releases/{release_id}":                     AuthTypeOAuth,
+	"/users/{username}/collection/folders/{folder_id}/releases/{release_id}/instances/{instance_id}/fields/{field_id}": AuthTypeOAuth,
+	"/users/{username}/collection/folders/{folder_id}/releases/{release_id}/instances/{instance_id}":                   AuthTypeOAuth,
+}
+
+// RegisterEndpointAuth registers the authentication type required by the endpoints matching a route pattern, such as
+// "/users/{username}/lists", so endpoints this package does not know yet can be called with Get, Post, Put and Delete.
+// It replaces the authentication type of a known route. Like EndpointAuthMap, which it updates, it is not safe for
+// concurrent use with requests, so routes are meant to be registered at startup, such as in an init function.
+func RegisterEndpointAuth(pattern string, t AuthType) {
+	EndpointAuthMap[pattern] = t
 }
 
 // matchRoute determines the authentication type required for a given endpoint.
 func matchRoute(endpoint string, authMap map[string]AuthType) (AuthType, error) {
-	for route, authType := range authMap {
-		if isMatch(route, endpoint) {
-			return authType, nil
+	route, ok := bestRoute(endpoint, authMap)
+	if !ok {
+		return AuthTypeUnknown, &ErrMatchNotFound{Endpoint: endpoint}
+	}
+	return authMap[route], nil
+}
+
+// routePattern returns the route pattern matching a given endpoint, or the endpoint itself if no route matches.
+func routePattern(endpoint string, authMap map[string]AuthType) string {
+	if route, ok := bestRoute(endpoint, authMap); ok {
+		return route
+	}
+	return endpoint
+}
+
+// bestRoute returns the most specific route pattern matching a given endpoint, that is the one with the fewest
+// parameters, so literal routes such as "/users/{username}/collection/folders/0/releases" take precedence over the
+// routes they are an instance of. Ties are broken by the route itself, so the match does not depend on map order.
+func bestRoute(endpoint string, authMap map[string]AuthType) (string, bool) {
+	var best string
+	bestParams := -1
+	for route := range authMap {
+		if !isMatch(route, endpoint) {
+			continue
+		}
+		params := strings.Count(route, "{")
+		if bestParams < 0 || params < bestParams || (params == bestParams && route < best) {
+			best, bestParams = route, params
 		}
 	}
-	return AuthTypeUnknown, &ErrMatchNotFound{Endpoint: endpoint}
+	return best, bestParams >= 0
 }
 
 // isMatch checks if a given endpoint matches a route pattern.
@@ -67,6 +67,36 @@ func TestNewDiscogsClient(t *testing.T) {
 	}
 }
 
+// recordingTransport records the paths of the requests it forwards to the default transport.
+type recordingTransport struct {
+	paths []string
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.paths = append(rt.paths, req.URL.Path)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewDiscogsClient_Transport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Timeout: time.Minute}
+	transport := &recordingTransport{}
+	testClient := discogs.NewDiscogsClient(&discogs.DiscogsConfig{HTTPClient: httpClient, Transport: transport})
+	testClient.Host = server.URL
+
+	assert.NoError(t, testClient.Get(ctx, "/test", nil, nil, nil))
+	assert.Equal(t, []string{"/test"}, transport.paths)
+	assert.Equal(t, time.Minute, testClient.Timeout)
+	assert.Nil(t, httpClient.Transport)
+
+	testClient = discogs.NewDiscogsClient(&discogs.DiscogsConfig{HTTPClient: httpClient})
+	assert.Same(t, httpClient, testClient.Client)
+}
+
 func TestDiscogsClient_Get(t *testing.T) {
 	t.Parallel()
 
@@ -330,6 +360,8 @@ func Test_matchRoute(t *testing.T) {
 		"/key/secret": discogs.AuthTypeKeySecret,
 		"/oauth":      discogs.AuthTypeOAuth,
 		"/pat":        discogs.AuthTypePAT,
+		"/users/{id}": discogs.AuthTypeOAuth,
+		"/users/0":    discogs.AuthTypeNone,
 	}
 
 	type want struct {
@@ -370,6 +402,16 @@ func Test_matchRoute(t *testing.T) {
 			args{"/pat", endpointAuthMap},
 			want{discogs.AuthTypePAT, nil},
 		},
+		{
+			"matchRoute param route",
+			args{"/users/1", endpointAuthMap},
+			want{discogs.AuthTypeOAuth, nil},
+		},
+		{
+			"matchRoute literal route takes precedence",
+			args{"/users/0", endpointAuthMap},
+			want{discogs.AuthTypeNone, nil},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -424,3 +466,109 @@ func Test_isMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestIsNotFound(t *testing.T) {
+	httpErr := &discogs.HTTPError{StatusCode: http.StatusNotFound, Message: "not found"}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			"IsNotFound nil error",
+			nil,
+			false,
+		},
+		{
+			"IsNotFound HTTPError",
+			httpErr,
+			false,
+		},
+		{
+			"IsNotFound ErrNotFound",
+			&discogs.ErrNotFound{discogs.ResourceArtist, "1", httpErr},
+			true,
+		},
+		{
+			"IsNotFound wrapped ErrNotFound",
+			fmt.Errorf("wrapped: %w", &discogs.ErrNotFound{discogs.ResourceLabel, "1", httpErr}),
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, discogs.IsNotFound(tt.err))
+		})
+	}
+}
+
+func TestDiscogsClient_RetainRawResponse(t *testing.T) {
+	type RawTestClientResponse struct {
+		discogs.RawResponse
+		TestClientResponse
+	}
+
+	body := `{"success":true,"unmodeled":"value"}`
+
+	tests := []struct {
+		name   string
+		retain bool
+		want   json.RawMessage
+	}{
+		{
+			"RetainRawResponse disabled",
+			false,
+			nil,
+		},
+		{
+			"RetainRawResponse enabled",
+			true,
+			json.RawMessage(body),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if _, err := rw.Write([]byte(body)); err != nil {
+					assert.FailNow(t, "failed to write the response body: %w", err)
+				}
+			}))
+			defer server.Close()
+
+			testClient := discogs.NewDiscogsClient(&discogs.DiscogsConfig{RetainRawResponse: tt.retain})
+			testClient.Host = server.URL
+			var res RawTestClientResponse
+
+			err := testClient.Get(ctx, "/test", nil, nil, &res)
+
+			assert.NoError(t, err)
+			assert.True(t, res.Success)
+			assert.Equal(t, tt.want, res.Raw)
+		})
+	}
+}
+
+func TestDiscogsClient_KeySecretInQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Empty(t, req.Header.Get(discogs.AuthHeader))
+		assert.Equal(t, "nirvana", req.URL.Query().Get("q"))
+		assert.Equal(t, key, req.URL.Query().Get("key"))
+		assert.Equal(t, secret, req.URL.Query().Get("secret"))
+		_, _ = rw.Write([]byte(`{"pagination":{},"results":[]}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret, KeySecretInQuery: true})
+	client.Host = server.URL
+
+	_, err := client.Search(ctx, &discogs.SearchOptions{Query: "nirvana"})
+	assert.NoError(t, err)
+
+	// The secret is redacted from transport errors
+	client.Host = "http://127.0.0.1:1"
+	_, err = client.Search(ctx, &discogs.SearchOptions{Query: "nirvana"})
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret="+secret)
+	assert.Contains(t, err.Error(), "secret=REDACTED")
+}
@@ -0,0 +1,308 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDownloadChunkSize is the default size of the chunks of a parallel download.
+	DefaultDownloadChunkSize = 8 << 20
+	// DefaultDownloadChunkRetries is the default number of times a failed chunk is resumed.
+	DefaultDownloadChunkRetries = 3
+)
+
+// DownloadOptions represents the options for downloading a file, such as an inventory export or an image.
+type DownloadOptions struct {
+	// Concurrency is the number of chunks downloaded in parallel. Files are downloaded with a single request if
+	// Concurrency is 1 or less, or if the server does not support range requests.
+	Concurrency int
+	// ChunkSize is the size in bytes of each chunk of a parallel download. DefaultDownloadChunkSize is used if unset.
+	ChunkSize int64
+	// ChunkRetries is the number of times a failed chunk is resumed from the last byte received before the download
+	// fails. DefaultDownloadChunkRetries is used if unset; use a negative value to disable resuming.
+	ChunkRetries int
+	// AuthType is the type of authentication sent with the download requests. No authentication is sent if unset.
+	AuthType AuthType
+}
+
+// ErrDownloadIncomplete indicates that a download ended before all bytes of the file were received.
+type ErrDownloadIncomplete struct {
+	URL      string
+	Received int64
+	Expected int64
+}
+
+func (e *ErrDownloadIncomplete) Error() string {
+	return fmt.Sprintf("download of %s incomplete: received %d of %d bytes", e.URL, e.Received, e.Expected)
+}
+
+// Download downloads the file at rawURL into w and returns the number of bytes written. Large files can be split into
+// chunks downloaded in parallel, and chunks that fail midway are resumed from the last byte received instead of
+// restarting the download. Every request waits on the rate limiter of the file's host, and applies the RequestOptions
+// of ctx, see WithRequestOptions.
+func (dc *DiscogsClient) Download(ctx context.Context, rawURL string, w io.WriterAt, options *DownloadOptions) (int64, error) {
+	var opts DownloadOptions
+	if options != nil {
+		opts = *options
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = DefaultDownloadChunkSize
+	}
+	if opts.ChunkRetries == 0 {
+		opts.ChunkRetries = DefaultDownloadChunkRetries
+	}
+
+	size := int64(-1)
+	if opts.Concurrency > 1 {
+		var err error
+		size, err = dc.rangeSize(ctx, rawURL, &opts)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Fall back to a single chunk if the size is unknown or the server does not support range requests
+	if size < 0 {
+		return dc.downloadChunk(ctx, rawURL, w, 0, -1, &opts)
+	}
+
+	type chunk struct {
+		start int64
+		end   int64
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := make(chan chunk)
+	go func() {
+		defer close(chunks)
+		for start := int64(0); start < size; start += opts.ChunkSize {
+			select {
+			case chunks <- chunk{start, min(start+opts.ChunkSize, size) - 1}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var written int64
+	var firstErr error
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range chunks {
+				n, err := dc.downloadChunk(ctx, rawURL, w, c.start, c.end, &opts)
+
+				mu.Lock()
+				written += n
+				if err != nil && firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return written, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return written, err
+	}
+	return written, nil
+}
+
+// rangeSize returns the size of the file at rawURL if the server supports range requests, or -1 otherwise.
+func (dc *DiscogsClient) rangeSize(ctx context.Context, rawURL string, opts *DownloadOptions) (int64, error) {
+	req, err := dc.newDownloadRequest(ctx, http.MethodHead, rawURL, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := dc.stream(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	response.Body.Close()
+
+	if response.Header.Get("Accept-Ranges") != "bytes" || response.ContentLength <= 0 {
+		return -1, nil
+	}
+	return response.ContentLength, nil
+}
+
+// downloadChunk downloads the bytes start through end of the file at rawURL into w. The whole file is downloaded if
+// end is negative. If the transfer fails with an error that is likely to be transient, it is resumed from the last
+// byte received up to ChunkRetries times.
+func (dc *DiscogsClient) downloadChunk(ctx context.Context, rawURL string, w io.WriterAt, start, end int64, opts *DownloadOptions) (int64, error) {
+	var written int64
+	for attempt := 0; ; attempt++ {
+		n, err := dc.downloadRange(ctx, rawURL, w, start+written, end, written > 0 || end >= 0, opts)
+		written += n
+		if err == nil {
+			return written, nil
+		}
+
+		if attempt >= opts.ChunkRetries || ctx.Err() != nil || !isResumable(err) {
+			return written, err
+		}
+
+		wait := dc.retryPolicy().Backoff(attempt+1, 0)
+		var rateLimited *ErrRateLimited
+		if errors.As(err, &rateLimited) {
+			wait = max(wait, rateLimited.RetryAfter)
+		}
+		select {
+		case <-ctx.Done():
+			return written, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// isResumable reports whether a chunk that failed with err is likely to succeed if resumed: the transfer was cut off,
+// a transient network error occurred, or the server answered with a 429 or 5xx status code. Other errors, such as a
+// file that is not found or a range request the server ignored, would fail again.
+func isResumable(err error) bool {
+	var incomplete *ErrDownloadIncomplete
+	if errors.As(err, &incomplete) || isTransientNetworkError(err) {
+		return true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	return false
+}
+
+// downloadRange performs a single request for the bytes start through end of the file at rawURL and copies the
+// response body into w. A Range header is only sent if useRange is set.
+func (dc *DiscogsClient) downloadRange(ctx context.Context, rawURL string, w io.WriterAt, start, end int64, useRange bool, opts *DownloadOptions) (int64, error) {
+	req, err := dc.newDownloadRequest(ctx, http.MethodGet, rawURL, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	if useRange {
+		byteRange := "bytes=" + strconv.FormatInt(start, 10) + "-"
+		if end >= 0 {
+			byteRange += strconv.FormatInt(end, 10)
+		}
+		req.Header.Set("Range", byteRange)
+	}
+
+	response, err := dc.stream(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if useRange && response.StatusCode != http.StatusPartialContent {
+		return 0, &HTTPError{StatusCode: response.StatusCode, Message: "server ignored range request"}
+	}
+
+	n, err := io.Copy(io.NewOffsetWriter(w, start), response.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	expected := response.ContentLength
+	if end >= 0 {
+		expected = end - start + 1
+	}
+	if expected >= 0 && n < expected {
+		return n, &ErrDownloadIncomplete{URL: rawURL, Received: n, Expected: expected}
+	}
+	return n, nil
+}
+
+// newDownloadRequest creates a request for downloading the file at rawURL, with the query parameters and headers of
+// the RequestOptions of ctx, and the User-Agent and authentication headers set.
+func (dc *DiscogsClient) newDownloadRequest(ctx context.Context, method, rawURL string, opts *DownloadOptions) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	options := requestOptionsFrom(ctx)
+	if err := options.apply(req); err != nil {
+		return nil, err
+	}
+	req.Header.Set(UserAgentHeader, dc.Config.AppName)
+
+	authType := opts.AuthType
+	if authType == "" {
+		authType = AuthTypeNone
+	}
+	if err := dc.addAuthHeaders(req, authType); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// stream sends an HTTP request, respecting the rate limits, and returns the response with its body unread so it can
+// be streamed. The caller must close the response body. The timeout of the RequestOptions of ctx also bounds reading
+// the body. It returns an HTTPError if the response status code is not 2xx.
+func (dc *DiscogsClient) stream(ctx context.Context, req *http.Request) (_ *http.Response, err error) {
+	// The request context may carry more than ctx, such as the route of the request
+	options := requestOptionsFrom(ctx)
+	ctx, cancel := options.withTimeout(req.Context())
+	req = req.WithContext(ctx)
+	defer func() {
+		if err != nil {
+			cancel()
+		}
+	}()
+
+	err = dc.waitRateLimit(ctx, req.URL)
+	if err != nil {
+		return nil, err
+	}
+	release, err := dc.acquireInFlight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signAttempt(req)
+
+	start := time.Now()
+	defer func() {
+		dc.stats.recordAttempt(req, time.Since(start), err)
+	}()
+
+	response, err := dc.Client.Do(req)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("request failed: %w", redactSecret(err))
+	}
+	dc.recordRateLimitStatus(response)
+	dc.adjustRateLimit(response)
+	response.Body = &releasingBody{ReadCloser: response.Body, release: func() {
+		release()
+		cancel()
+	}}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		defer response.Body.Close()
+
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, statusError(response, responseBody)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,187 @@
+package discogs_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+// writerAtBuffer is a concurrency-safe io.WriterAt backed by a byte slice.
+type writerAtBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (w *writerAtBuffer) WriteAt(p []byte, off int64) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if end := int(off) + len(p); end > len(w.buf) {
+		w.buf = append(w.buf, make([]byte, end-len(w.buf))...)
+	}
+	return copy(w.buf[off:], p), nil
+}
+
+// truncatingWriter is an http.ResponseWriter that drops everything written after the first remaining bytes.
+type truncatingWriter struct {
+	http.ResponseWriter
+	remaining int
+}
+
+func (w *truncatingWriter) Write(p []byte) (int, error) {
+	n := min(len(p), w.remaining)
+	w.remaining -= n
+	_, err := w.ResponseWriter.Write(p[:n])
+	return len(p), err
+}
+
+func TestDiscogsClient_Download(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+
+	type args struct {
+		options *discogs.DownloadOptions
+		// ranges is whether the mock server supports range requests.
+		ranges bool
+		// interruptions is the number of responses that are cut off midway.
+		interruptions int64
+	}
+	type want struct {
+		minRequests int64
+		err         bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"Download single request",
+			args{nil, true, 0},
+			want{1, false},
+		},
+		{
+			"Download parallel chunks",
+			args{&discogs.DownloadOptions{Concurrency: 4, ChunkSize: 1024}, true, 0},
+			want{11, false},
+		},
+		{
+			"Download without range support",
+			args{&discogs.DownloadOptions{Concurrency: 4, ChunkSize: 1024}, false, 0},
+			want{2, false},
+		},
+		{
+			"Download resumes interrupted chunks",
+			args{&discogs.DownloadOptions{Concurrency: 2, ChunkSize: 4096}, true, 2},
+			want{6, false},
+		},
+		{
+			"Download fails when resuming is disabled",
+			args{&discogs.DownloadOptions{Concurrency: 2, ChunkSize: 4096, ChunkRetries: -1}, true, 1},
+			want{2, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests, interruptions atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				requests.Add(1)
+				if !tt.args.ranges {
+					_, _ = rw.Write(content)
+					return
+				}
+
+				if req.Method == http.MethodGet && interruptions.Add(1) <= tt.args.interruptions {
+					// Promise the requested range but only send part of it
+					rw = &truncatingWriter{ResponseWriter: rw, remaining: 100}
+				}
+				http.ServeContent(rw, req, "export.csv", time.Time{}, bytes.NewReader(content))
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{RetryWait: time.Millisecond})
+
+			var w writerAtBuffer
+			n, err := client.Download(context.Background(), server.URL+"/export.csv", &w, tt.args.options)
+			if tt.want.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(content)), n)
+			assert.Equal(t, content, w.buf)
+			assert.GreaterOrEqual(t, requests.Load(), tt.want.minRequests)
+		})
+	}
+}
+
+func TestDiscogsClient_DownloadRetries(t *testing.T) {
+	tests := []struct {
+		name string
+		// statuses are the status codes of the first responses, before the file is served.
+		statuses []int
+		wantErr  bool
+		// requests is the number of requests sent.
+		requests int64
+	}{
+		{"Download retries server errors", []int{http.StatusServiceUnavailable}, false, 2},
+		{"Download retries rate limited requests", []int{http.StatusTooManyRequests}, false, 2},
+		{"Download does not retry missing files", []int{http.StatusNotFound}, true, 1},
+		{"Download does not retry forbidden files", []int{http.StatusForbidden}, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if n := requests.Add(1); n <= int64(len(tt.statuses)) {
+					rw.WriteHeader(tt.statuses[n-1])
+					return
+				}
+				_, _ = rw.Write([]byte("content"))
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{RetryWait: time.Millisecond})
+
+			var w writerAtBuffer
+			_, err := client.Download(ctx, server.URL+"/export.csv", &w, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "content", string(w.buf))
+			}
+			assert.Equal(t, tt.requests, requests.Load())
+		})
+	}
+}
+
+func TestDiscogsClient_DownloadRateLimitHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set(discogs.RateLimitHeader, "30")
+		_, _ = rw.Write([]byte("listing_id,price\n"))
+	}))
+	defer server.Close()
+
+	var updates [][2]int
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+		AccessToken:       &token,
+		MaxRequests:       60,
+		OnRateLimitUpdate: func(old, new int) { updates = append(updates, [2]int{old, new}) },
+	})
+	client.Host = server.URL
+
+	var buf bytes.Buffer
+	_, err := client.DownloadInventoryExport(ctx, 1, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int{{60, 30}}, updates)
+}
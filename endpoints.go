@@ -0,0 +1,88 @@
+package discogs
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+)
+
+// Endpoint describes a route of the Discogs API supported by this package.
+type Endpoint struct {
+	// Route is the route pattern of the endpoint, such as "/releases/{release_id}".
+	Route string
+	// Methods are the HTTP methods the Discogs API accepts on the endpoint.
+	Methods []string
+	// AuthType is the authentication the endpoint requires.
+	AuthType AuthType
+}
+
+// endpointMethods maps the routes of EndpointAuthMap to the HTTP methods the Discogs API accepts on them.
+var endpointMethods = map[string][]string{
+	"/":                             {http.MethodGet},
+	"/oauth/identity":               {http.MethodGet},
+	"/oauth/request_token":          {http.MethodGet},
+	"/oauth/access_token":           {http.MethodPost},
+	"/test":                         {http.MethodGet},
+	"/releases/{release_id}":        {http.MethodGet},
+	"/releases/{release_id}/rating": {http.MethodGet},
+	"/releases/{release_id}/stats":  {http.MethodGet},
+	"/masters/{master_id}":          {http.MethodGet},
+	"/masters/{master_id}/versions": {http.MethodGet},
+	"/artists/{artist_id}":          {http.MethodGet},
+	"/artists/{artist_id}/releases": {http.MethodGet},
+	"/labels/{label_id}":            {http.MethodGet},
+	"/labels/{label_id}/releases":   {http.MethodGet},
+	"/database/search":              {http.MethodGet},
+	"/inventory/export":             {http.MethodGet, http.MethodPost},
+	"/users/{username}":             {http.MethodGet, http.MethodPost},
+	"/users/{username}/collection/folders/{folder_id}/releases":              {http.MethodGet},
+	"/users/{username}/collection/folders/0/releases":                        {http.MethodGet},
+	"/users/{username}/collection/folders/{folder_id}":                       {http.MethodGet, http.MethodPost, http.MethodDelete},
+	"/releases/{release_id}/rating/{username}":                               {http.MethodPut},
+	"/users/{username}/submissions":                                          {http.MethodGet},
+	"/users/{username}/contributions":                                        {http.MethodGet},
+	"/inventory/export/{export_id}":                                          {http.MethodGet},
+	"/inventory/export/{export_id}/download":                                 {http.MethodGet},
+	"/users/{username}/inventory":                                            {http.MethodGet},
+	"/users/{username}/wants":                                                {http.MethodGet},
+	"/users/{username}/wants/{release_id}":                                   {http.MethodPut},
+	"/users/{username}/collection/fields":                                    {http.MethodGet},
+	"/users/{username}/collection/folders/{folder_id}/releases/{release_id}": {http.MethodPost},
+	"/marketplace/orders":                                                    {http.MethodGet},
+	"/marketplace/orders/{order_id}":                                         {http.MethodGet, http.MethodPost},
+	"/marketplace/orders/{order_id}/messages":                                {http.MethodPost},
+	"/marketplace/listings/{listing_id}":                                     {http.MethodGet, http.MethodPost},
+	"/marketplace/stats/{release_id}":                                        {http.MethodGet},
+	"/marketplace/price_suggestions/{release_id}":                            {http.MethodGet},
+	"/lists/{list_id}":                                                       {http.MethodGet},
+	"/inventory/upload":                                                      {http.MethodGet},
+	"/inventory/upload/add":                                                  {http.MethodPost},
+	"/inventory/upload/change":                                               {http.MethodPost},
+	"/inventory/upload/delete":                                               {http.MethodPost},
+	"/inventory/upload/{upload_id}":                                          {http.MethodGet},
+	"/marketplace/listings":                                                  {http.MethodPost},
+	"/marketplace/fee/{price}":                                               {http.MethodGet},
+	"/marketplace/fee/{price}/{currency}":                                    {http.MethodGet},
+	"/users/{username}/lists":                                                {http.MethodGet},
+	"/users/{username}/collection/value":                                     {http.MethodGet},
+	"/users/{username}/collection/folders":                                   {http.MethodGet, http.MethodPost},
+	"/users/{username}/collection/releases/{release_id}":                     {http.MethodGet},
+	"/users/{username}/collection/folders/{folder_id}/releases/{release_id}/instances/{instance_id}/fields/{field_id}": {http.MethodPost},
+	"/users/{username}/collection/folders/{folder_id}/releases/{release_id}/instances/{instance_id}":                   {http.MethodPost, http.MethodDelete},
+}
+
+// ListEndpoints returns the endpoints supported by this package, ordered by route, so tools can introspect the
+// coverage of the Discogs API and the authentication each endpoint requires. Routes registered with
+// RegisterEndpointAuth are included, without methods.
+func ListEndpoints() []Endpoint {
+	endpoints := make([]Endpoint, 0, len(EndpointAuthMap))
+	for route, authType := range EndpointAuthMap {
+		endpoints = append(endpoints, Endpoint{
+			Route:    route,
+			Methods:  slices.Clone(endpointMethods[route]),
+			AuthType: authType,
+		})
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Route < endpoints[j].Route })
+	return endpoints
+}
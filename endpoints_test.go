@@ -0,0 +1,55 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListEndpoints(t *testing.T) {
+	endpoints := discogs.ListEndpoints()
+	assert.Len(t, endpoints, len(discogs.EndpointAuthMap))
+
+	for i, endpoint := range endpoints {
+		assert.NotEmpty(t, endpoint.Methods, "route %s has no methods", endpoint.Route)
+		assert.Equal(t, discogs.EndpointAuthMap[endpoint.Route], endpoint.AuthType)
+		if i > 0 {
+			assert.Less(t, endpoints[i-1].Route, endpoint.Route)
+		}
+	}
+
+	assert.Contains(t, endpoints, discogs.Endpoint{
+		Route:    "/users/{username}",
+		Methods:  []string{http.MethodGet, http.MethodPost},
+		AuthType: discogs.AuthTypeNone,
+	})
+}
+
+func TestRegisterEndpointAuth(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		headers = append(headers, req.Header.Get(discogs.AuthHeader))
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	err := client.Get(ctx, "/new/1", nil, nil, nil)
+	assert.Equal(t, &discogs.ErrMatchNotFound{Endpoint: "/new/1"}, err)
+
+	discogs.RegisterEndpointAuth("/new/{id}", discogs.AuthTypeOAuth)
+	defer delete(discogs.EndpointAuthMap, "/new/{id}")
+	assert.NoError(t, client.Get(ctx, "/new/1", nil, nil, nil))
+
+	// Unknown routes fall back to the default authentication type if set
+	client.Config.DefaultAuthType = discogs.AuthTypeNone
+	assert.NoError(t, client.Get(ctx, "/unknown", nil, nil, nil))
+
+	assert.Equal(t, []string{"Bearer token", ""}, headers)
+}
@@ -0,0 +1,286 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// batchConcurrency is the number of requests the batch helpers, such as Releases, send at once. The rate limiter
+// spaces the requests out anyway, so a few workers are enough to keep it busy.
+const batchConcurrency = 4
+
+// ErrBatchFailed indicates that some lookups of a batch failed, while the others succeeded. Errors holds the error of
+// every failed lookup, keyed by ID.
+type ErrBatchFailed struct {
+	Errors map[int64]error
+}
+
+// Error returns a formatted error message listing the failed IDs.
+//
+// Example: "2 lookups failed: 2: release ID 2 not found: ..., 5: ...".
+func (e *ErrBatchFailed) Error() string {
+	ids := make([]int64, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	messages := make([]string, len(ids))
+	for i, id := range ids {
+		messages[i] = strconv.FormatInt(id, 10) + ": " + e.Errors[id].Error()
+	}
+	return fmt.Sprintf("%d lookups failed: %s", len(ids), strings.Join(messages, ", "))
+}
+
+// Unwrap returns the errors of the failed lookups, so errors.Is and errors.As match any of them.
+func (e *ErrBatchFailed) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// EnrichOptions represents the options for fetching an enriched release.
+type EnrichOptions struct {
+	// CurrAbr is the currency of the release's lowest price and of its marketplace statistics.
+	CurrAbr Currency
+	// SkipPriceSuggestions skips fetching price suggestions, which require OAuth or a personal access token and
+	// filled in seller settings.
+	SkipPriceSuggestions bool
+}
+
+// EnrichedRelease combines a release with its community rating, statistics, marketplace statistics and price
+// suggestions.
+type EnrichedRelease struct {
+	Release          *ReleaseResponse
+	Rating           *CommunityReleaseRatingResponse
+	Stats            *ReleaseStatsResponse
+	MarketplaceStats *MarketplaceStatsResponse
+	// PriceSuggestions is nil if EnrichOptions.SkipPriceSuggestions is set.
+	PriceSuggestions PriceSuggestionsResponse
+}
+
+// EnrichRelease concurrently fetches a release along with its community rating, statistics, marketplace statistics
+// and price suggestions, and returns them combined. Every request goes through the client, so they are still spaced
+// out by the rate limiter. If options is nil, the default options are used.
+//
+// If any request fails, the remaining requests are canceled and the first error is returned.
+func (dc *DiscogsClient) EnrichRelease(ctx context.Context, releaseID int64, options *EnrichOptions) (*EnrichedRelease, error) {
+	var opts EnrichOptions
+	if options != nil {
+		opts = *options
+	}
+	if err := validateCurrency(opts.CurrAbr); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var res EnrichedRelease
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	fetch := func(fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+
+	fetch(func() (err error) {
+		res.Release, err = dc.Release(ctx, releaseID, &ReleaseOptions{CurrAbr: opts.CurrAbr})
+		return err
+	})
+	fetch(func() (err error) {
+		res.Rating, err = dc.CommunityReleaseRating(ctx, releaseID)
+		return err
+	})
+	fetch(func() (err error) {
+		res.Stats, err = dc.ReleaseStats(ctx, releaseID)
+		return err
+	})
+	fetch(func() (err error) {
+		res.MarketplaceStats, err = dc.MarketplaceStats(ctx, releaseID, &MarketplaceStatsOptions{CurrAbr: opts.CurrAbr})
+		return err
+	})
+	if !opts.SkipPriceSuggestions {
+		fetch(func() (err error) {
+			res.PriceSuggestions, err = dc.PriceSuggestions(ctx, releaseID)
+			return err
+		})
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return &res, nil
+}
+
+// MarketplaceStatsByCurrency fetches the marketplace statistics of a release in several currencies concurrently, with
+// one request per currency, and returns them keyed by currency. Duplicate currencies are requested once. The requests
+// go through the same client, so they are spaced out by the rate limiter.
+//
+// It returns an ErrInvalidCurrency before sending any request if a currency is not valid. If any request fails, the
+// remaining requests are canceled and the first error is returned.
+func (dc *DiscogsClient) MarketplaceStatsByCurrency(ctx context.Context, releaseID int64, currencies []Currency) (map[Currency]*MarketplaceStatsResponse, error) {
+	for _, currency := range currencies {
+		if !currency.IsValid() {
+			return nil, &ErrInvalidCurrency{Currency: string(currency)}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Deduplicate the currencies before any request starts, so the goroutines are the only ones using res
+	var unique []Currency
+	seen := make(map[Currency]bool, len(currencies))
+	for _, currency := range currencies {
+		if !seen[currency] {
+			seen[currency] = true
+			unique = append(unique, currency)
+		}
+	}
+
+	res := make(map[Currency]*MarketplaceStatsResponse, len(unique))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for _, currency := range unique {
+		wg.Add(1)
+		go func(currency Currency) {
+			defer wg.Done()
+
+			stats, err := dc.MarketplaceStats(ctx, releaseID, &MarketplaceStatsOptions{CurrAbr: currency})
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+
+			mu.Lock()
+			res[currency] = stats
+			mu.Unlock()
+		}(currency)
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return res, nil
+}
+
+// LowestPrices fetches the lowest price of a release in several currencies concurrently, see
+// MarketplaceStatsByCurrency, and returns them keyed by currency. Currencies are missing from the result if the
+// release is not for sale.
+func (dc *DiscogsClient) LowestPrices(ctx context.Context, releaseID int64, currencies []Currency) (map[Currency]Price, error) {
+	stats, err := dc.MarketplaceStatsByCurrency(ctx, releaseID, currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[Currency]Price, len(stats))
+	for currency, s := range stats {
+		if s.LowestPrice != nil {
+			prices[currency] = *s.LowestPrice
+		}
+	}
+	return prices, nil
+}
+
+// Releases fetches many releases concurrently and returns them in the order of ids. Duplicate IDs are fetched once.
+// Every request goes through the client, so they are spaced out by the rate limiter. If options is nil, no release
+// options are used.
+//
+// A failed lookup does not stop the others: the release is nil in the result, and its error is collected in an
+// ErrBatchFailed returned along with the releases that were fetched. It returns an ErrInvalidCurrency before sending
+// any request if the currency in options is not valid, and the context's error if ctx is canceled.
+//
+// Example:
+//
+//	releases, err := client.Releases(ctx, ids, nil)
+//	var batchErr *discogs.ErrBatchFailed
+//	if errors.As(err, &batchErr) {
+//		for id, err := range batchErr.Errors {
+//			log.Printf("skipping release %d: %v", id, err)
+//		}
+//	} else if err != nil {
+//		return err
+//	}
+func (dc *DiscogsClient) Releases(ctx context.Context, ids []int64, options *ReleaseOptions) ([]*ReleaseResponse, error) {
+	if options != nil {
+		if err := validateCurrency(options.CurrAbr); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	queue := make(chan int64, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			queue <- id
+		}
+	}
+	close(queue)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	fetched := make(map[int64]*ReleaseResponse, len(seen))
+	errs := make(map[int64]error)
+
+	workers := min(batchConcurrency, len(seen))
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range queue {
+				if ctx.Err() != nil {
+					return
+				}
+
+				release, err := dc.Release(ctx, id, options)
+				mu.Lock()
+				if err != nil {
+					errs[id] = err
+				} else {
+					fetched[id] = release
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	releases := make([]*ReleaseResponse, len(ids))
+	for i, id := range ids {
+		releases[i] = fetched[id]
+	}
+	if len(errs) > 0 {
+		return releases, &ErrBatchFailed{Errors: errs}
+	}
+	return releases, nil
+}
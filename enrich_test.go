@@ -0,0 +1,178 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_EnrichRelease(t *testing.T) {
+	token := "token"
+
+	type args struct {
+		options *discogs.EnrichOptions
+		// failPath is a path the mock server responds to with an error.
+		failPath string
+	}
+	type want struct {
+		requests int64
+		res      *discogs.EnrichedRelease
+		err      bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"EnrichRelease fetches everything",
+			args{&discogs.EnrichOptions{CurrAbr: discogs.CurrencyEUR}, ""},
+			want{5, &discogs.EnrichedRelease{
+				Release:          &discogs.ReleaseResponse{ID: 1, Title: "Release"},
+				Rating:           &discogs.CommunityReleaseRatingResponse{ReleaseID: 1, Rating: &discogs.CommunityRating{Average: discogs.Float64(4.5), Count: discogs.Int64(10)}},
+				Stats:            &discogs.ReleaseStatsResponse{NumHave: discogs.Int64(100), NumWant: discogs.Int64(50)},
+				MarketplaceStats: &discogs.MarketplaceStatsResponse{LowestPrice: &discogs.Price{Currency: discogs.CurrencyEUR, Value: 999}, NumForSale: discogs.Int64(3)},
+				PriceSuggestions: discogs.PriceSuggestionsResponse{"Mint (M)": {Currency: discogs.CurrencyEUR, Value: 2000}},
+			}, false},
+		},
+		{
+			"EnrichRelease skips price suggestions",
+			args{&discogs.EnrichOptions{SkipPriceSuggestions: true}, ""},
+			want{4, &discogs.EnrichedRelease{
+				Release:          &discogs.ReleaseResponse{ID: 1, Title: "Release"},
+				Rating:           &discogs.CommunityReleaseRatingResponse{ReleaseID: 1, Rating: &discogs.CommunityRating{Average: discogs.Float64(4.5), Count: discogs.Int64(10)}},
+				Stats:            &discogs.ReleaseStatsResponse{NumHave: discogs.Int64(100), NumWant: discogs.Int64(50)},
+				MarketplaceStats: &discogs.MarketplaceStatsResponse{LowestPrice: &discogs.Price{Currency: discogs.CurrencyEUR, Value: 999}, NumForSale: discogs.Int64(3)},
+			}, false},
+		},
+		{
+			"EnrichRelease fails if any request fails",
+			args{nil, "/releases/1/stats"},
+			want{5, nil, true},
+		},
+		{
+			"EnrichRelease with invalid currency",
+			args{&discogs.EnrichOptions{CurrAbr: "XYZ"}, ""},
+			want{0, nil, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				requests.Add(1)
+				if req.URL.Path == tt.args.failPath {
+					rw.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				var res interface{}
+				switch req.URL.Path {
+				case "/releases/1":
+					res = discogs.ReleaseResponse{ID: 1, Title: "Release"}
+				case "/releases/1/rating":
+					res = discogs.CommunityReleaseRatingResponse{ReleaseID: 1, Rating: &discogs.CommunityRating{Average: discogs.Float64(4.5), Count: discogs.Int64(10)}}
+				case "/releases/1/stats":
+					res = discogs.ReleaseStatsResponse{NumHave: discogs.Int64(100), NumWant: discogs.Int64(50)}
+				case "/marketplace/stats/1":
+					res = discogs.MarketplaceStatsResponse{LowestPrice: &discogs.Price{Currency: discogs.CurrencyEUR, Value: 999}, NumForSale: discogs.Int64(3)}
+				case "/marketplace/price_suggestions/1":
+					assert.Equal(t, "Bearer "+token, req.Header.Get(discogs.AuthHeader))
+					res = discogs.PriceSuggestionsResponse{"Mint (M)": {Currency: discogs.CurrencyEUR, Value: 2000}}
+				default:
+					rw.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				_ = json.NewEncoder(rw).Encode(res)
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+			client.Host = server.URL
+
+			res, err := client.EnrichRelease(ctx, 1, tt.args.options)
+			if tt.want.err {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want.res, res)
+			}
+			assert.LessOrEqual(t, requests.Load(), tt.want.requests)
+		})
+	}
+}
+
+func TestDiscogsClient_LowestPrices(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		currency := req.URL.Query().Get("curr_abbr")
+		mu.Lock()
+		requested = append(requested, currency)
+		mu.Unlock()
+
+		if currency == "JPY" {
+			_, _ = rw.Write([]byte(`{"lowest_price":null,"num_for_sale":0}`))
+			return
+		}
+		_, _ = fmt.Fprintf(rw, `{"lowest_price":{"currency":%q,"value":10},"num_for_sale":3}`, currency)
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	got, err := client.LowestPrices(ctx, 1, []discogs.Currency{discogs.CurrencyEUR, discogs.CurrencyUSD, discogs.CurrencyEUR, discogs.CurrencyJPY})
+	assert.NoError(t, err)
+	assert.Equal(t, map[discogs.Currency]discogs.Price{
+		discogs.CurrencyEUR: {Currency: discogs.CurrencyEUR, Value: 1000},
+		discogs.CurrencyUSD: {Currency: discogs.CurrencyUSD, Value: 1000},
+	}, got)
+	assert.ElementsMatch(t, []string{"EUR", "USD", "JPY"}, requested)
+
+	_, err = client.LowestPrices(ctx, 1, []discogs.Currency{"XYZ"})
+	assert.Equal(t, &discogs.ErrInvalidCurrency{Currency: "XYZ"}, err)
+}
+
+func TestDiscogsClient_Releases(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "EUR", req.URL.Query().Get("curr_abbr"))
+		if req.URL.Path == "/releases/2" {
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"message": "Release not found."}`))
+			return
+		}
+		var id int64
+		_, _ = fmt.Sscanf(req.URL.Path, "/releases/%d", &id)
+		_ = json.NewEncoder(rw).Encode(discogs.ReleaseResponse{ID: id})
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret})
+	client.Host = server.URL
+
+	releases, err := client.Releases(ctx, []int64{1, 2, 3, 1}, &discogs.ReleaseOptions{CurrAbr: discogs.CurrencyEUR})
+	assert.Equal(t, []*discogs.ReleaseResponse{{ID: 1}, nil, {ID: 3}, {ID: 1}}, releases)
+	assert.Equal(t, int64(3), requests.Load())
+
+	var batchErr *discogs.ErrBatchFailed
+	assert.ErrorAs(t, err, &batchErr)
+	assert.Len(t, batchErr.Errors, 1)
+	assert.True(t, discogs.IsNotFound(batchErr.Errors[2]))
+	assert.True(t, discogs.IsNotFound(err))
+
+	_, err = client.Releases(ctx, []int64{1}, &discogs.ReleaseOptions{CurrAbr: "XYZ"})
+	assert.Equal(t, &discogs.ErrInvalidCurrency{Currency: "XYZ"}, err)
+	assert.Equal(t, int64(3), requests.Load())
+}
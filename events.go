@@ -0,0 +1,148 @@
+package discogs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultEventBuffer is the default number of events a Subscription buffers before publishers block.
+const DefaultEventBuffer = 16
+
+// EventKind represents the kind of change an Event reports.
+type EventKind string
+
+// EventKind constants representing the changes reported by the watchers.
+const (
+	EventPriceAlert      EventKind = "price_alert"
+	EventOrderChanged    EventKind = "order_changed"
+	EventProfileChanged  EventKind = "profile_changed"
+	EventDatabaseChanged EventKind = "database_changed"
+)
+
+// Event represents a change observed on Discogs by one of the watchers. Kind tells which of PriceAlert, Order,
+// Profile and Database is set.
+type Event struct {
+	Kind       EventKind
+	Time       time.Time
+	PriceAlert *PriceAlert
+	Order      *OrderChange
+	Profile    *ProfileChange
+	Database   *DatabaseChange
+}
+
+// An EventFeed fans the events of the watchers out to its subscribers, so an application can consume all changes
+// through a single feed. A watcher publishes to the feed set in its options.
+//
+// Publishing applies backpressure: it blocks until every subscriber has room for the event in its buffer, so a slow
+// subscriber slows the watchers down instead of missing events.
+//
+// An EventFeed is safe for concurrent use.
+type EventFeed struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+// NewEventFeed creates a new EventFeed without subscribers.
+func NewEventFeed() *EventFeed {
+	return &EventFeed{subs: make(map[*Subscription]struct{})}
+}
+
+// A Subscription receives the events published to an EventFeed after it was created.
+type Subscription struct {
+	feed *EventFeed
+	ch   chan Event
+	done chan struct{}
+	once sync.Once
+}
+
+// Subscribe creates a Subscription buffering up to buffer events. DefaultEventBuffer is used if buffer is not
+// positive. The subscriber must receive from Events until it calls Close, otherwise publishing blocks.
+func (f *EventFeed) Subscribe(buffer int) *Subscription {
+	if buffer <= 0 {
+		buffer = DefaultEventBuffer
+	}
+
+	s := &Subscription{feed: f, ch: make(chan Event, buffer), done: make(chan struct{})}
+
+	f.mu.Lock()
+	f.subs[s] = struct{}{}
+	f.mu.Unlock()
+
+	return s
+}
+
+// SubscribeFunc subscribes fn to the feed. The events are passed to fn one at a time, in a separate goroutine, until
+// the returned Subscription is closed. A slow fn applies backpressure like any other subscriber once buffer events
+// are pending.
+func (f *EventFeed) SubscribeFunc(buffer int, fn func(Event)) *Subscription {
+	s := f.Subscribe(buffer)
+	go func() {
+		for event := range s.ch {
+			fn(event)
+		}
+	}()
+	return s
+}
+
+// Events returns the channel the events are delivered on. It is closed by Close.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Close unsubscribes from the feed and closes the Events channel. Publishers blocked on the subscription are
+// released. It is safe to call Close more than once.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.feed.mu.Lock()
+		delete(s.feed.subs, s)
+		close(s.ch)
+		s.feed.mu.Unlock()
+	})
+}
+
+// Publish delivers an event to every subscriber, blocking until each has room for it, and sets the event's Time if
+// it is unset. It returns the context's error if ctx is canceled before the event is delivered to every subscriber.
+// Publishing to a nil EventFeed does nothing.
+func (f *EventFeed) Publish(ctx context.Context, event Event) error {
+	if f == nil {
+		return nil
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for s := range f.subs {
+		select {
+		case s.ch <- event:
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// pollEvery calls poll immediately and then every interval until ctx is canceled, and then returns the context's
+// error. Errors returned by poll are passed to onError, if set, unless ctx was canceled.
+func pollEvery(ctx context.Context, interval time.Duration, poll func(ctx context.Context) error, onError func(err error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := poll(ctx); err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
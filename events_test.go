@@ -0,0 +1,73 @@
+package discogs_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFeed(t *testing.T) {
+	feed := discogs.NewEventFeed()
+
+	sub := feed.Subscribe(2)
+	defer sub.Close()
+
+	var mu sync.Mutex
+	var received []discogs.EventKind
+	done := make(chan struct{})
+	funcSub := feed.SubscribeFunc(0, func(event discogs.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, event.Kind)
+		if len(received) == 2 {
+			close(done)
+		}
+	})
+	defer funcSub.Close()
+
+	assert.NoError(t, feed.Publish(ctx, discogs.Event{Kind: discogs.EventOrderChanged}))
+	assert.NoError(t, feed.Publish(ctx, discogs.Event{Kind: discogs.EventProfileChanged}))
+
+	first := <-sub.Events()
+	assert.Equal(t, discogs.EventOrderChanged, first.Kind)
+	assert.False(t, first.Time.IsZero())
+	assert.Equal(t, discogs.EventProfileChanged, (<-sub.Events()).Kind)
+
+	<-done
+	mu.Lock()
+	assert.Equal(t, []discogs.EventKind{discogs.EventOrderChanged, discogs.EventProfileChanged}, received)
+	mu.Unlock()
+}
+
+func TestEventFeed_Backpressure(t *testing.T) {
+	feed := discogs.NewEventFeed()
+	sub := feed.Subscribe(1)
+
+	assert.NoError(t, feed.Publish(ctx, discogs.Event{Kind: discogs.EventPriceAlert}))
+
+	// The buffer is full, so publishing blocks until the context is canceled
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, feed.Publish(timeoutCtx, discogs.Event{Kind: discogs.EventPriceAlert}), context.DeadlineExceeded)
+
+	// Closing the subscription releases blocked publishers
+	published := make(chan error)
+	go func() {
+		published <- feed.Publish(ctx, discogs.Event{Kind: discogs.EventPriceAlert})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	sub.Close()
+	assert.NoError(t, <-published)
+
+	_, ok := <-sub.Events()
+	assert.True(t, ok, "buffered events are still delivered")
+	_, ok = <-sub.Events()
+	assert.False(t, ok)
+
+	var nilFeed *discogs.EventFeed
+	assert.NoError(t, nilFeed.Publish(ctx, discogs.Event{}))
+}
@@ -3,6 +3,7 @@ package discogs
 import (
 	"context"
 	"net/http"
+	"time"
 )
 
 func (dc *DiscogsClient) UpdateRateLimitFromHeader(res *http.Response) {
@@ -10,7 +11,7 @@ func (dc *DiscogsClient) UpdateRateLimitFromHeader(res *http.Response) {
 }
 
 func (dc *DiscogsClient) Wait(ctx context.Context) error {
-	return dc.rateLimiter.Wait(ctx)
+	return dc.hostLimiter().Wait(ctx)
 }
 
 func (dc *DiscogsClient) AddAuthHeaders(req *http.Request, authType AuthType) error {
@@ -20,3 +21,20 @@ func (dc *DiscogsClient) AddAuthHeaders(req *http.Request, authType AuthType) er
 var MatchRoute = matchRoute
 
 var IsMatch = isMatch
+
+func (dc *DiscogsClient) AdaptRateLimit(res *http.Response) {
+	dc.adaptRateLimit(res)
+}
+
+func (dc *DiscogsClient) PaceRateLimit(res *http.Response) {
+	dc.paceRateLimit(res)
+}
+
+var ParsePurchasePrice = parsePurchasePrice
+
+func (b *MemoryLimiterBackend) Sweep() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sweep(time.Now())
+	return len(b.full)
+}
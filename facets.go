@@ -0,0 +1,86 @@
+package discogs
+
+import "context"
+
+// Facet returns the facet with the given ID, such as FacetFormat, and whether the response contains it.
+func (r *MasterVersionsResponse) Facet(id string) (FilterFacet, bool) {
+	if r == nil {
+		return FilterFacet{}, false
+	}
+	for _, facet := range r.FilterFacets {
+		if facet.ID == id {
+			return facet, true
+		}
+	}
+	return FilterFacet{}, false
+}
+
+// WithFacet returns a copy of the options with the filter of the given facet set to value, so the versions can be
+// narrowed down by requesting them again with the returned options. The page is reset, since the filtered versions
+// have a different pagination. It returns an ErrInvalidOption if the facet is not known.
+//
+// Example:
+//
+//	facet, _ := res.Facet(discogs.FacetCountry)
+//	options, err := options.WithFacet(facet.ID, facet.Values[0].Value)
+func (o *MasterVersionsOptions) WithFacet(facetID, value string) (*MasterVersionsOptions, error) {
+	var options MasterVersionsOptions
+	if o != nil {
+		options = *o
+	}
+
+	field := options.facetField(facetID)
+	if field == nil {
+		return nil, &ErrInvalidOption{Option: "facet", Value: facetID}
+	}
+	*field = value
+	options.Page = nil
+
+	return &options, nil
+}
+
+// WithoutFacet returns a copy of the options with the filter of the given facet cleared. The page is reset, since the
+// versions have a different pagination. It returns an ErrInvalidOption if the facet is not known.
+func (o *MasterVersionsOptions) WithoutFacet(facetID string) (*MasterVersionsOptions, error) {
+	return o.WithFacet(facetID, "")
+}
+
+// MasterVersionsWithFacet re-issues a query of the versions of a master release with the filter of the given facet set
+// to value, see MasterVersionsOptions.WithFacet. It returns the first page of the narrowed versions along with the
+// options used, so the versions can be paged through or narrowed down further. It returns an ErrInvalidOption if the
+// facet is not known.
+//
+// Example:
+//
+//	res, err := client.MasterVersions(ctx, masterID, options)
+//	...
+//	facet, _ := res.Facet(discogs.FacetFormat)
+//	res, options, err = client.MasterVersionsWithFacet(ctx, masterID, options, facet.ID, facet.Values[0].Value)
+func (dc *DiscogsClient) MasterVersionsWithFacet(ctx context.Context, masterID int64, options *MasterVersionsOptions, facetID, value string) (*MasterVersionsResponse, *MasterVersionsOptions, error) {
+	narrowed, err := options.WithFacet(facetID, value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := dc.MasterVersions(ctx, masterID, narrowed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, narrowed, nil
+}
+
+// facetField returns a pointer to the field of the options holding the filter of the given facet, or nil if the facet
+// is not known.
+func (o *MasterVersionsOptions) facetField(facetID string) *string {
+	switch facetID {
+	case FacetFormat:
+		return &o.Format
+	case FacetLabel:
+		return &o.Label
+	case FacetCountry:
+		return &o.Country
+	case FacetReleased:
+		return &o.Released
+	}
+	return nil
+}
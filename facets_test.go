@@ -0,0 +1,75 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+const masterVersionsJSON = `{
+	"pagination": {"page": 2, "pages": 3, "items": 120, "per_page": 50},
+	"filters": {
+		"applied": {"format": [{"title": "Vinyl", "value": "Vinyl", "count": 80}]},
+		"available": {"country": {"UK": 30, "US": 50}}
+	},
+	"filter_facets": [
+		{"title": "Format", "id": "format", "values": [{"title": "Vinyl", "value": "Vinyl", "count": 80}], "allows_multiple_values": true},
+		{"title": "Country", "id": "country", "values": [{"title": "UK", "value": "UK", "count": 30}, {"title": "US", "value": "US", "count": 50}], "allows_multiple_values": false}
+	],
+	"versions": [{"id": 1, "title": "Album", "country": "UK", "major_formats": ["Vinyl"], "stats": {"community": {"in_collection": 10, "in_wantlist": 5}}}]
+}`
+
+func TestMasterVersionsFacets(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/masters/1/versions", req.URL.Path)
+		queries = append(queries, req.URL.RawQuery)
+		_, _ = rw.Write([]byte(masterVersionsJSON))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	options := &discogs.MasterVersionsOptions{PaginationParams: discogs.PaginationParams{Page: discogs.Int(2)}, Format: "Vinyl"}
+	res, err := client.MasterVersions(ctx, 1, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []discogs.FacetValue{{Title: "Vinyl", Value: "Vinyl", Count: 80}}, res.Filters.Applied[discogs.FacetFormat])
+	assert.Equal(t, int64(50), res.Filters.Available[discogs.FacetCountry]["US"])
+	assert.Equal(t, int64(10), res.Versions[0].GetStats().GetCommunity().GetInCollection())
+
+	facet, ok := res.Facet(discogs.FacetCountry)
+	assert.True(t, ok)
+	assert.False(t, facet.AllowsMultipleValues)
+	_, ok = res.Facet("genre")
+	assert.False(t, ok)
+
+	// Applying a facet keeps the other filters and starts over at the first page
+	refined, err := options.WithFacet(facet.ID, facet.Values[1].Value)
+	assert.NoError(t, err)
+	assert.Equal(t, &discogs.MasterVersionsOptions{Format: "Vinyl", Country: "US"}, refined)
+	assert.Equal(t, 2, *options.Page)
+
+	_, err = client.MasterVersions(ctx, 1, refined)
+	assert.NoError(t, err)
+
+	cleared, err := refined.WithoutFacet(discogs.FacetFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, &discogs.MasterVersionsOptions{Country: "US"}, cleared)
+
+	_, err = options.WithFacet("genre", "Rock")
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "facet", Value: "genre"}, err)
+
+	res, narrowed, err := client.MasterVersionsWithFacet(ctx, 1, cleared, discogs.FacetReleased, "1991")
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+	assert.Equal(t, &discogs.MasterVersionsOptions{Country: "US", Released: "1991"}, narrowed)
+
+	_, _, err = client.MasterVersionsWithFacet(ctx, 1, cleared, "genre", "Rock")
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "facet", Value: "genre"}, err)
+
+	assert.Equal(t, []string{"format=Vinyl&page=2", "country=US&format=Vinyl", "country=US&released=1991"}, queries)
+}
@@ -0,0 +1,149 @@
+package discogs
+
+import (
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Fault represents a failure injected by a FaultInjector into matching requests. A fault can combine a latency with
+// one of its failures: a connection reset, a status code or a malformed body.
+type Fault struct {
+	// Route is the route pattern of the requests the fault applies to, such as "/releases/{release_id}". The fault
+	// applies to all requests if unset.
+	Route string
+	// Method is the HTTP method of the requests the fault applies to. The fault applies to all methods if unset.
+	Method string
+	// Probability is the fraction of matching requests the fault is injected into, from 0 to 1. The fault is injected
+	// into every matching request if 0.
+	Probability float64
+
+	// Latency delays the matching requests.
+	Latency time.Duration
+	// ResetConnection fails the matching requests with a connection reset error, without sending them.
+	ResetConnection bool
+	// StatusCode answers the matching requests with a response of this status code and Body, without sending them.
+	StatusCode int
+	// Body is the body of the responses answered with StatusCode.
+	Body string
+	// MalformedBody sends the matching requests and truncates the body of their responses, so it cannot be decoded.
+	MalformedBody bool
+}
+
+// matches reports whether the fault applies to req.
+func (f *Fault) matches(req *http.Request) bool {
+	if f.Method != "" && f.Method != req.Method {
+		return false
+	}
+	return f.Route == "" || isMatch(f.Route, req.URL.Path)
+}
+
+// A FaultInjector is an http.RoundTripper injecting faults into the requests it forwards to Transport, to test how an
+// application handles failures of the Discogs API. It can wrap the real transport or the transport of a mock server.
+// For every request, the first matching fault is injected, if any.
+//
+// Example:
+//
+//	client.Client.Transport = &discogs.FaultInjector{
+//		Faults: []discogs.Fault{
+//			{Route: "/database/search", StatusCode: http.StatusTooManyRequests, Probability: 0.2},
+//			{Latency: 500 * time.Millisecond},
+//		},
+//	}
+type FaultInjector struct {
+	// Transport sends the requests that are not answered by a fault. http.DefaultTransport is used if unset.
+	Transport http.RoundTripper
+	Faults    []Fault
+
+	mu       sync.Mutex
+	injected int
+}
+
+// Injected returns the number of requests a fault was injected into.
+func (fi *FaultInjector) Injected() int {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.injected
+}
+
+// RoundTrip injects the first matching fault into the request, and forwards it to Transport unless the fault
+// answers it.
+func (fi *FaultInjector) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := fi.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	fault := fi.fault(req)
+	if fault == nil {
+		return transport.RoundTrip(req)
+	}
+
+	if fault.Latency > 0 {
+		timer := time.NewTimer(fault.Latency)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	switch {
+	case fault.ResetConnection:
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	case fault.StatusCode != 0:
+		return &http.Response{
+			Status:        http.StatusText(fault.StatusCode),
+			StatusCode:    fault.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": {"application/json"}},
+			Body:          io.NopCloser(bytes.NewBufferString(fault.Body)),
+			ContentLength: int64(len(fault.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil || !fault.MalformedBody {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	body = body[:len(body)/2]
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+// fault returns the first fault matching req that is drawn to be injected, or nil if none is.
+func (fi *FaultInjector) fault(req *http.Request) *Fault {
+	for i := range fi.Faults {
+		fault := &fi.Faults[i]
+		if !fault.matches(req) {
+			continue
+		}
+		if fault.Probability > 0 && rand.Float64() >= fault.Probability {
+			continue
+		}
+
+		fi.mu.Lock()
+		fi.injected++
+		fi.mu.Unlock()
+		return fault
+	}
+	return nil
+}
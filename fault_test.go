@@ -0,0 +1,80 @@
+package discogs_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultInjector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"title":"Release"}`))
+	}))
+	defer server.Close()
+
+	injector := &discogs.FaultInjector{
+		Faults: []discogs.Fault{
+			{Route: "/releases/{release_id}", Method: http.MethodPost, StatusCode: http.StatusInternalServerError},
+			{Route: "/releases/1", StatusCode: http.StatusTooManyRequests, Body: `{"message":"You are making requests too quickly."}`},
+			{Route: "/releases/2", ResetConnection: true},
+			{Route: "/releases/3", MalformedBody: true, Latency: 20 * time.Millisecond},
+			{Route: "/releases/4", Probability: 0.000001, ResetConnection: true},
+		},
+	}
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+	client.Client.Transport = injector
+
+	tests := []struct {
+		name     string
+		endpoint string
+		check    func(t *testing.T, err error)
+	}{
+		{
+			name:     "status code",
+			endpoint: "/releases/1",
+			check: func(t *testing.T, err error) {
+				assert.Equal(t, &discogs.ErrRateLimited{
+					Err: &discogs.HTTPError{StatusCode: http.StatusTooManyRequests, Message: `{"message":"You are making requests too quickly."}`},
+				}, err)
+			},
+		},
+		{
+			name:     "connection reset",
+			endpoint: "/releases/2",
+			check: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, syscall.ECONNRESET))
+			},
+		},
+		{
+			name:     "malformed body",
+			endpoint: "/releases/3",
+			check: func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "failed to unmarshal response body")
+			},
+		},
+		{
+			name:     "unlikely fault",
+			endpoint: "/releases/4",
+			check: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var res discogs.ReleaseResponse
+			tt.check(t, client.Get(ctx, tt.endpoint, nil, nil, &res))
+		})
+	}
+
+	assert.Equal(t, 3, injector.Injected())
+}
@@ -0,0 +1,186 @@
+//go:build ignore
+
+// gen-accessors generates nil-safe accessor methods for pointer fields of the exported struct types in this package.
+//
+// It is meant to be used by the go generate command in the discogs package:
+//
+//	go generate ./...
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const fileName = "discogs-accessors.go"
+
+// accessor describes a single generated accessor method.
+type accessor struct {
+	ReceiverType string
+	ReceiverName string
+	FieldName    string
+	FieldType    string
+	ZeroValue    string
+	// Pointer is true if the accessor returns the pointer field itself rather than its dereferenced value.
+	Pointer bool
+}
+
+var tmpl = template.Must(template.New("accessors").Parse(`// Code generated by gen-accessors; DO NOT EDIT.
+// Instead, please run "go generate ./..." as described in gen-accessors.go.
+
+package discogs
+{{range .}}
+{{- if .Pointer}}
+// Get{{.FieldName}} returns the {{.FieldName}} field.
+func ({{.ReceiverName}} *{{.ReceiverType}}) Get{{.FieldName}}() *{{.FieldType}} {
+	if {{.ReceiverName}} == nil {
+		return nil
+	}
+	return {{.ReceiverName}}.{{.FieldName}}
+}
+{{else}}
+// Get{{.FieldName}} returns the {{.FieldName}} field if it's non-nil, zero value otherwise.
+func ({{.ReceiverName}} *{{.ReceiverType}}) Get{{.FieldName}}() {{.FieldType}} {
+	if {{.ReceiverName}} == nil || {{.ReceiverName}}.{{.FieldName}} == nil {
+		return {{.ZeroValue}}
+	}
+	return *{{.ReceiverName}}.{{.FieldName}}
+}
+{{end}}
+{{- end}}`))
+
+// basicZeroValues maps the basic types used by response fields to their zero values.
+var basicZeroValues = map[string]string{
+	"string":  `""`,
+	"int":     "0",
+	"int64":   "0",
+	"float64": "0",
+	"bool":    "false",
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", sourceFilter, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pkg, ok := pkgs["discogs"]
+	if !ok {
+		log.Fatal("discogs package not found")
+	}
+
+	// Collect the underlying kind of every named type so pointer fields of named types can be classified.
+	underlying := make(map[string]ast.Expr)
+	var structs []*ast.TypeSpec
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				underlying[ts.Name.Name] = ts.Type
+				if _, ok := ts.Type.(*ast.StructType); ok && ts.Name.IsExported() && ts.TypeParams == nil {
+					structs = append(structs, ts)
+				}
+			}
+		}
+	}
+
+	var accessors []accessor
+	for _, ts := range structs {
+		for _, field := range ts.Type.(*ast.StructType).Fields.List {
+			star, ok := field.Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			ident, ok := star.X.(*ast.Ident)
+			if !ok {
+				continue
+			}
+
+			for _, name := range field.Names {
+				if !name.IsExported() {
+					continue
+				}
+
+				a := accessor{
+					ReceiverType: ts.Name.Name,
+					ReceiverName: strings.ToLower(ts.Name.Name[:1]),
+					FieldName:    name.Name,
+					FieldType:    ident.Name,
+				}
+
+				if zero, ok := basicZeroValues[ident.Name]; ok {
+					a.ZeroValue = zero
+				} else {
+					switch t := underlying[ident.Name].(type) {
+					case *ast.Ident:
+						zero, ok := basicZeroValues[t.Name]
+						if !ok {
+							continue
+						}
+						a.ZeroValue = zero
+					case *ast.StructType:
+						if isValueStruct(ident.Name) {
+							a.ZeroValue = ident.Name + "{}"
+						} else {
+							a.Pointer = true
+						}
+					default:
+						continue
+					}
+				}
+
+				accessors = append(accessors, a)
+			}
+		}
+	}
+
+	sort.Slice(accessors, func(i, j int) bool {
+		if accessors[i].ReceiverType != accessors[j].ReceiverType {
+			return accessors[i].ReceiverType < accessors[j].ReceiverType
+		}
+		return accessors[i].FieldName < accessors[j].FieldName
+	})
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, accessors); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(fmt.Errorf("formatting generated source: %w\n%s", err, buf.Bytes()))
+	}
+
+	if err := os.WriteFile(fileName, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// sourceFilter excludes test files and the generated file itself from parsing.
+func sourceFilter(fi os.FileInfo) bool {
+	return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != fileName
+}
+
+// isValueStruct reports whether pointers to the named struct type should be dereferenced by their accessors. These
+// are small value types, such as Timestamp, that are more convenient to handle by value.
+func isValueStruct(name string) bool {
+	switch name {
+	case "Timestamp":
+		return true
+	}
+	return false
+}
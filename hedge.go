@@ -0,0 +1,63 @@
+package discogs
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// hedgeResult holds the outcome of a single attempt of a hedged request.
+type hedgeResult struct {
+	response *http.Response
+	body     []byte
+	err      error
+}
+
+// sendHedged sends an HTTP request and, if it has not completed after delay, sends a second identical request,
+// returning whichever successful response arrives first. The remaining attempt is canceled.
+//
+// Both attempts wait on the rate limiter, so hedging never exceeds the rate limit. To avoid delaying other requests,
+// the second attempt is only sent if the rate limiter allows a request immediately. An error is only returned once
+// every attempt has failed, in which case the error of the first failed attempt is returned.
+func (dc *DiscogsClient) sendHedged(ctx context.Context, req *http.Request, delay time.Duration) (*http.Response, []byte, error) {
+	// The attempts keep the values of the request's context, such as its OAuth signer, and stop along with ctx
+	attemptCtx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	results := make(chan hedgeResult, 2)
+	attempt := func(r *http.Request) {
+		response, body, err := dc.send(attemptCtx, r)
+		results <- hedgeResult{response, body, err}
+	}
+
+	go attempt(req.Clone(attemptCtx))
+	inFlight := 1
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var first *hedgeResult
+	for {
+		select {
+		case <-timer.C:
+			if limiterTokens(dc.limiterFor(req.URL)) >= 1 {
+				dc.stats.recordRetry(req)
+				go attempt(req.Clone(attemptCtx))
+				inFlight++
+			}
+		case res := <-results:
+			inFlight--
+			if res.err == nil {
+				return res.response, res.body, nil
+			}
+			if first == nil {
+				first = &res
+			}
+			if inFlight == 0 {
+				return first.response, nil, first.err
+			}
+		}
+	}
+}
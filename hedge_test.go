@@ -0,0 +1,90 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_HedgedGet(t *testing.T) {
+	type want struct {
+		requests int64
+		err      bool
+	}
+	tests := []struct {
+		name string
+		// handle responds to the nth request received by the mock server.
+		handle func(n int64, rw http.ResponseWriter, req *http.Request)
+		want   want
+	}{
+		{
+			"HedgedGet fast response is not hedged",
+			func(n int64, rw http.ResponseWriter, req *http.Request) {
+				_, _ = rw.Write([]byte(`{"success":true}`))
+			},
+			want{1, false},
+		},
+		{
+			"HedgedGet slow response is hedged",
+			func(n int64, rw http.ResponseWriter, req *http.Request) {
+				if n == 1 {
+					select {
+					case <-req.Context().Done():
+						return
+					case <-time.After(time.Second):
+					}
+				}
+				_, _ = rw.Write([]byte(`{"success":true}`))
+			},
+			want{2, false},
+		},
+		{
+			"HedgedGet slow response with failed hedge",
+			func(n int64, rw http.ResponseWriter, req *http.Request) {
+				if n == 2 {
+					rw.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				time.Sleep(100 * time.Millisecond)
+				_, _ = rw.Write([]byte(`{"success":true}`))
+			},
+			want{2, false},
+		},
+		{
+			"HedgedGet all attempts failed",
+			func(n int64, rw http.ResponseWriter, req *http.Request) {
+				time.Sleep(50 * time.Millisecond)
+				rw.WriteHeader(http.StatusInternalServerError)
+			},
+			want{2, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				tt.handle(requests.Add(1), rw, req)
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{HedgeDelay: 20 * time.Millisecond})
+			client.Host = server.URL
+			var res TestClientResponse
+
+			err := client.Get(ctx, "/test", nil, nil, &res)
+
+			if tt.want.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, res.Success)
+			}
+			assert.Equal(t, tt.want.requests, requests.Load())
+		})
+	}
+}
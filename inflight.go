@@ -0,0 +1,43 @@
+package discogs
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// acquireInFlight waits until fewer than MaxInFlight requests are in flight, or returns an error if ctx is done first.
+// The returned function releases the slot of the request once its response was read.
+func (dc *DiscogsClient) acquireInFlight(ctx context.Context) (release func(), err error) {
+	if dc.Config.MaxInFlight <= 0 {
+		return func() {}, nil
+	}
+
+	dc.mu.Lock()
+	if dc.inFlight == nil {
+		dc.inFlight = make(chan struct{}, dc.Config.MaxInFlight)
+	}
+	inFlight := dc.inFlight
+	dc.mu.Unlock()
+
+	select {
+	case inFlight <- struct{}{}:
+		return func() { <-inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// releasingBody is a response body releasing the in-flight slot of its request when closed, since a streamed response
+// is still in flight until the caller has read it.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,62 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_MaxInFlight(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxInFlight int
+		want        int64
+	}{
+		{name: "limited", maxInFlight: 2, want: 2},
+		{name: "unlimited", maxInFlight: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var current, peak atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				n := current.Add(1)
+				defer current.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				_, _ = rw.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{MaxRequests: 1000, MaxInFlight: tt.maxInFlight})
+			client.Host = server.URL
+
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					assert.NoError(t, client.Get(ctx, "/releases/1", nil, nil, nil))
+				}()
+			}
+			wg.Wait()
+
+			if tt.maxInFlight > 0 {
+				assert.Equal(t, tt.want, peak.Load())
+			} else {
+				assert.Greater(t, peak.Load(), int64(2))
+			}
+		})
+	}
+}
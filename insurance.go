@@ -0,0 +1,358 @@
+package discogs
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Default custom field names and condition used by InsuranceReport.
+const (
+	DefaultPurchasePriceField           = "Purchase Price"
+	DefaultConditionField               = "Media Condition"
+	DefaultReportCondition    Condition = ConditionVeryGoodPlus
+)
+
+// InsuranceReportOptions represents the options for generating an insurance report.
+type InsuranceReportOptions struct {
+	// FolderID is the collection folder the report covers. FolderAll is used if unset.
+	FolderID int64
+	// PurchasePriceField is the name of the custom field holding the purchase price of the items.
+	// DefaultPurchasePriceField is used if unset.
+	PurchasePriceField string
+	// ConditionField is the name of the custom field holding the media condition of the items.
+	// DefaultConditionField is used if unset.
+	ConditionField string
+	// DefaultCondition is the condition used to value items without a condition. DefaultReportCondition is used if
+	// unset.
+	DefaultCondition Condition
+	// Currency is the currency of the purchase prices, which are left out of the report if they name another currency,
+	// since it has no exchange rates. The currency of the price suggestions is used if unset, or else the first
+	// currency named by a purchase price.
+	Currency Currency
+}
+
+// InsuranceReport represents the valuation of a collection folder, for insurance documentation.
+type InsuranceReport struct {
+	Username    string                `json:"username"`
+	FolderID    int64                 `json:"folder_id"`
+	GeneratedAt time.Time             `json:"generated_at"`
+	Items       []InsuranceReportItem `json:"items"`
+	// TotalPurchasePrice is the sum of the purchase prices of the items that have one, in PurchaseCurrency.
+	TotalPurchasePrice Amount `json:"total_purchase_price"`
+	// PurchaseCurrency is the currency of the purchase prices, or empty if none of them names one.
+	PurchaseCurrency Currency `json:"purchase_currency,omitempty"`
+	// TotalCurrentValue is the sum of the current values of the items that have one, in Currency.
+	TotalCurrentValue Amount   `json:"total_current_value"`
+	Currency          Currency `json:"currency,omitempty"`
+}
+
+// InsuranceReportItem represents the valuation of a single collection item.
+type InsuranceReportItem struct {
+	ReleaseID  int64     `json:"release_id"`
+	InstanceID int64     `json:"instance_id"`
+	Artist     string    `json:"artist"`
+	Title      string    `json:"title"`
+	Label      string    `json:"label"`
+	CatNo      string    `json:"catno"`
+	Format     string    `json:"format"`
+	Year       int64     `json:"year,omitempty"`
+	Condition  Condition `json:"condition"`
+	// PurchasePrice is the price the item was bought at in the PurchaseCurrency of the report, or nil if unknown,
+	// ambiguous, such as "1,200" when the currency is not known, or in another currency. The raw value is kept in
+	// Fields.
+	PurchasePrice *Amount `json:"purchase_price,omitempty"`
+	// CurrentValue is the suggested price of the item in its condition, or nil if Discogs has no suggestion.
+	CurrentValue *Price `json:"current_value,omitempty"`
+	// Fields holds the values of the custom fields of the item, keyed by field name.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// InsuranceReport values every item in a folder of a user's collection. It combines the collection items, their
+// custom fields and the price suggestions for their condition into a report that can be written as CSV or JSON. The
+// purchase price and condition of the items are read from custom fields. If options is nil, the default options are
+// used.
+//
+// Price suggestions require OAuth or a personal access token, and are in the currency of the authenticated user's
+// seller settings. One request is made per distinct release, so large collections take a while to value.
+func (dc *DiscogsClient) InsuranceReport(ctx context.Context, username string, options *InsuranceReportOptions) (*InsuranceReport, error) {
+	var opts InsuranceReportOptions
+	if options != nil {
+		opts = *options
+	}
+	if opts.PurchasePriceField == "" {
+		opts.PurchasePriceField = DefaultPurchasePriceField
+	}
+	if opts.ConditionField == "" {
+		opts.ConditionField = DefaultConditionField
+	}
+	if opts.DefaultCondition == "" {
+		opts.DefaultCondition = DefaultReportCondition
+	}
+
+	fields, err := dc.CollectionFields(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	fieldNames := make(map[int64]string, len(fields.Fields))
+	for _, field := range fields.Fields {
+		fieldNames[field.ID] = field.Name
+	}
+
+	report := &InsuranceReport{Username: username, FolderID: opts.FolderID, GeneratedAt: time.Now()}
+	suggestions := make(map[int64]PriceSuggestionsResponse)
+
+	err = ForEachPage(ctx, dc.CollectionItemsPages(username, opts.FolderID, nil), &IteratorOptions{PerPage: DefaultCrawlPerPage}, func(items []CollectionItem, _ *Pagination) error {
+		for _, item := range items {
+			reportItem := newInsuranceReportItem(&item, fieldNames)
+
+			// Conditions entered by hand, such as "VG+", are normalized; unknown ones are kept as is and not valued
+			reportItem.Condition = opts.DefaultCondition
+			if value := reportItem.Fields[opts.ConditionField]; value != "" {
+				condition, err := ParseCondition(value)
+				if err != nil {
+					condition = Condition(value)
+				}
+				reportItem.Condition = condition
+			}
+			suggestion, ok := suggestions[item.ID]
+			if !ok {
+				var err error
+				suggestion, err = dc.PriceSuggestions(ctx, item.ID)
+				if err != nil && !IsNotFound(err) {
+					return err
+				}
+				suggestions[item.ID] = suggestion
+			}
+			if value, ok := suggestion[reportItem.Condition]; ok {
+				reportItem.CurrentValue = &value
+				report.TotalCurrentValue += value.Value
+				report.Currency = value.Currency
+			}
+
+			report.Items = append(report.Items, reportItem)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The purchase prices are only added up once their currency is known, so the total never mixes currencies
+	report.PurchaseCurrency = cmp.Or(opts.Currency, report.Currency)
+	if report.PurchaseCurrency == "" {
+		for _, item := range report.Items {
+			if _, named, err := parsePurchasePrice(item.Fields[opts.PurchasePriceField], ""); err == nil && named != "" {
+				report.PurchaseCurrency = named
+				break
+			}
+		}
+	}
+	for i := range report.Items {
+		item := &report.Items[i]
+		value := item.Fields[opts.PurchasePriceField]
+		if value == "" {
+			continue
+		}
+		price, named, err := parsePurchasePrice(value, report.PurchaseCurrency)
+		if err == nil && (named == "" || named == report.PurchaseCurrency) {
+			item.PurchasePrice = &price
+			report.TotalPurchasePrice += price
+		}
+	}
+
+	return report, nil
+}
+
+// newInsuranceReportItem creates a report item from a collection item, naming its custom fields using fieldNames.
+func newInsuranceReportItem(item *CollectionItem, fieldNames map[int64]string) InsuranceReportItem {
+	reportItem := InsuranceReportItem{ReleaseID: item.ID, InstanceID: item.InstanceID}
+
+	for _, note := range item.Notes {
+		if name, ok := fieldNames[note.FieldID]; ok {
+			if reportItem.Fields == nil {
+				reportItem.Fields = make(map[string]string, len(item.Notes))
+			}
+			reportItem.Fields[name] = note.Value
+		}
+	}
+
+	if info := item.BasicInformation; info != nil {
+		reportItem.Title = info.Title
+		reportItem.Year = info.GetYear()
+
+		artists := make([]string, len(info.Artists))
+		for i, artist := range info.Artists {
+			artists[i] = artist.Name
+		}
+		reportItem.Artist = strings.Join(artists, ", ")
+
+		if len(info.Labels) > 0 {
+			reportItem.Label = info.Labels[0].Name
+			reportItem.CatNo = info.Labels[0].CatNo
+		}
+		if len(info.Formats) > 0 {
+			reportItem.Format = info.Formats[0].Name
+		}
+	}
+
+	return reportItem
+}
+
+// purchaseSymbols maps the currency symbols recognized in purchase prices to their currency, longest first so "CA$" is
+// not read as "$".
+var purchaseSymbols = []struct {
+	symbol   string
+	currency Currency
+}{
+	{"CA$", CurrencyCAD}, {"A$", CurrencyAUD}, {"MX$", CurrencyMXN}, {"NZ$", CurrencyNZD}, {"R$", CurrencyBRL},
+	{"£", CurrencyGBP}, {"€", CurrencyEUR}, {"¥", CurrencyJPY}, {"$", CurrencyUSD},
+}
+
+// parsePurchasePrice parses a purchase price entered by hand, such as "€12.50", "1,200" or "1.234,56 EUR", and
+// returns it along with the currency it names, which is empty if it names none. The currency named by s, or fallback
+// otherwise, tells the separators apart: "," or "." followed by three digits is a thousands separator in a currency
+// with two decimal places, and any separator is one in a currency without decimals. If neither currency is known,
+// such a separator is ambiguous. It returns an ErrInvalidAmount if s holds no amount or an ambiguous one.
+func parsePurchasePrice(s string, fallback Currency) (Amount, Currency, error) {
+	invalid := &ErrInvalidAmount{Amount: s}
+
+	// Currency codes are only recognized as whole words, so "AUD" is not found in "AUDIO"
+	var named Currency
+	words := strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool { return !unicode.IsLetter(r) })
+	for _, word := range words {
+		if currency := Currency(word); currency.IsValid() {
+			named = currency
+			break
+		}
+	}
+	for _, symbol := range purchaseSymbols {
+		if named == "" && strings.Contains(s, symbol.symbol) {
+			named = symbol.currency
+			// A bare "$" is the dollar of the fallback currency, if it has one
+			if symbol.symbol == "$" && strings.HasSuffix(currencies[fallback].symbol, "$") {
+				named = fallback
+			}
+		}
+	}
+
+	decimals := -1
+	if currency := cmp.Or(named, fallback); currency.IsValid() {
+		decimals = currency.Decimals()
+	}
+
+	// Keep the digits, the separators and the sign; spaces and apostrophes are also used as thousands separators
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' || r == '.' || r == ',' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	number := strings.Trim(b.String(), ".,")
+	if number == "" {
+		return 0, "", invalid
+	}
+
+	var decimal, thousands string
+	lastDot, lastComma := strings.LastIndex(number, "."), strings.LastIndex(number, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		decimal, thousands = ".", ","
+		if lastComma > lastDot {
+			decimal, thousands = ",", "."
+		}
+	case lastDot >= 0 || lastComma >= 0:
+		separator := "."
+		if lastComma >= 0 {
+			separator = ","
+		}
+		after := len(number) - strings.LastIndex(number, separator) - 1
+		switch {
+		case strings.Count(number, separator) > 1 || decimals == 0:
+			thousands = separator
+		case after == 3 && decimals == 2:
+			thousands = separator
+		case after < 3:
+			decimal = separator
+		default:
+			return 0, "", invalid
+		}
+	}
+
+	units, fraction := number, ""
+	if decimal != "" {
+		units, fraction, _ = strings.Cut(number, decimal)
+		if len(fraction) > max(decimals, 2) || strings.Contains(fraction, thousands) && thousands != "" {
+			return 0, "", invalid
+		}
+	}
+	if thousands != "" {
+		groups := strings.Split(strings.TrimPrefix(units, "-"), thousands)
+		for i, group := range groups {
+			if len(group) > 3 || len(group) == 0 || i > 0 && len(group) != 3 {
+				return 0, "", invalid
+			}
+		}
+		units = strings.ReplaceAll(units, thousands, "")
+	}
+
+	amount, err := ParseAmount(units + "." + fraction)
+	if err != nil {
+		return 0, "", invalid
+	}
+	return amount, named, nil
+}
+
+// insuranceReportHeader is the header row of an insurance report written as CSV.
+var insuranceReportHeader = []string{
+	"release_id", "instance_id", "artist", "title", "label", "catno", "format", "year", "condition",
+	"purchase_price", "purchase_currency", "current_value", "currency",
+}
+
+// WriteCSV writes the items of the report to w as CSV, with a header row. Unknown prices are left empty.
+func (r *InsuranceReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(insuranceReportHeader); err != nil {
+		return err
+	}
+
+	for _, item := range r.Items {
+		var year, purchasePrice, purchaseCurrency, currentValue, currency string
+		if item.Year != 0 {
+			year = strconv.FormatInt(item.Year, 10)
+		}
+		if item.PurchasePrice != nil {
+			purchasePrice = item.PurchasePrice.String()
+			purchaseCurrency = string(r.PurchaseCurrency)
+		}
+		if item.CurrentValue != nil {
+			currentValue = item.CurrentValue.Value.format(item.CurrentValue.Currency.Decimals())
+			currency = string(item.CurrentValue.Currency)
+		}
+
+		err := cw.Write([]string{
+			strconv.FormatInt(item.ReleaseID, 10), strconv.FormatInt(item.InstanceID, 10), item.Artist, item.Title,
+			item.Label, item.CatNo, item.Format, year, string(item.Condition), purchasePrice, purchaseCurrency,
+			currentValue, currency,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the report to w as indented JSON.
+func (r *InsuranceReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,125 @@
+package discogs_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_InsuranceReport(t *testing.T) {
+	token := "token"
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var res interface{}
+		switch req.URL.Path {
+		case "/users/collector/collection/fields":
+			res = discogs.CollectionFieldsResponse{Fields: []discogs.CollectionField{
+				{ID: 1, Name: "Media Condition", Type: "dropdown"},
+				{ID: 3, Name: "Purchase Price", Type: "textarea"},
+			}}
+		case "/users/collector/collection/folders/0/releases":
+			res = discogs.CollectionItemsResponse{
+				Pagination: &discogs.Pagination{Page: 1, Pages: 1},
+				Releases: []discogs.CollectionItem{
+					// Valued before any price suggestion tells the currency of the report
+					{ID: 30, InstanceID: 300, Notes: []discogs.FieldValue{{FieldID: 3, Value: "$8"}}},
+					{
+						ID: 10, InstanceID: 100,
+						BasicInformation: &discogs.BasicInformation{
+							Title:   "Album",
+							Year:    discogs.Int64(1990),
+							Artists: []discogs.ArtistCredit{{Name: "Artist"}},
+							Labels:  []discogs.LabelCredit{{Name: "Label", CatNo: "LBL 1"}},
+							Formats: []discogs.Format{{Name: "Vinyl"}},
+						},
+						Notes: []discogs.FieldValue{{FieldID: 1, Value: "Mint (M)"}, {FieldID: 3, Value: "€12,50"}},
+					},
+					{ID: 20, InstanceID: 200},
+				},
+			}
+		case "/marketplace/price_suggestions/10":
+			res = discogs.PriceSuggestionsResponse{"Mint (M)": {Currency: discogs.CurrencyEUR, Value: 4000}}
+		case "/marketplace/price_suggestions/20":
+			res = discogs.PriceSuggestionsResponse{"Very Good Plus (VG+)": {Currency: discogs.CurrencyEUR, Value: 1550}}
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"message":"Release not found."}`))
+			return
+		}
+		_ = json.NewEncoder(rw).Encode(res)
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	report, err := client.InsuranceReport(ctx, "collector", nil)
+	assert.NoError(t, err)
+	assert.Len(t, report.Items, 3)
+	assert.Equal(t, discogs.Amount(1250), report.TotalPurchasePrice, "prices in another currency must be left out")
+	assert.Equal(t, discogs.CurrencyEUR, report.PurchaseCurrency)
+	assert.Equal(t, discogs.Amount(5550), report.TotalCurrentValue)
+	assert.Equal(t, discogs.CurrencyEUR, report.Currency)
+	assert.Nil(t, report.Items[0].CurrentValue)
+	assert.Nil(t, report.Items[0].PurchasePrice)
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&buf))
+	assert.Equal(t, strings.Join([]string{
+		"release_id,instance_id,artist,title,label,catno,format,year,condition,purchase_price,purchase_currency,current_value,currency",
+		"30,300,,,,,,,Very Good Plus (VG+),,,,",
+		"10,100,Artist,Album,Label,LBL 1,Vinyl,1990,Mint (M),12.50,EUR,40.00,EUR",
+		"20,200,,,,,,,Very Good Plus (VG+),,,15.50,EUR",
+		"",
+	}, "\n"), buf.String())
+
+	buf.Reset()
+	assert.NoError(t, report.WriteJSON(&buf))
+	var decoded discogs.InsuranceReport
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report.Items, decoded.Items)
+}
+
+func TestParsePurchasePrice(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		fallback     discogs.Currency
+		want         discogs.Amount
+		wantCurrency discogs.Currency
+		wantErr      bool
+	}{
+		{"decimal comma", "€12,50", "", 1250, discogs.CurrencyEUR, false},
+		{"decimal point", "$12.50", "", 1250, discogs.CurrencyUSD, false},
+		{"thousands comma", "1,200", discogs.CurrencyEUR, 120000, "", false},
+		{"thousands point", "1.200 EUR", "", 120000, discogs.CurrencyEUR, false},
+		{"both separators", "1.234,56", discogs.CurrencyEUR, 123456, "", false},
+		{"both separators swapped", "1,234.56 USD", "", 123456, discogs.CurrencyUSD, false},
+		{"several thousands", "1,234,567", discogs.CurrencyUSD, 123456700, "", false},
+		{"no decimals", "¥1,200", "", 120000, discogs.CurrencyJPY, false},
+		{"dollar of the fallback", "CA$5", "", 500, discogs.CurrencyCAD, false},
+		{"bare dollar", "$5", discogs.CurrencyAUD, 500, discogs.CurrencyAUD, false},
+		{"code in a word", "12.50 audio", "", 1250, "", false},
+		{"ambiguous", "1,200", "", 0, "", true},
+		{"bad grouping", "12,00,0", discogs.CurrencyEUR, 0, "", true},
+		{"empty", "n/a", discogs.CurrencyEUR, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, currency, err := discogs.ParsePurchasePrice(tt.value, tt.fallback)
+			if tt.wantErr {
+				assert.IsType(t, &discogs.ErrInvalidAmount{}, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCurrency, currency)
+		})
+	}
+}
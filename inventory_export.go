@@ -0,0 +1,132 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/google/go-querystring/query"
+)
+
+// ErrNoLocation indicates that a request creating a resource succeeded, but its response did not locate the created
+// resource.
+var ErrNoLocation = errors.New("response has no location of the created resource")
+
+// createdResponse holds the location of a resource created by a request whose response has no body.
+type createdResponse struct {
+	location string
+}
+
+// setHeader reads the location of the created resource from the headers of the response.
+func (r *createdResponse) setHeader(header http.Header) {
+	r.location = header.Get("Location")
+}
+
+// id returns the ID of the created resource, which is the last segment of its location.
+func (r *createdResponse) id() (int64, error) {
+	if r.location == "" {
+		return 0, ErrNoLocation
+	}
+	id, err := strconv.ParseInt(path.Base(r.location), 10, 64)
+	if err != nil {
+		return 0, ErrNoLocation
+	}
+	return id, nil
+}
+
+// CreateInventoryExport requests an export of the authenticated seller's inventory by sending a POST request to the
+// /inventory/export endpoint. The export is generated in the background; its status can be followed with its ID. The
+// endpoint responds without a body, so the ID is read from the Location header of the response. The context.Context
+// provides control over the request's lifecycle. It returns the ID of the export, or an error if the request fails or
+// the response does not locate the export.
+//
+// Documentation: https://www.discogs.com/developers#page:inventory-export,header:inventory-export-export-your-inventory
+func (dc *DiscogsClient) CreateInventoryExport(ctx context.Context, opts ...RequestOption) (int64, error) {
+	endpoint := "/inventory/export"
+	var res createdResponse
+
+	if err := dc.Post(WithRequestOptions(ctx, opts...), endpoint, nil, nil, nil, &res); err != nil {
+		return 0, err
+	}
+
+	return res.id()
+}
+
+// InventoryExports fetches a page of the inventory exports of the authenticated seller, most recent first, by sending
+// a GET request to the /inventory/export endpoint. The options parameter allows for pagination. The context.Context
+// provides control over the request's lifecycle. It returns a pointer to an InventoryExportsResponse struct
+// containing the exports, or an error if the request fails.
+//
+// Documentation: https://www.discogs.com/developers#page:inventory-export,header:inventory-export-get-recent-exports
+func (dc *DiscogsClient) InventoryExports(ctx context.Context, options *PaginationParams, opts ...RequestOption) (*InventoryExportsResponse, error) {
+	endpoint := "/inventory/export"
+	var res InventoryExportsResponse
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// InventoryExportsPages returns a PageFetcher that fetches pages of the inventory exports of the authenticated
+// seller, for use with NewIterator or ForEachPage.
+func (dc *DiscogsClient) InventoryExportsPages() PageFetcher[InventoryExport] {
+	return func(ctx context.Context, page PaginationParams) ([]InventoryExport, *Pagination, error) {
+		res, err := dc.InventoryExports(ctx, &page)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Items, res.Pagination, nil
+	}
+}
+
+// InventoryExport fetches an inventory export of the authenticated seller by sending a GET request to the
+// /inventory/export/{export_id} endpoint, such as to wait for an export created with CreateInventoryExport to finish.
+// The context.Context provides control over the request's lifecycle. It returns a pointer to an InventoryExport
+// struct containing the export, or an error if the request fails or the export is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:inventory-export,header:inventory-export-get-an-export
+func (dc *DiscogsClient) InventoryExport(ctx context.Context, exportID int64, opts ...RequestOption) (*InventoryExport, error) {
+	endpoint := "/inventory/export/" + strconv.FormatInt(exportID, 10)
+	var res InventoryExport
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceExport, strconv.FormatInt(exportID, 10))
+	}
+
+	return &res, nil
+}
+
+// DownloadInventoryExport downloads the CSV file of a finished inventory export of the authenticated seller by
+// sending a GET request to the /inventory/export/{export_id}/download endpoint. The file is streamed to w as it is
+// received instead of being buffered in memory, since inventories can be large. The context.Context provides control
+// over the request's lifecycle. It returns the number of bytes written, or an error if the request fails, the export
+// is not found or the file cannot be written.
+//
+// Documentation: https://www.discogs.com/developers#page:inventory-export,header:inventory-export-download-an-export
+func (dc *DiscogsClient) DownloadInventoryExport(ctx context.Context, exportID int64, w io.Writer, opts ...RequestOption) (int64, error) {
+	endpoint := "/inventory/export/" + strconv.FormatInt(exportID, 10) + "/download"
+
+	ctx = withRoute(WithRequestOptions(ctx, opts...), routePattern(endpoint, EndpointAuthMap))
+	req, err := dc.newDownloadRequest(ctx, http.MethodGet, dc.Host+endpoint, &DownloadOptions{AuthType: AuthTypeOAuth})
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := dc.stream(ctx, req)
+	if err != nil {
+		return 0, wrapNotFound(err, ResourceExport, strconv.FormatInt(exportID, 10))
+	}
+	defer response.Body.Close()
+
+	return io.Copy(w, response.Body)
+}
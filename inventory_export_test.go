@@ -0,0 +1,121 @@
+package discogs_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_CreateInventoryExport(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		want     int64
+		err      error
+	}{
+		{"CreateInventoryExport", "https://api.discogs.com/inventory/export/599632", 599632, nil},
+		{"CreateInventoryExport without location", "", 0, discogs.ErrNoLocation},
+		{"CreateInventoryExport with unexpected location", "https://api.discogs.com/inventory/export", 0, discogs.ErrNoLocation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, http.MethodPost, req.Method)
+				assert.Equal(t, "/inventory/export", req.URL.Path)
+				assert.Equal(t, "Bearer token", req.Header.Get(discogs.AuthHeader))
+
+				if tt.location != "" {
+					rw.Header().Set("Location", tt.location)
+				}
+				rw.WriteHeader(http.StatusCreated)
+			}))
+			defer server.Close()
+
+			token := "token"
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+			client.Host = server.URL
+
+			id, err := client.CreateInventoryExport(ctx)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, id)
+		})
+	}
+}
+
+func TestDiscogsClient_InventoryExports(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "/inventory/export", req.URL.Path)
+		assert.Equal(t, "page=2&per_page=1", req.URL.RawQuery)
+
+		_, _ = rw.Write([]byte(`{"pagination":{"page":2,"pages":2,"per_page":1,"items":2},"items":[{
+			"id":599632,
+			"status":"success",
+			"created_ts":"2018-09-27T12:59:02",
+			"finished_ts":"2018-09-27T12:59:03",
+			"download_url":"https://api.discogs.com/inventory/export/599632/download",
+			"filename":"seller-inventory-20180927-1259.csv",
+			"url":"https://api.discogs.com/inventory/export/599632"
+		}]}`))
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	res, err := client.InventoryExports(ctx, &discogs.PaginationParams{Page: discogs.Int(2), PerPage: discogs.Int(1)})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Items, 1) {
+		export := res.Items[0]
+		assert.Equal(t, int64(599632), export.ID)
+		assert.Equal(t, discogs.InventoryExportStatusSuccess, export.Status)
+		assert.Equal(t, time.Date(2018, 9, 27, 12, 59, 2, 0, time.UTC), export.GetCreatedTS().Time)
+		assert.Equal(t, time.Date(2018, 9, 27, 12, 59, 3, 0, time.UTC), export.GetFinishedTS().Time)
+		assert.Equal(t, "https://api.discogs.com/inventory/export/599632/download", export.DownloadURL)
+	}
+}
+
+func TestDiscogsClient_DownloadInventoryExport(t *testing.T) {
+	const csv = "listing_id,artist,title,price\n1,Artist,Title,12.50\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "Bearer token", req.Header.Get(discogs.AuthHeader))
+
+		switch req.URL.Path {
+		case "/inventory/export/599632":
+			_, _ = rw.Write([]byte(`{"id":599632,"status":"success","download_url":"https://api.discogs.com/inventory/export/599632/download"}`))
+		case "/inventory/export/599632/download":
+			rw.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			_, _ = rw.Write([]byte(csv))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"message":"Export not found."}`))
+		}
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	export, err := client.InventoryExport(ctx, 599632)
+	assert.NoError(t, err)
+	assert.Equal(t, discogs.InventoryExportStatusSuccess, export.Status)
+
+	var buf bytes.Buffer
+	n, err := client.DownloadInventoryExport(ctx, 599632, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(csv)), n)
+	assert.Equal(t, csv, buf.String())
+
+	_, err = client.InventoryExport(ctx, 1)
+	assert.True(t, discogs.IsNotFound(err))
+	_, err = client.DownloadInventoryExport(ctx, 1, &buf)
+	assert.True(t, discogs.IsNotFound(err))
+}
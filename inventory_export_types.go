@@ -0,0 +1,35 @@
+package discogs
+
+// InventoryExportStatus represents the status of an inventory export.
+type InventoryExportStatus string
+
+// InventoryExportStatus constants representing the statuses of an inventory export. The file of an export can only
+// be downloaded once its status is InventoryExportStatusSuccess.
+const (
+	InventoryExportStatusPending InventoryExportStatus = "pending"
+	InventoryExportStatusSuccess InventoryExportStatus = "success"
+	InventoryExportStatusFailed  InventoryExportStatus = "failed"
+)
+
+// InventoryExportsResponse represents the response from the Discogs API for the inventory exports of the
+// authenticated seller.
+type InventoryExportsResponse struct {
+	RawResponse
+	ExtraFields
+	Pagination *Pagination       `json:"pagination,omitempty"`
+	Items      []InventoryExport `json:"items"`
+}
+
+// InventoryExport represents an export of the inventory of the authenticated seller.
+type InventoryExport struct {
+	RawResponse
+	ExtraFields
+	ID         int64                 `json:"id"`
+	Status     InventoryExportStatus `json:"status"`
+	CreatedTS  *Timestamp            `json:"created_ts,omitempty"`
+	FinishedTS *Timestamp            `json:"finished_ts,omitempty"` // Only set once the export is finished.
+	// DownloadURL is the URL of the CSV file of the export, which requires authentication.
+	DownloadURL string `json:"download_url"`
+	Filename    string `json:"filename"`
+	URL         string `json:"url"`
+}
@@ -0,0 +1,307 @@
+// Package inventorycsv builds the CSV files accepted by the inventory upload of the Discogs marketplace, which adds,
+// changes or deletes many listings at once. Rows are typed Go structs, using the condition and money types of the
+// discogs package, and every row is validated before anything is written, so a file Discogs would reject is caught
+// locally instead of after the upload.
+package inventorycsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/couwuch/discogs"
+)
+
+// Column names of the inventory upload files.
+const (
+	ColumnListingID       = "listing_id"
+	ColumnReleaseID       = "release_id"
+	ColumnPrice           = "price"
+	ColumnCondition       = "media_condition"
+	ColumnSleeveCondition = "sleeve_condition"
+	ColumnComments        = "comments"
+	ColumnAcceptOffer     = "accept_offer"
+	ColumnStatus          = "status"
+	ColumnExternalID      = "external_id"
+	ColumnLocation        = "location"
+	ColumnWeight          = "weight"
+	ColumnFormatQuantity  = "format_quantity"
+)
+
+// columns lists the columns of the listing fields, in the order they are written.
+var columns = []string{
+	ColumnReleaseID, ColumnPrice, ColumnCondition, ColumnSleeveCondition, ColumnComments, ColumnAcceptOffer,
+	ColumnStatus, ColumnExternalID, ColumnLocation, ColumnWeight, ColumnFormatQuantity,
+}
+
+// ErrInvalidRow indicates that a row cannot be written to an upload file. Row is the index of the row in the slice
+// passed to the writer, and Err the reason, usually a discogs.ErrInvalidOption naming the column.
+type ErrInvalidRow struct {
+	Row int
+	Err error
+}
+
+func (e *ErrInvalidRow) Error() string {
+	return fmt.Sprintf("invalid row %d: %v", e.Row, e.Err)
+}
+
+func (e *ErrInvalidRow) Unwrap() error {
+	return e.Err
+}
+
+// Add represents a listing to add to the inventory. ReleaseID, Price and Condition are required.
+type Add struct {
+	ReleaseID       int64
+	Price           discogs.Amount
+	Condition       discogs.Condition
+	SleeveCondition discogs.SleeveCondition
+	Comments        string
+	AcceptOffer     bool
+	// Status is either discogs.ListingStatusForSale or discogs.ListingStatusDraft. Discogs lists new items for sale
+	// if unset.
+	Status         discogs.ListingStatus
+	ExternalID     string
+	Location       string
+	Weight         int64
+	FormatQuantity int64
+}
+
+// Validate returns a discogs.ErrInvalidOption if a required column is missing, or a column is set to a value not
+// accepted by Discogs.
+func (a *Add) Validate() error {
+	if a.ReleaseID <= 0 {
+		return &discogs.ErrInvalidOption{Option: ColumnReleaseID, Value: strconv.FormatInt(a.ReleaseID, 10)}
+	}
+	changes := a.changes()
+	// The other columns are checked the same way as the changes of a listing
+	return changes.Validate()
+}
+
+// changes returns the fields of the listing to add as the changes of a listing, leaving the optional fields that are
+// not set unset.
+func (a *Add) changes() discogs.UpdateListingRequest {
+	changes := discogs.UpdateListingRequest{
+		ReleaseID:   &a.ReleaseID,
+		Price:       &a.Price,
+		Condition:   &a.Condition,
+		AllowOffers: &a.AcceptOffer,
+	}
+	if a.SleeveCondition != "" {
+		changes.SleeveCondition = &a.SleeveCondition
+	}
+	if a.Comments != "" {
+		changes.Comments = &a.Comments
+	}
+	if a.Status != "" {
+		changes.Status = &a.Status
+	}
+	if a.ExternalID != "" {
+		changes.ExternalID = &a.ExternalID
+	}
+	if a.Location != "" {
+		changes.Location = &a.Location
+	}
+	if a.Weight != 0 {
+		changes.Weight = &a.Weight
+	}
+	if a.FormatQuantity != 0 {
+		changes.FormatQuantity = &a.FormatQuantity
+	}
+	return changes
+}
+
+// Change represents a change to a listing of the inventory. Only the fields set in Changes are changed.
+type Change struct {
+	ListingID int64
+	Changes   discogs.UpdateListingRequest
+}
+
+// Validate returns a discogs.ErrInvalidOption if the listing ID is missing, no change is set, or a change is set to a
+// value not accepted by Discogs.
+func (c *Change) Validate() error {
+	if c.ListingID <= 0 {
+		return &discogs.ErrInvalidOption{Option: ColumnListingID, Value: strconv.FormatInt(c.ListingID, 10)}
+	}
+	return c.Changes.Validate()
+}
+
+// WriteAdd writes a file adding the listings to the inventory. The file only holds the columns set by at least one
+// row, and the optional columns a row does not set are left empty. Every row is validated first: if any is invalid,
+// nothing is written and the error joins an ErrInvalidRow for each invalid row.
+func WriteAdd(w io.Writer, rows []Add) error {
+	changes := make([]discogs.UpdateListingRequest, len(rows))
+	var errs []error
+	for i := range rows {
+		if err := rows[i].Validate(); err != nil {
+			errs = append(errs, &ErrInvalidRow{Row: i, Err: err})
+		}
+		changes[i] = rows[i].changes()
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	header := usedColumns(changes)
+	records := make([][]string, len(rows))
+	for i := range changes {
+		records[i] = record(header, &changes[i])
+	}
+	return write(w, header, records)
+}
+
+// WriteChange writes a file changing listings of the inventory. Since an empty cell cannot be told apart from a
+// cleared field, every row must set the same fields. Every row is validated first: if any is invalid, nothing is
+// written and the error joins an ErrInvalidRow for each invalid row.
+func WriteChange(w io.Writer, rows []Change) error {
+	changes := make([]discogs.UpdateListingRequest, len(rows))
+	for i := range rows {
+		changes[i] = rows[i].Changes
+	}
+	used := usedColumns(changes)
+
+	var errs []error
+	for i := range rows {
+		if err := rows[i].Validate(); err != nil {
+			errs = append(errs, &ErrInvalidRow{Row: i, Err: err})
+			continue
+		}
+		for _, column := range used {
+			if fieldValue(column, &changes[i]) == nil {
+				errs = append(errs, &ErrInvalidRow{Row: i, Err: &discogs.ErrInvalidOption{Option: column, Value: ""}})
+				break
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	header := append([]string{ColumnListingID}, used...)
+	records := make([][]string, len(rows))
+	for i := range rows {
+		records[i] = append([]string{strconv.FormatInt(rows[i].ListingID, 10)}, record(used, &changes[i])...)
+	}
+	return write(w, header, records)
+}
+
+// WriteDelete writes a file deleting the listings from the inventory. Every listing ID is validated first: if any is
+// invalid, nothing is written and the error joins an ErrInvalidRow for each invalid row.
+func WriteDelete(w io.Writer, listingIDs []int64) error {
+	var errs []error
+	records := make([][]string, len(listingIDs))
+	for i, id := range listingIDs {
+		if id <= 0 {
+			errs = append(errs, &ErrInvalidRow{Row: i, Err: &discogs.ErrInvalidOption{
+				Option: ColumnListingID,
+				Value:  strconv.FormatInt(id, 10),
+			}})
+		}
+		records[i] = []string{strconv.FormatInt(id, 10)}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return write(w, []string{ColumnListingID}, records)
+}
+
+// usedColumns returns the columns of the fields set in at least one of changes, in the order they are written.
+func usedColumns(changes []discogs.UpdateListingRequest) []string {
+	var used []string
+	for _, column := range columns {
+		if slices.ContainsFunc(changes, func(c discogs.UpdateListingRequest) bool { return fieldValue(column, &c) != nil }) {
+			used = append(used, column)
+		}
+	}
+	return used
+}
+
+// record returns the cells of the columns for a row, leaving the cells of the fields that are not set empty.
+func record(columns []string, changes *discogs.UpdateListingRequest) []string {
+	cells := make([]string, len(columns))
+	for i, column := range columns {
+		if value := fieldValue(column, changes); value != nil {
+			cells[i] = *value
+		}
+	}
+	return cells
+}
+
+// fieldValue returns the cell of a column for a row, or nil if its field is not set.
+func fieldValue(column string, changes *discogs.UpdateListingRequest) *string {
+	var value string
+	switch column {
+	case ColumnReleaseID:
+		if changes.ReleaseID == nil {
+			return nil
+		}
+		value = strconv.FormatInt(*changes.ReleaseID, 10)
+	case ColumnPrice:
+		if changes.Price == nil {
+			return nil
+		}
+		value = changes.Price.String()
+	case ColumnCondition:
+		if changes.Condition == nil {
+			return nil
+		}
+		value = string(*changes.Condition)
+	case ColumnSleeveCondition:
+		if changes.SleeveCondition == nil {
+			return nil
+		}
+		value = string(*changes.SleeveCondition)
+	case ColumnComments:
+		if changes.Comments == nil {
+			return nil
+		}
+		value = *changes.Comments
+	case ColumnAcceptOffer:
+		if changes.AllowOffers == nil {
+			return nil
+		}
+		value = "N"
+		if *changes.AllowOffers {
+			value = "Y"
+		}
+	case ColumnStatus:
+		if changes.Status == nil {
+			return nil
+		}
+		value = string(*changes.Status)
+	case ColumnExternalID:
+		if changes.ExternalID == nil {
+			return nil
+		}
+		value = *changes.ExternalID
+	case ColumnLocation:
+		if changes.Location == nil {
+			return nil
+		}
+		value = *changes.Location
+	case ColumnWeight:
+		if changes.Weight == nil {
+			return nil
+		}
+		value = strconv.FormatInt(*changes.Weight, 10)
+	case ColumnFormatQuantity:
+		if changes.FormatQuantity == nil {
+			return nil
+		}
+		value = strconv.FormatInt(*changes.FormatQuantity, 10)
+	default:
+		return nil
+	}
+	return &value
+}
+
+// write writes the header and the records to w as CSV.
+func write(w io.Writer, header []string, records [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	return cw.WriteAll(records)
+}
@@ -0,0 +1,124 @@
+package inventorycsv_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/couwuch/discogs/inventorycsv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteAdd(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    []inventorycsv.Add
+		want    string
+		wantErr []error
+	}{
+		{
+			name: "required and optional columns",
+			rows: []inventorycsv.Add{
+				{ReleaseID: 249504, Price: 1250, Condition: discogs.ConditionVeryGoodPlus, AcceptOffer: true},
+				{
+					ReleaseID: 5, Price: 800, Condition: discogs.ConditionMint,
+					SleeveCondition: discogs.SleeveConditionGeneric, Comments: "Sealed, \"hype\" sticker",
+				},
+			},
+			want: "release_id,price,media_condition,sleeve_condition,comments,accept_offer\n" +
+				"249504,12.50,Very Good Plus (VG+),,,Y\n" +
+				"5,8.00,Mint (M),Generic,\"Sealed, \"\"hype\"\" sticker\",N\n",
+		},
+		{
+			name: "invalid rows",
+			rows: []inventorycsv.Add{
+				{ReleaseID: 249504, Price: 1250, Condition: discogs.ConditionVeryGoodPlus},
+				{Price: 1250, Condition: discogs.ConditionVeryGoodPlus},
+				{ReleaseID: 249504, Condition: discogs.ConditionVeryGoodPlus},
+				{ReleaseID: 249504, Price: 1250, Condition: "VG+"},
+			},
+			wantErr: []error{
+				&inventorycsv.ErrInvalidRow{Row: 1, Err: &discogs.ErrInvalidOption{Option: "release_id", Value: "0"}},
+				&inventorycsv.ErrInvalidRow{Row: 2, Err: &discogs.ErrInvalidOption{Option: "price", Value: "0.00"}},
+				&inventorycsv.ErrInvalidRow{Row: 3, Err: &discogs.ErrInvalidOption{Option: "condition", Value: "VG+"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := inventorycsv.WriteAdd(&buf, tt.rows)
+			if tt.wantErr != nil {
+				assert.Equal(t, errors.Join(tt.wantErr...), err)
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriteChange(t *testing.T) {
+	price := func(a discogs.Amount) *discogs.Amount { return &a }
+
+	tests := []struct {
+		name    string
+		rows    []inventorycsv.Change
+		want    string
+		wantErr []error
+	}{
+		{
+			name: "same columns",
+			rows: []inventorycsv.Change{
+				{ListingID: 1, Changes: discogs.UpdateListingRequest{Price: price(1000), Location: discogs.String("A1")}},
+				{ListingID: 2, Changes: discogs.UpdateListingRequest{Price: price(2050), Location: discogs.String("")}},
+			},
+			want: "listing_id,price,location\n1,10.00,A1\n2,20.50,\n",
+		},
+		{
+			name: "invalid rows",
+			rows: []inventorycsv.Change{
+				{ListingID: 1, Changes: discogs.UpdateListingRequest{Price: price(1000)}},
+				{ListingID: 2, Changes: discogs.UpdateListingRequest{Location: discogs.String("A1")}},
+				{Changes: discogs.UpdateListingRequest{Price: price(1000)}},
+				{ListingID: 4},
+			},
+			wantErr: []error{
+				&inventorycsv.ErrInvalidRow{Row: 0, Err: &discogs.ErrInvalidOption{Option: "location", Value: ""}},
+				&inventorycsv.ErrInvalidRow{Row: 1, Err: &discogs.ErrInvalidOption{Option: "price", Value: ""}},
+				&inventorycsv.ErrInvalidRow{Row: 2, Err: &discogs.ErrInvalidOption{Option: "listing_id", Value: "0"}},
+				&inventorycsv.ErrInvalidRow{Row: 3, Err: &discogs.ErrInvalidOption{Option: "listing update", Value: ""}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := inventorycsv.WriteChange(&buf, tt.rows)
+			if tt.wantErr != nil {
+				assert.Equal(t, errors.Join(tt.wantErr...), err)
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriteDelete(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, inventorycsv.WriteDelete(&buf, []int64{1, 2}))
+	assert.Equal(t, "listing_id\n1\n2\n", buf.String())
+
+	buf.Reset()
+	err := inventorycsv.WriteDelete(&buf, []int64{1, -2})
+	var rowErr *inventorycsv.ErrInvalidRow
+	assert.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, 1, rowErr.Row)
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,103 @@
+package discogs
+
+import "context"
+
+// DefaultLabelTreeDepth is the default depth of the sublabel tree built by LabelTree. Large labels can have hundreds
+// of sublabels, and every label in the tree takes a request, so the tree is bounded unless a depth is set.
+const DefaultLabelTreeDepth = 3
+
+// LabelNode represents a label in a label hierarchy.
+type LabelNode struct {
+	LabelRef
+	// Depth is the distance of the label from the root of the hierarchy.
+	Depth int
+	// Sublabels are the sublabels of the label. The sublabels of labels at the maximum depth are not fetched, so it
+	// is always empty for those labels.
+	Sublabels []*LabelNode
+}
+
+// Walk calls fn for the node and each of its descendants, depth first. Descendants of a node are skipped if fn
+// returns false for it.
+func (n *LabelNode) Walk(fn func(node *LabelNode) bool) {
+	if n == nil || !fn(n) {
+		return
+	}
+	for _, sublabel := range n.Sublabels {
+		sublabel.Walk(fn)
+	}
+}
+
+// LabelTree fetches the label with the given ID and its sublabels, recursively, and returns them as a tree. The
+// maxDepth parameter limits how deep sublabels are fetched, where 1 only fetches the direct sublabels of the label;
+// DefaultLabelTreeDepth is used if it is zero or less. Every label appears at most once in the tree, even if the
+// label hierarchy contains cycles.
+func (dc *DiscogsClient) LabelTree(ctx context.Context, labelID int64, maxDepth int) (*LabelNode, error) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultLabelTreeDepth
+	}
+
+	label, err := dc.Label(ctx, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &LabelNode{LabelRef: LabelRef{ID: labelID, Name: label.Name, ResourceURL: label.ResourceURL}}
+	visited := map[int64]bool{labelID: true}
+
+	// Build the tree breadth first, so a label reachable through several paths is placed at its shallowest depth
+	type queued struct {
+		node      *LabelNode
+		sublabels []LabelRef
+	}
+	queue := []queued{{root, label.Sublabels}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, sublabel := range current.sublabels {
+			if visited[sublabel.ID] {
+				continue
+			}
+			visited[sublabel.ID] = true
+
+			node := &LabelNode{LabelRef: sublabel, Depth: current.node.Depth + 1}
+			current.node.Sublabels = append(current.node.Sublabels, node)
+
+			if node.Depth < maxDepth {
+				label, err := dc.Label(ctx, sublabel.ID)
+				if err != nil {
+					return nil, err
+				}
+				queue = append(queue, queued{node, label.Sublabels})
+			}
+		}
+	}
+
+	return root, nil
+}
+
+// LabelAncestors returns the parent labels of the label with the given ID, starting with its direct parent and ending
+// with the top-level label. At most maxDepth parents are fetched, or all parents if maxDepth is zero or less. The walk
+// stops when a label is encountered twice, so cycles in the label hierarchy are not followed.
+func (dc *DiscogsClient) LabelAncestors(ctx context.Context, labelID int64, maxDepth int) ([]LabelRef, error) {
+	var ancestors []LabelRef
+	visited := map[int64]bool{labelID: true}
+
+	for id := labelID; maxDepth <= 0 || len(ancestors) < maxDepth; {
+		label, err := dc.Label(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		parent := label.ParentLabel
+		if parent == nil || visited[parent.ID] {
+			break
+		}
+		visited[parent.ID] = true
+
+		ancestors = append(ancestors, *parent)
+		id = parent.ID
+	}
+
+	return ancestors, nil
+}
@@ -0,0 +1,110 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+// newLabelHierarchyServer creates a mock server for the label hierarchy 1 > 2 > 4 > 5 and 1 > 3, where label 4 also
+// lists label 1 as a sublabel and label 1 lists label 5 as its parent to form cycles.
+func newLabelHierarchyServer(t *testing.T, requests *atomic.Int64) *httptest.Server {
+	labels := map[int64]discogs.LabelResponse{
+		1: {Name: "Label 1", Sublabels: []discogs.LabelRef{{ID: 2, Name: "Label 2"}, {ID: 3, Name: "Label 3"}}, ParentLabel: &discogs.LabelRef{ID: 5, Name: "Label 5"}},
+		2: {Name: "Label 2", Sublabels: []discogs.LabelRef{{ID: 4, Name: "Label 4"}}, ParentLabel: &discogs.LabelRef{ID: 1, Name: "Label 1"}},
+		3: {Name: "Label 3", ParentLabel: &discogs.LabelRef{ID: 1, Name: "Label 1"}},
+		4: {Name: "Label 4", Sublabels: []discogs.LabelRef{{ID: 1, Name: "Label 1"}, {ID: 5, Name: "Label 5"}}, ParentLabel: &discogs.LabelRef{ID: 2, Name: "Label 2"}},
+		5: {Name: "Label 5", ParentLabel: &discogs.LabelRef{ID: 4, Name: "Label 4"}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		id, _ := strconv.ParseInt(strings.TrimPrefix(req.URL.Path, "/labels/"), 10, 64)
+		label, ok := labels[id]
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		label.ID = id
+
+		if err := json.NewEncoder(rw).Encode(label); err != nil {
+			assert.FailNow(t, "failed to write the response body: %w", err)
+		}
+	}))
+}
+
+func TestDiscogsClient_LabelTree(t *testing.T) {
+	type want struct {
+		// tree lists the IDs of the labels in the tree, depth first, prefixed by their depth.
+		tree     []string
+		requests int64
+	}
+	tests := []struct {
+		name     string
+		maxDepth int
+		want     want
+	}{
+		{"LabelTree with direct sublabels", 1, want{[]string{"0:1", "1:2", "1:3"}, 1}},
+		{"LabelTree with default depth", 0, want{[]string{"0:1", "1:2", "2:4", "3:5", "1:3"}, 4}},
+		{"LabelTree with unreachable depth", 10, want{[]string{"0:1", "1:2", "2:4", "3:5", "1:3"}, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := newLabelHierarchyServer(t, &requests)
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+			client.Host = server.URL
+
+			root, err := client.LabelTree(ctx, 1, tt.maxDepth)
+			assert.NoError(t, err)
+
+			var tree []string
+			root.Walk(func(node *discogs.LabelNode) bool {
+				tree = append(tree, strconv.Itoa(node.Depth)+":"+strconv.FormatInt(node.ID, 10))
+				return true
+			})
+			assert.Equal(t, tt.want.tree, tree)
+			assert.Equal(t, tt.want.requests, requests.Load())
+		})
+	}
+}
+
+func TestDiscogsClient_LabelAncestors(t *testing.T) {
+	tests := []struct {
+		name     string
+		labelID  int64
+		maxDepth int
+		want     []int64
+	}{
+		{"LabelAncestors stops at cycle", 3, 0, []int64{1, 5, 4, 2}},
+		{"LabelAncestors with max depth", 4, 2, []int64{2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := newLabelHierarchyServer(t, &requests)
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+			client.Host = server.URL
+
+			ancestors, err := client.LabelAncestors(ctx, tt.labelID, tt.maxDepth)
+			assert.NoError(t, err)
+
+			var ids []int64
+			for _, ancestor := range ancestors {
+				ids = append(ids, ancestor.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
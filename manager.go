@@ -0,0 +1,163 @@
+package discogs
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownAccount indicates that a ClientManager holds no client for a user.
+type ErrUnknownAccount struct {
+	Username string
+}
+
+func (e *ErrUnknownAccount) Error() string {
+	return fmt.Sprintf("no client registered for user %q", e.Username)
+}
+
+// A ClientManager holds one authenticated client per user, for applications acting on behalf of many Discogs users.
+// The consumer key and secret are held once, in the template config, and every user only adds their access token.
+// Every client has its own rate limiters, since Discogs enforces rate limits per token, so a busy user never
+// throttles the others; use a SessionManager to share them instead. The clients share their HTTP client, and thus
+// their connection pool.
+//
+// Requests that don't depend on the user, such as database lookups, should go through the Public client or the
+// shared Batcher, so their results can be shared across users without leaking anything private.
+//
+// A ClientManager is safe for concurrent use.
+type ClientManager struct {
+	config         DiscogsConfig
+	shareRateLimit bool
+	public         *DiscogsClient
+	batcher        *Batcher
+
+	mu      sync.RWMutex
+	clients map[string]*DiscogsClient
+}
+
+// NewClientManager creates a new ClientManager. The config is used as a template for the clients of every user, with
+// the access token replaced by theirs; its own access token and CredentialProvider are ignored. The consumer key and
+// secret of the config, if any, are used by the Public client, and so is its Cache, since cached responses must not be
+// shared across users.
+func NewClientManager(config *DiscogsConfig) *ClientManager {
+	template := *config
+	template.AccessToken = nil
+	template.AccessTokenSecret = nil
+	template.CredentialProvider = nil
+
+	public := NewDiscogsClient(&template)
+	m := &ClientManager{
+		config:  public.Config,
+		public:  public,
+		clients: make(map[string]*DiscogsClient),
+	}
+	m.batcher = public.NewBatcher(nil)
+	return m
+}
+
+// Public returns the client used for requests that don't act on behalf of a user. Clients added afterwards inherit
+// its Host and HTTP client.
+func (m *ClientManager) Public() *DiscogsClient {
+	return m.public
+}
+
+// Batcher returns a Batcher shared by all users, coalescing their release and master lookups through the Public
+// client.
+func (m *ClientManager) Batcher() *Batcher {
+	return m.batcher
+}
+
+// Add registers the personal access token of a user and returns the client acting on their behalf. The client
+// replaces any existing client of the user.
+func (m *ClientManager) Add(username, accessToken string) *DiscogsClient {
+	return m.add(username, accessToken, nil)
+}
+
+// AddOAuth registers the OAuth access token and secret of a user and returns the client acting on their behalf, which
+// signs its requests with the consumer key and secret of the template config. The client replaces any existing client
+// of the user.
+func (m *ClientManager) AddOAuth(username, token, tokenSecret string) *DiscogsClient {
+	return m.add(username, token, &tokenSecret)
+}
+
+// add registers a client of a user authenticated by the access token and, for OAuth, its secret.
+func (m *ClientManager) add(username, accessToken string, accessTokenSecret *string) *DiscogsClient {
+	config := m.config
+	config.AccessToken = &accessToken
+	config.AccessTokenSecret = accessTokenSecret
+	config.Cache = nil
+
+	client := NewDiscogsClient(&config)
+	client.Host = m.public.Host
+	client.Client = m.public.Client
+
+	if m.shareRateLimit {
+		// Share the registry itself, so hosts first used after the client was added are shared too, and requests of
+		// higher priority go first across clients
+		client.hosts = m.public.hostRegistry()
+	}
+
+	m.mu.Lock()
+	m.clients[username] = client
+	m.mu.Unlock()
+
+	return client
+}
+
+// Remove unregisters the client of a user. It does nothing if the user has no client.
+func (m *ClientManager) Remove(username string) {
+	m.mu.Lock()
+	delete(m.clients, username)
+	m.mu.Unlock()
+}
+
+// Client returns the client acting on behalf of a user, or an ErrUnknownAccount if the user has no client.
+func (m *ClientManager) Client(username string) (*DiscogsClient, error) {
+	m.mu.RLock()
+	client, ok := m.clients[username]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, &ErrUnknownAccount{Username: username}
+	}
+	return client, nil
+}
+
+// Usernames returns the users with a registered client, in alphabetical order.
+func (m *ClientManager) Usernames() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	usernames := make([]string, 0, len(m.clients))
+	for username := range m.clients {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+// SessionManagerOptions represents the options of a SessionManager.
+type SessionManagerOptions struct {
+	// ShareRateLimit makes the clients of every user share the rate limiters of the Public client, for applications
+	// whose users are all subject to a single rate limit, such as one enforced by a proxy. Every client has its own
+	// rate limiters otherwise.
+	ShareRateLimit bool
+}
+
+// A SessionManager is a ClientManager whose behavior is set by SessionManagerOptions, such as sharing a single rate
+// limit across users.
+//
+// A SessionManager is safe for concurrent use.
+type SessionManager struct {
+	*ClientManager
+}
+
+// NewSessionManager creates a new SessionManager, using config as NewClientManager does. If options is nil, the
+// default options are used, and the SessionManager behaves as a ClientManager.
+func NewSessionManager(config *DiscogsConfig, options *SessionManagerOptions) *SessionManager {
+	m := NewClientManager(config)
+	if options != nil {
+		m.shareRateLimit = options.ShareRateLimit
+	}
+	return &SessionManager{ClientManager: m}
+}
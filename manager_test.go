@@ -0,0 +1,94 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientManager(t *testing.T) {
+	var authHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		authHeaders = append(authHeaders, req.Header.Get(discogs.AuthHeader))
+		_, _ = rw.Write([]byte(`{"orders":[]}`))
+	}))
+	defer server.Close()
+
+	token := "ignored"
+	manager := discogs.NewClientManager(&discogs.DiscogsConfig{AppName: "Manager/1.0", AccessToken: &token, MaxRequests: 10})
+	manager.Public().Host = server.URL
+
+	alice := manager.Add("alice", "alice-token")
+	bob := manager.Add("bob", "bob-token")
+	assert.Equal(t, []string{"alice", "bob"}, manager.Usernames())
+
+	client, err := manager.Client("alice")
+	assert.NoError(t, err)
+	assert.Same(t, alice, client)
+	assert.Equal(t, "Manager/1.0", client.Config.AppName)
+	assert.Same(t, manager.Public().Client, client.Client)
+
+	_, err = client.Orders(ctx, nil)
+	assert.NoError(t, err)
+	_, err = bob.Orders(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bearer alice-token", "Bearer bob-token"}, authHeaders)
+
+	// Each user has their own rate limiter
+	assert.Less(t, alice.Tokens(), 10.0)
+	assert.Less(t, bob.Tokens(), 10.0)
+	assert.Equal(t, 10.0, manager.Public().Tokens())
+	assert.Nil(t, manager.Public().Config.AccessToken)
+
+	manager.Remove("alice")
+	_, err = manager.Client("alice")
+	assert.Equal(t, &discogs.ErrUnknownAccount{Username: "alice"}, err)
+	assert.Equal(t, []string{"bob"}, manager.Usernames())
+}
+
+func TestClientManager_SharedRateLimit(t *testing.T) {
+	var authHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		authHeaders = append(authHeaders, req.Header.Get(discogs.AuthHeader))
+		_, _ = rw.Write([]byte(`{"orders":[]}`))
+	}))
+	defer server.Close()
+
+	manager := discogs.NewSessionManager(
+		&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret, MaxRequests: 10},
+		&discogs.SessionManagerOptions{ShareRateLimit: true},
+	)
+	manager.Public().Host = server.URL
+
+	alice := manager.AddOAuth("alice", "alice-token", "alice-secret")
+	bob := manager.Add("bob", "bob-token")
+
+	_, err := alice.Orders(ctx, nil)
+	assert.NoError(t, err)
+	_, err = bob.Orders(ctx, nil)
+	assert.NoError(t, err)
+
+	assert.Len(t, authHeaders, 2)
+	assert.Contains(t, authHeaders[0], `oauth_token="alice-token"`)
+	assert.Contains(t, authHeaders[0], `oauth_signature="secret%26alice-secret"`)
+	assert.Equal(t, "Bearer bob-token", authHeaders[1])
+
+	// Both requests were taken from the limiter of the Public client
+	assert.Less(t, manager.Public().Tokens(), 9.0)
+	assert.InDelta(t, manager.Public().Tokens(), alice.Tokens(), 0.1)
+
+	// Hosts first used after the clients were added are shared as well
+	other := httptest.NewServer(server.Config.Handler)
+	defer other.Close()
+	alice.Host, bob.Host = other.URL, other.URL
+
+	_, err = alice.Orders(ctx, nil)
+	assert.NoError(t, err)
+	_, err = bob.Orders(ctx, nil)
+	assert.NoError(t, err)
+	assert.Less(t, bob.Tokens(), 9.0)
+	assert.InDelta(t, bob.Tokens(), alice.Tokens(), 0.1)
+}
@@ -0,0 +1,445 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+// orderTimeLayout is the ISO 8601 layout the Discogs API expects for order date filters.
+const orderTimeLayout = "2006-01-02T15:04:05Z"
+
+// ErrInvalidOption indicates that an option has a value that is not accepted by the Discogs API.
+type ErrInvalidOption struct {
+	Option string
+	Value  string
+}
+
+func (e *ErrInvalidOption) Error() string {
+	return fmt.Sprintf("invalid %s: %q", e.Option, e.Value)
+}
+
+// IsValid reports whether the status is a listing status known to the Discogs API.
+func (s ListingStatus) IsValid() bool {
+	switch s {
+	case ListingStatusForSale, ListingStatusDraft, ListingStatusSold, ListingStatusExpired, ListingStatusDeleted:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the field can be used to sort an inventory.
+func (s InventorySort) IsValid() bool {
+	switch s {
+	case InventorySortListed, InventorySortPrice, InventorySortItem, InventorySortArtist, InventorySortLabel,
+		InventorySortCatNo, InventorySortAudio:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the sort order is known to the Discogs API.
+func (o SortOrder) IsValid() bool {
+	return o == SortOrderAsc || o == SortOrderDesc
+}
+
+// Validate returns an ErrInvalidOption if any of the options is set to a value not accepted by the Discogs API. The
+// API ignores unknown filters and returns the full inventory, so they are caught before a request is sent.
+func (o *InventoryOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.Status != "" && !o.Status.IsValid() {
+		return &ErrInvalidOption{Option: "status", Value: string(o.Status)}
+	}
+	if o.Sort != "" && !o.Sort.IsValid() {
+		return &ErrInvalidOption{Option: "sort", Value: string(o.Sort)}
+	}
+	if o.SortOrder != "" && !o.SortOrder.IsValid() {
+		return &ErrInvalidOption{Option: "sort order", Value: string(o.SortOrder)}
+	}
+	return nil
+}
+
+// NewListingFromRelease creates a draft NewListing for the release with the given condition and price. The format
+// quantity and estimated weight of the release are carried over so shipping is calculated the same way Discogs would.
+// The returned listing has the ListingStatusDraft status and can be further adjusted before it is submitted.
+func NewListingFromRelease(release *ReleaseResponse, condition Condition, price Amount) *NewListing {
+	listing := &NewListing{
+		Condition: condition,
+		Price:     price,
+		Status:    ListingStatusDraft,
+	}
+
+	if release != nil {
+		listing.ReleaseID = release.ID
+		if release.FormatQuantity != nil {
+			listing.FormatQuantity = Int64(*release.FormatQuantity)
+		}
+		if release.EstimatedWeight != nil {
+			listing.Weight = Int64(*release.EstimatedWeight)
+		}
+	}
+
+	return listing
+}
+
+// IsValid reports whether the status is an order status known to the Discogs API.
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderStatusAll, OrderStatusNewOrder, OrderStatusBuyerContacted, OrderStatusInvoiceSent,
+		OrderStatusPaymentPending, OrderStatusPaymentReceived, OrderStatusInProgress, OrderStatusShipped,
+		OrderStatusMerged, OrderStatusOrderChanged, OrderStatusRefundSent, OrderStatusCancelled,
+		OrderStatusCancelledNonPayingBuyer, OrderStatusCancelledItemUnavailable,
+		OrderStatusCancelledPerBuyersRequest, OrderStatusCancelledRefundReceived:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the field can be used to sort orders.
+func (s OrderSort) IsValid() bool {
+	switch s {
+	case OrderSortID, OrderSortBuyer, OrderSortCreated, OrderSortStatus, OrderSortLastActivity:
+		return true
+	}
+	return false
+}
+
+// Validate returns an ErrInvalidOption if any of the options is set to a value not accepted by the Discogs API.
+func (o *OrdersOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.Status != "" && !o.Status.IsValid() {
+		return &ErrInvalidOption{Option: "status", Value: string(o.Status)}
+	}
+	if o.Sort != "" && !o.Sort.IsValid() {
+		return &ErrInvalidOption{Option: "sort", Value: string(o.Sort)}
+	}
+	if o.SortOrder != "" && !o.SortOrder.IsValid() {
+		return &ErrInvalidOption{Option: "sort order", Value: string(o.SortOrder)}
+	}
+	if !o.CreatedAfter.IsZero() && !o.CreatedBefore.IsZero() && !o.CreatedAfter.Before(o.CreatedBefore) {
+		return &ErrInvalidOption{Option: "created range", Value: o.CreatedAfter.Format(time.RFC3339) + " - " + o.CreatedBefore.Format(time.RFC3339)}
+	}
+	return nil
+}
+
+// Orders lists the orders of the authenticated seller by sending a GET request to the /marketplace/orders endpoint.
+// The options parameter filters and sorts the orders; the created date filters are converted to UTC, so orders can
+// be ingested incrementally by passing the creation time of the last order seen. The context.Context provides control
+// over the request's lifecycle. It returns a pointer to an OrdersResponse struct containing a page of orders, or an
+// error if the options are not valid or the request fails.
+//
+// Documentation: https://www.discogs.com/developers#page:marketplace,header:marketplace-list-orders
+func (dc *DiscogsClient) Orders(ctx context.Context, options *OrdersOptions, opts ...RequestOption) (*OrdersResponse, error) {
+	endpoint := "/marketplace/orders"
+	var res OrdersResponse
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if options != nil {
+		if !options.CreatedAfter.IsZero() {
+			params.Set("created_after", options.CreatedAfter.UTC().Format(orderTimeLayout))
+		}
+		if !options.CreatedBefore.IsZero() {
+			params.Set("created_before", options.CreatedBefore.UTC().Format(orderTimeLayout))
+		}
+	}
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// OrdersPages returns a PageFetcher that fetches pages of the orders of the authenticated seller, for use with
+// NewIterator or ForEachPage. The pagination parameters of options are overridden by the page being fetched.
+func (dc *DiscogsClient) OrdersPages(options *OrdersOptions) PageFetcher[Order] {
+	return func(ctx context.Context, page PaginationParams) ([]Order, *Pagination, error) {
+		var pageOptions OrdersOptions
+		if options != nil {
+			pageOptions = *options
+		}
+		pageOptions.Page = page.Page
+		if page.PerPage != nil {
+			pageOptions.PerPage = page.PerPage
+		}
+
+		res, err := dc.Orders(ctx, &pageOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Orders, res.Pagination, nil
+	}
+}
+
+// Order fetches an order of the authenticated seller by sending a GET request to the /marketplace/orders/{order_id}
+// endpoint. The context.Context provides control over the request's lifecycle. It returns a pointer to an Order struct
+// containing the order, or an error if the request fails or the order is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:marketplace,header:marketplace-order-get
+func (dc *DiscogsClient) Order(ctx context.Context, orderID string, opts ...RequestOption) (*Order, error) {
+	endpoint := "/marketplace/orders/" + url.PathEscape(orderID)
+	var res Order
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceOrder, orderID)
+	}
+
+	return &res, nil
+}
+
+// UpdateOrder changes the status or the shipping amount of an order of the authenticated seller by sending a POST
+// request to the /marketplace/orders/{order_id} endpoint. Only the fields set in changes are sent. If the status is
+// changed, the order is fetched first and the new status is checked against its next statuses, since Discogs only
+// allows some transitions, such as from OrderStatusPaymentReceived to OrderStatusShipped. The context.Context provides
+// control over the request's lifecycle. It returns a pointer to an Order struct containing the updated order, or an
+// error if the changes are not valid, the request fails or the order is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:marketplace,header:marketplace-order-post
+func (dc *DiscogsClient) UpdateOrder(ctx context.Context, orderID string, changes *UpdateOrderRequest, opts ...RequestOption) (*Order, error) {
+	endpoint := "/marketplace/orders/" + url.PathEscape(orderID)
+	var res Order
+
+	if changes == nil || (changes.Status == nil && changes.Shipping == nil) {
+		return nil, &ErrInvalidOption{Option: "order update", Value: ""}
+	}
+	if changes.Shipping != nil && *changes.Shipping < 0 {
+		return nil, &ErrInvalidOption{Option: "shipping", Value: changes.Shipping.String()}
+	}
+	if changes.Status != nil {
+		status := *changes.Status
+		// OrderStatusAll and OrderStatusCancelled can only be used to filter orders
+		if !status.IsValid() || status == OrderStatusAll || status == OrderStatusCancelled {
+			return nil, &ErrInvalidOption{Option: "status", Value: string(status)}
+		}
+
+		order, err := dc.Order(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(order.NextStatus, string(status)) {
+			return nil, &ErrInvalidOption{Option: "status", Value: string(status)}
+		}
+	}
+
+	if err := dc.Post(WithRequestOptions(ctx, opts...), endpoint, nil, nil, changes, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceOrder, orderID)
+	}
+
+	return &res, nil
+}
+
+// AddOrderMessage adds a message to the message log of an order of the authenticated seller, which is emailed to the
+// buyer, by sending a POST request to the /marketplace/orders/{order_id}/messages endpoint. The context.Context
+// provides control over the request's lifecycle. It returns a pointer to an OrderMessage struct containing the
+// message, or an error if the message is empty, the request fails or the order is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:marketplace,header:marketplace-list-order-messages-post
+func (dc *DiscogsClient) AddOrderMessage(ctx context.Context, orderID, message string, opts ...RequestOption) (*OrderMessage, error) {
+	endpoint := "/marketplace/orders/" + url.PathEscape(orderID) + "/messages"
+	var res OrderMessage
+
+	if message == "" {
+		return nil, &ErrInvalidOption{Option: "message", Value: message}
+	}
+
+	body := struct {
+		Message string `json:"message"`
+	}{message}
+	if err := dc.Post(WithRequestOptions(ctx, opts...), endpoint, nil, nil, body, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceOrder, orderID)
+	}
+
+	return &res, nil
+}
+
+// MarketplaceStats fetches the marketplace statistics of a release, such as its lowest price and the number of items
+// for sale, by sending a GET request to the /marketplace/stats/{release_id} endpoint. The options parameter sets the
+// currency of the lowest price. The context.Context provides control over the request's lifecycle. It returns a
+// pointer to a MarketplaceStatsResponse struct containing the statistics, or an error if the request fails, the
+// release is not found, or the currency in options is not valid.
+//
+// Documentation: https://www.discogs.com/developers#page:marketplace,header:marketplace-release-statistics
+func (dc *DiscogsClient) MarketplaceStats(ctx context.Context, releaseID int64, options *MarketplaceStatsOptions, opts ...RequestOption) (*MarketplaceStatsResponse, error) {
+	endpoint := "/marketplace/stats/" + strconv.FormatInt(releaseID, 10)
+	var res MarketplaceStatsResponse
+
+	if options != nil {
+		if err := validateCurrency(options.CurrAbr); err != nil {
+			return nil, err
+		}
+	}
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceRelease, strconv.FormatInt(releaseID, 10))
+	}
+
+	return &res, nil
+}
+
+// PriceSuggestions fetches the suggested prices of a release for each condition by sending a GET request to the
+// /marketplace/price_suggestions/{release_id} endpoint. The suggestions are in the currency of the authenticated
+// user's seller settings, which must be filled in. The context.Context provides control over the request's
+// lifecycle. It returns a PriceSuggestionsResponse containing the suggested prices, or an error if the request fails
+// or the release is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:marketplace,header:marketplace-price-suggestions
+func (dc *DiscogsClient) PriceSuggestions(ctx context.Context, releaseID int64, opts ...RequestOption) (PriceSuggestionsResponse, error) {
+	endpoint := "/marketplace/price_suggestions/" + strconv.FormatInt(releaseID, 10)
+	var res PriceSuggestionsResponse
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceRelease, strconv.FormatInt(releaseID, 10))
+	}
+
+	return res, nil
+}
+
+// Listing fetches a marketplace listing by sending a GET request to the /marketplace/listings/{listing_id} endpoint.
+// The options parameter sets the currency of the listing's price. The context.Context provides control over the
+// request's lifecycle. It returns a pointer to a Listing struct containing the listing, or an error if the request
+// fails, the listing is not found, or the currency in options is not valid.
+//
+// Documentation: https://www.discogs.com/developers#page:marketplace,header:marketplace-listing
+func (dc *DiscogsClient) Listing(ctx context.Context, listingID int64, options *ListingOptions, opts ...RequestOption) (*Listing, error) {
+	endpoint := "/marketplace/listings/" + strconv.FormatInt(listingID, 10)
+	var res Listing
+
+	if options != nil {
+		if err := validateCurrency(options.CurrAbr); err != nil {
+			return nil, err
+		}
+	}
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceListing, strconv.FormatInt(listingID, 10))
+	}
+
+	return &res, nil
+}
+
+// Inventory fetches a page of the listings in a user's inventory by sending a GET request to the
+// /users/{username}/inventory endpoint. The options parameter filters the listings by status and sorts them. Only
+// listings for sale are visible to other users; the seller also sees the other statuses, along with the
+// seller-only fields of each Listing, since the access token is sent if set. The context.Context provides control
+// over the request's lifecycle. It returns a pointer to an InventoryResponse struct containing the listings, or an
+// error if the options are not valid, the request fails or the user is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:marketplace,header:marketplace-inventory
+func (dc *DiscogsClient) Inventory(ctx context.Context, username string, options *InventoryOptions, opts ...RequestOption) (*InventoryResponse, error) {
+	endpoint := "/users/" + url.PathEscape(username) + "/inventory"
+	var res InventoryResponse
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dc.requestWithAuth(WithRequestOptions(ctx, opts...), http.MethodGet, endpoint, dc.optionalAuth(ctx), params, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceUser, username)
+	}
+
+	return &res, nil
+}
+
+// InventoryPages returns a PageFetcher that fetches pages of the listings in a user's inventory, for use with
+// NewIterator or ForEachPage. The pagination parameters of options are overridden by the page being fetched.
+func (dc *DiscogsClient) InventoryPages(username string, options *InventoryOptions) PageFetcher[Listing] {
+	return func(ctx context.Context, page PaginationParams) ([]Listing, *Pagination, error) {
+		var pageOptions InventoryOptions
+		if options != nil {
+			pageOptions = *options
+		}
+		pageOptions.Page = page.Page
+		if page.PerPage != nil {
+			pageOptions.PerPage = page.PerPage
+		}
+
+		res, err := dc.Inventory(ctx, username, &pageOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Listings, res.Pagination, nil
+	}
+}
+
+// InventoryIter returns an Iterator over all listings in a user's inventory, fetching pages as needed, so a seller can
+// process their whole inventory without a pagination loop. The options parameter specifies the status filter and the
+// sorting, which are kept across pages; its pagination parameters are managed by the iterator. The iterOptions
+// parameter configures the iteration, such as the page size and whether to prefetch pages.
+func (dc *DiscogsClient) InventoryIter(username string, options *InventoryOptions, iterOptions *IteratorOptions) *Iterator[Listing] {
+	return NewIterator(dc.InventoryPages(username, options), iterOptions)
+}
+
+// Validate returns an ErrInvalidOption if any of the changes is set to a value not accepted by the Discogs API, or if
+// no change is set.
+func (r *UpdateListingRequest) Validate() error {
+	if r == nil || *r == (UpdateListingRequest{}) {
+		return &ErrInvalidOption{Option: "listing update", Value: ""}
+	}
+	if r.Status != nil && *r.Status != ListingStatusForSale && *r.Status != ListingStatusDraft {
+		return &ErrInvalidOption{Option: "status", Value: string(*r.Status)}
+	}
+	if r.Condition != nil && !r.Condition.IsValid() {
+		return &ErrInvalidOption{Option: "condition", Value: string(*r.Condition)}
+	}
+	if r.SleeveCondition != nil && !r.SleeveCondition.IsValid() {
+		return &ErrInvalidOption{Option: "sleeve condition", Value: string(*r.SleeveCondition)}
+	}
+	if r.Price != nil && *r.Price <= 0 {
+		return &ErrInvalidOption{Option: "price", Value: r.Price.String()}
+	}
+	return nil
+}
+
+// UpdateListing edits a marketplace listing of the authenticated seller by sending a POST request to the
+// /marketplace/listings/{listing_id} endpoint. Only the fields set in changes are sent, so a repricer can change just
+// the price. The context.Context provides control over the request's lifecycle. It returns an error if the changes
+// are not valid, the request fails or the listing is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:marketplace,header:marketplace-listing-post
+func (dc *DiscogsClient) UpdateListing(ctx context.Context, listingID int64, changes *UpdateListingRequest, opts ...RequestOption) error {
+	endpoint := "/marketplace/listings/" + strconv.FormatInt(listingID, 10)
+
+	if err := changes.Validate(); err != nil {
+		return err
+	}
+
+	// The listing can be viewed by anyone, but only edited by its seller
+	if err := dc.requestWithAuth(WithRequestOptions(ctx, opts...), http.MethodPost, endpoint, AuthTypeOAuth, nil, nil, changes, nil); err != nil {
+		return wrapNotFound(err, ResourceListing, strconv.FormatInt(listingID, 10))
+	}
+
+	return nil
+}
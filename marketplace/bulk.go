@@ -0,0 +1,125 @@
+// Package marketplace provides helpers to manage a Discogs marketplace inventory at scale, such as repricing every
+// listing of a seller. Every request goes through a discogs.DiscogsClient, so the helpers share its rate limiter with
+// the rest of the application.
+package marketplace
+
+import (
+	"context"
+	"sync"
+
+	"github.com/couwuch/discogs"
+)
+
+// DefaultConcurrency is the default number of mutations a BulkUpdater applies at once. The rate limiter spaces the
+// requests out anyway, so a few workers are enough to keep it busy.
+const DefaultConcurrency = 4
+
+// Mutation represents a change to a listing.
+type Mutation struct {
+	ListingID int64
+	// Changes holds the fields of the listing to change. Only the fields that are set are sent.
+	Changes discogs.UpdateListingRequest
+}
+
+// PriceChange returns a Mutation that changes the price of a listing.
+func PriceChange(listingID int64, price discogs.Amount) Mutation {
+	return Mutation{ListingID: listingID, Changes: discogs.UpdateListingRequest{Price: &price}}
+}
+
+// StatusChange returns a Mutation that changes the status of a listing, such as putting a draft up for sale.
+func StatusChange(listingID int64, status discogs.ListingStatus) Mutation {
+	return Mutation{ListingID: listingID, Changes: discogs.UpdateListingRequest{Status: &status}}
+}
+
+// Result represents the outcome of a Mutation.
+type Result struct {
+	Mutation Mutation
+	// Applied reports whether the mutation was sent and succeeded. It is always false in dry-run mode.
+	Applied bool
+	// Err is the error of the mutation, if it is not valid or failed to be applied.
+	Err error
+}
+
+// BulkUpdaterOptions represents the options of a BulkUpdater.
+type BulkUpdaterOptions struct {
+	// Concurrency is the number of mutations applied at once. DefaultConcurrency is used if unset.
+	Concurrency int
+	// DryRun only validates the mutations, without sending any request, so a repricing run can be checked first.
+	DryRun bool
+	// OnResult is called with the result of every mutation as soon as it is known, such as to report progress. It is
+	// called from the workers, so it must be safe for concurrent use.
+	OnResult func(Result)
+}
+
+// A BulkUpdater applies many listing mutations concurrently, and reports the outcome of each one, so a whole inventory
+// can be repriced without sending the updates one by one.
+type BulkUpdater struct {
+	client  *discogs.DiscogsClient
+	options BulkUpdaterOptions
+}
+
+// NewBulkUpdater creates a new BulkUpdater that applies mutations using the DiscogsClient. If options is nil, the
+// default options are used.
+func NewBulkUpdater(client *discogs.DiscogsClient, options *BulkUpdaterOptions) *BulkUpdater {
+	u := &BulkUpdater{client: client}
+	if options != nil {
+		u.options = *options
+	}
+	if u.options.Concurrency <= 0 {
+		u.options.Concurrency = DefaultConcurrency
+	}
+	return u
+}
+
+// Apply applies the mutations and returns their results, in the order of mutations. Every mutation is validated
+// before it is sent, and invalid mutations are reported without sending a request.
+//
+// A failed mutation does not stop the others: its error is reported in its result. Apply only returns an error if
+// ctx is canceled, along with the results so far; the mutations that were not attempted have neither Applied nor Err
+// set.
+func (u *BulkUpdater) Apply(ctx context.Context, mutations []Mutation) ([]Result, error) {
+	results := make([]Result, len(mutations))
+	queue := make(chan int, len(mutations))
+	for i := range mutations {
+		results[i].Mutation = mutations[i]
+		queue <- i
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(u.options.Concurrency, len(mutations)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				if ctx.Err() != nil {
+					return
+				}
+				results[i] = u.apply(ctx, mutations[i])
+				if u.options.OnResult != nil {
+					u.options.OnResult(results[i])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+// apply validates a mutation, and sends it unless in dry-run mode.
+func (u *BulkUpdater) apply(ctx context.Context, mutation Mutation) Result {
+	res := Result{Mutation: mutation}
+
+	if err := mutation.Changes.Validate(); err != nil {
+		res.Err = err
+		return res
+	}
+	if u.options.DryRun {
+		return res
+	}
+
+	res.Err = u.client.UpdateListing(ctx, mutation.ListingID, &mutation.Changes)
+	res.Applied = res.Err == nil
+	return res
+}
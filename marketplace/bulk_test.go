@@ -0,0 +1,83 @@
+package marketplace_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/couwuch/discogs/marketplace"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+func TestBulkUpdater_Apply(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		mu.Lock()
+		requests = append(requests, req.Method+" "+req.URL.Path+" "+string(body))
+		mu.Unlock()
+
+		if req.URL.Path == "/marketplace/listings/3" {
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"message":"Item not found. It may have been deleted."}`))
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	mutations := []marketplace.Mutation{
+		marketplace.PriceChange(1, 1250),
+		marketplace.StatusChange(2, discogs.ListingStatusForSale),
+		marketplace.PriceChange(3, 800),
+		marketplace.StatusChange(4, discogs.ListingStatusSold),
+	}
+
+	// A dry run only validates the mutations
+	results, err := marketplace.NewBulkUpdater(client, &marketplace.BulkUpdaterOptions{DryRun: true}).Apply(ctx, mutations)
+	assert.NoError(t, err)
+	assert.Empty(t, requests)
+	assert.Equal(t, []marketplace.Result{
+		{Mutation: mutations[0]},
+		{Mutation: mutations[1]},
+		{Mutation: mutations[2]},
+		{Mutation: mutations[3], Err: &discogs.ErrInvalidOption{Option: "status", Value: "Sold"}},
+	}, results)
+
+	var reported int
+	results, err = marketplace.NewBulkUpdater(client, &marketplace.BulkUpdaterOptions{
+		OnResult: func(marketplace.Result) {
+			mu.Lock()
+			reported++
+			mu.Unlock()
+		},
+	}).Apply(ctx, mutations)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, reported)
+
+	assert.True(t, results[0].Applied)
+	assert.True(t, results[1].Applied)
+	assert.False(t, results[2].Applied)
+	assert.True(t, discogs.IsNotFound(results[2].Err))
+	assert.False(t, results[3].Applied)
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "status", Value: "Sold"}, results[3].Err)
+
+	sort.Strings(requests)
+	assert.Equal(t, []string{
+		`POST /marketplace/listings/1 {"price":12.50}`,
+		`POST /marketplace/listings/2 {"status":"For Sale"}`,
+		`POST /marketplace/listings/3 {"price":8.00}`,
+	}, requests)
+}
@@ -0,0 +1,414 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewListingFromRelease(t *testing.T) {
+	type args struct {
+		release   *discogs.ReleaseResponse
+		condition discogs.Condition
+		price     discogs.Amount
+	}
+	tests := []struct {
+		name string
+		args args
+		want *discogs.NewListing
+	}{
+		{
+			"NewListingFromRelease with weight and format quantity",
+			args{
+				&discogs.ReleaseResponse{ID: 1, EstimatedWeight: discogs.Int64(230), FormatQuantity: discogs.Int64(2)},
+				"Very Good Plus (VG+)",
+				1999,
+			},
+			&discogs.NewListing{
+				ReleaseID:      1,
+				Condition:      "Very Good Plus (VG+)",
+				Price:          1999,
+				Status:         discogs.ListingStatusDraft,
+				Weight:         discogs.Int64(230),
+				FormatQuantity: discogs.Int64(2),
+			},
+		},
+		{
+			"NewListingFromRelease without estimates",
+			args{&discogs.ReleaseResponse{ID: 2}, "Mint (M)", 500},
+			&discogs.NewListing{ReleaseID: 2, Condition: "Mint (M)", Price: 500, Status: discogs.ListingStatusDraft},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := discogs.NewListingFromRelease(tt.args.release, tt.args.condition, tt.args.price)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInventoryOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *discogs.InventoryOptions
+		want    error
+	}{
+		{"Validate nil options", nil, nil},
+		{"Validate empty options", &discogs.InventoryOptions{}, nil},
+		{
+			"Validate valid options",
+			&discogs.InventoryOptions{Status: discogs.ListingStatusSold, Sort: discogs.InventorySortCatNo, SortOrder: discogs.SortOrderDesc},
+			nil,
+		},
+		{
+			"Validate invalid status",
+			&discogs.InventoryOptions{Status: "for sale"},
+			&discogs.ErrInvalidOption{Option: "status", Value: "for sale"},
+		},
+		{
+			"Validate invalid sort",
+			&discogs.InventoryOptions{Sort: "year"},
+			&discogs.ErrInvalidOption{Option: "sort", Value: "year"},
+		},
+		{
+			"Validate invalid sort order",
+			&discogs.InventoryOptions{SortOrder: "descending"},
+			&discogs.ErrInvalidOption{Option: "sort order", Value: "descending"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.options.Validate())
+		})
+	}
+}
+
+func TestMarketplace_Orders(t *testing.T) {
+	token := "token"
+	berlin := time.FixedZone("CEST", 2*60*60)
+
+	type want struct {
+		query string
+		res   *discogs.OrdersResponse
+		err   error
+	}
+	tests := []struct {
+		name string
+		args *discogs.OrdersOptions
+		want want
+	}{
+		{
+			"Orders without options",
+			nil,
+			want{"", &discogs.OrdersResponse{Orders: []discogs.Order{{ID: "1-1"}}}, nil},
+		},
+		{
+			"Orders with date range and archive filters",
+			&discogs.OrdersOptions{
+				CreatedAfter:  time.Date(2024, 5, 1, 10, 30, 0, 0, berlin),
+				CreatedBefore: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				Archived:      discogs.Bool(false),
+			},
+			want{
+				"archived=false&created_after=2024-05-01T08%3A30%3A00Z&created_before=2024-06-01T00%3A00%3A00Z",
+				&discogs.OrdersResponse{Orders: []discogs.Order{{ID: "1-1"}}},
+				nil,
+			},
+		},
+		{
+			"Orders with status and sort",
+			&discogs.OrdersOptions{Status: discogs.OrderStatusShipped, Sort: discogs.OrderSortLastActivity, SortOrder: discogs.SortOrderAsc},
+			want{"sort=last_activity&sort_order=asc&status=Shipped", &discogs.OrdersResponse{Orders: []discogs.Order{{ID: "1-1"}}}, nil},
+		},
+		{
+			"Orders with invalid status",
+			&discogs.OrdersOptions{Status: "Lost"},
+			want{"", nil, &discogs.ErrInvalidOption{Option: "status", Value: "Lost"}},
+		},
+		{
+			"Orders with inverted date range",
+			&discogs.OrdersOptions{
+				CreatedAfter:  time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				CreatedBefore: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want{"", nil, &discogs.ErrInvalidOption{Option: "created range", Value: "2024-06-01T00:00:00Z - 2024-05-01T00:00:00Z"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				query = req.URL.RawQuery
+				assert.Equal(t, "/marketplace/orders", req.URL.Path)
+				assert.Equal(t, "Bearer "+token, req.Header.Get(discogs.AuthHeader))
+
+				_ = json.NewEncoder(rw).Encode(discogs.OrdersResponse{Orders: []discogs.Order{{ID: "1-1"}}})
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+			client.Host = server.URL
+
+			res, err := client.Orders(ctx, tt.args)
+			if tt.want.err != nil {
+				assert.Equal(t, tt.want.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.query, query)
+			assert.Equal(t, tt.want.res.Orders, res.Orders)
+		})
+	}
+}
+
+func TestMarketplace_Inventory(t *testing.T) {
+	const listingsJSON = `{"pagination":{"page":1,"pages":1,"per_page":50,"items":1},"listings":[
+		{"id":10,"status":"Draft","price":{"currency":"USD","value":12.5},"external_id":"A-1","location":"Shelf 3","weight":230,"format_quantity":1}
+	]}`
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.URL.Path+"?"+req.URL.RawQuery+" "+req.Header.Get(discogs.AuthHeader))
+
+		if req.URL.Path == "/users/unknown/inventory" {
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"message":"User does not exist or may have been deleted."}`))
+			return
+		}
+		_, _ = rw.Write([]byte(listingsJSON))
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	res, err := client.Inventory(ctx, "seller", &discogs.InventoryOptions{
+		Status:    discogs.ListingStatusDraft,
+		Sort:      discogs.InventorySortPrice,
+		SortOrder: discogs.SortOrderDesc,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &discogs.Pagination{Page: 1, Pages: 1, PerPage: 50, Items: 1}, res.Pagination)
+	assert.Equal(t, []discogs.Listing{{
+		ID:             10,
+		Status:         discogs.ListingStatusDraft,
+		Price:          &discogs.Price{Currency: discogs.CurrencyUSD, Value: 1250},
+		ExternalID:     "A-1",
+		Location:       "Shelf 3",
+		Weight:         discogs.Int64(230),
+		FormatQuantity: discogs.Int64(1),
+	}}, res.Listings)
+
+	_, err = client.Inventory(ctx, "unknown", nil)
+	assert.True(t, discogs.IsNotFound(err))
+
+	// Invalid filters are rejected without sending a request
+	_, err = client.Inventory(ctx, "seller", &discogs.InventoryOptions{Status: "Lost"})
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "status", Value: "Lost"}, err)
+
+	// Inventories can be browsed without credentials
+	client = discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+	_, err = client.Inventory(ctx, "seller", nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/users/seller/inventory?sort=price&sort_order=desc&status=Draft Bearer token",
+		"/users/unknown/inventory? Bearer token",
+		"/users/seller/inventory? ",
+	}, requests)
+}
+
+func TestMarketplace_InventoryIter(t *testing.T) {
+	prices := []discogs.Amount{500, 750, 1200}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/users/seller/inventory", req.URL.Path)
+		assert.Equal(t, "For Sale", req.URL.Query().Get("status"))
+		assert.Equal(t, "price", req.URL.Query().Get("sort"))
+		assert.Equal(t, "1", req.URL.Query().Get("per_page"))
+
+		page, err := strconv.Atoi(req.URL.Query().Get("page"))
+		if err != nil {
+			assert.FailNow(t, "invalid page parameter: %v", err)
+		}
+
+		_, _ = fmt.Fprintf(rw, `{"pagination":{"page":%d,"pages":%d,"per_page":1},"listings":[{"id":%d,"price":{"currency":"EUR","value":%s}}]}`,
+			page, len(prices), page, prices[page-1])
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	options := &discogs.InventoryOptions{Status: discogs.ListingStatusForSale, Sort: discogs.InventorySortPrice}
+	it := client.InventoryIter("seller", options, &discogs.IteratorOptions{PerPage: 1})
+	defer it.Close()
+
+	var got []discogs.Amount
+	for it.Next(ctx) {
+		listing := it.Value()
+		got = append(got, listing.Price.Value)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, prices, got)
+	assert.Nil(t, options.Page, "the options of the caller must not be modified")
+}
+
+func TestMarketplace_UpdateListing(t *testing.T) {
+	draft := discogs.ListingStatusDraft
+	sold := discogs.ListingStatusSold
+	price := discogs.Amount(999)
+	negative := discogs.Amount(-100)
+
+	tests := []struct {
+		name    string
+		changes *discogs.UpdateListingRequest
+		body    string
+		err     error
+	}{
+		{
+			"UpdateListing only sends the price",
+			&discogs.UpdateListingRequest{Price: &price},
+			`{"price":9.99}`,
+			nil,
+		},
+		{
+			"UpdateListing with status and comments",
+			&discogs.UpdateListingRequest{Status: &draft, Comments: discogs.String("")},
+			`{"comments":"","status":"Draft"}`,
+			nil,
+		},
+		{
+			"UpdateListing without changes",
+			&discogs.UpdateListingRequest{},
+			"",
+			&discogs.ErrInvalidOption{Option: "listing update", Value: ""},
+		},
+		{
+			"UpdateListing with a status that cannot be set",
+			&discogs.UpdateListingRequest{Status: &sold},
+			"",
+			&discogs.ErrInvalidOption{Option: "status", Value: "Sold"},
+		},
+		{
+			"UpdateListing with a free-text condition",
+			&discogs.UpdateListingRequest{Condition: (*discogs.Condition)(discogs.String("VG+"))},
+			"",
+			&discogs.ErrInvalidOption{Option: "condition", Value: "VG+"},
+		},
+		{
+			"UpdateListing with a negative price",
+			&discogs.UpdateListingRequest{Price: &negative},
+			"",
+			&discogs.ErrInvalidOption{Option: "price", Value: "-1.00"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, http.MethodPost, req.Method)
+				assert.Equal(t, "/marketplace/listings/10", req.URL.Path)
+				assert.Equal(t, "Bearer token", req.Header.Get(discogs.AuthHeader))
+
+				b, _ := io.ReadAll(req.Body)
+				body = string(b)
+				rw.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			token := "token"
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+			client.Host = server.URL
+
+			err := client.UpdateListing(ctx, 10, tt.changes)
+			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.JSONEq(t, tt.body, body)
+			}
+		})
+	}
+}
+
+func TestMarketplace_UpdateOrder(t *testing.T) {
+	const orderJSON = `{"id":"1-1","status":"Payment Received","next_status":["Shipped","Cancelled (Item Unavailable)"]}`
+
+	shipped := discogs.OrderStatusShipped
+	newOrder := discogs.OrderStatusNewOrder
+	cancelled := discogs.OrderStatusCancelled
+	shipping := discogs.Amount(450)
+
+	tests := []struct {
+		name     string
+		changes  *discogs.UpdateOrderRequest
+		requests []string
+		err      error
+	}{
+		{
+			"UpdateOrder to a next status",
+			&discogs.UpdateOrderRequest{Status: &shipped},
+			[]string{"GET ", `POST {"status":"Shipped"}`},
+			nil,
+		},
+		{
+			"UpdateOrder shipping only",
+			&discogs.UpdateOrderRequest{Shipping: &shipping},
+			[]string{`POST {"shipping":4.50}`},
+			nil,
+		},
+		{
+			"UpdateOrder to a status that is not next",
+			&discogs.UpdateOrderRequest{Status: &newOrder},
+			[]string{"GET "},
+			&discogs.ErrInvalidOption{Option: "status", Value: "New Order"},
+		},
+		{
+			"UpdateOrder to a filter-only status",
+			&discogs.UpdateOrderRequest{Status: &cancelled},
+			nil,
+			&discogs.ErrInvalidOption{Option: "status", Value: "Cancelled"},
+		},
+		{
+			"UpdateOrder without changes",
+			nil,
+			nil,
+			&discogs.ErrInvalidOption{Option: "order update", Value: ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "/marketplace/orders/1-1", req.URL.Path)
+				body, _ := io.ReadAll(req.Body)
+				requests = append(requests, req.Method+" "+string(body))
+
+				_, _ = rw.Write([]byte(orderJSON))
+			}))
+			defer server.Close()
+
+			token := "token"
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+			client.Host = server.URL
+
+			order, err := client.UpdateOrder(ctx, "1-1", tt.changes)
+			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Equal(t, "1-1", order.ID)
+			}
+			assert.Equal(t, tt.requests, requests)
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package discogs
+
+import "time"
+
+// ListingStatus represents the status of a marketplace listing.
+type ListingStatus string
+
+// ListingStatus constants representing the statuses of a listing. Only ListingStatusForSale and ListingStatusDraft
+// can be used when creating a listing.
+const (
+	ListingStatusForSale ListingStatus = "For Sale"
+	ListingStatusDraft   ListingStatus = "Draft"
+	ListingStatusSold    ListingStatus = "Sold"
+	ListingStatusExpired ListingStatus = "Expired"
+	ListingStatusDeleted ListingStatus = "Deleted"
+)
+
+// InventorySort represents a field an inventory can be sorted by.
+type InventorySort string
+
+// InventorySort constants representing the fields an inventory can be sorted by.
+const (
+	InventorySortListed InventorySort = "listed"
+	InventorySortPrice  InventorySort = "price"
+	InventorySortItem   InventorySort = "item"
+	InventorySortArtist InventorySort = "artist"
+	InventorySortLabel  InventorySort = "label"
+	InventorySortCatNo  InventorySort = "catno"
+	InventorySortAudio  InventorySort = "audio"
+)
+
+// SortOrder represents the order results are sorted in.
+type SortOrder string
+
+// SortOrder constants representing the sort orders.
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// InventoryOptions represents the options for retrieving a user's inventory.
+//
+// See https://www.discogs.com/developers#page:marketplace,header:marketplace-inventory
+type InventoryOptions struct {
+	PaginationParams
+	Status    ListingStatus `url:"status,omitempty"`
+	Sort      InventorySort `url:"sort,omitempty"`
+	SortOrder SortOrder     `url:"sort_order,omitempty"`
+}
+
+// NewListing represents the parameters for creating a new marketplace listing.
+//
+// See https://www.discogs.com/developers#page:marketplace,header:marketplace-new-listing
+type NewListing struct {
+	ReleaseID       int64           `json:"release_id"`
+	Condition       Condition       `json:"condition"`
+	SleeveCondition SleeveCondition `json:"sleeve_condition,omitempty"`
+	Price           Amount          `json:"price"`
+	Comments        string          `json:"comments,omitempty"`
+	AllowOffers     *bool           `json:"allow_offers,omitempty"`
+	Status          ListingStatus   `json:"status"`
+	ExternalID      string          `json:"external_id,omitempty"`
+	Location        string          `json:"location,omitempty"`
+	Weight          *int64          `json:"weight,omitempty"`          // The weight in grams. Discogs estimates it if unset.
+	FormatQuantity  *int64          `json:"format_quantity,omitempty"` // The number of items counted for shipping. Discogs estimates it if unset.
+}
+
+// UpdateListingRequest represents the changes to a marketplace listing. Only the fields that are set are sent, so a
+// listing can be repriced without knowing its other fields.
+//
+// See https://www.discogs.com/developers#page:marketplace,header:marketplace-listing-post
+type UpdateListingRequest struct {
+	ReleaseID       *int64           `json:"release_id,omitempty"`
+	Condition       *Condition       `json:"condition,omitempty"`
+	SleeveCondition *SleeveCondition `json:"sleeve_condition,omitempty"`
+	Price           *Amount          `json:"price,omitempty"`
+	Comments        *string          `json:"comments,omitempty"`
+	AllowOffers     *bool            `json:"allow_offers,omitempty"`
+	Status          *ListingStatus   `json:"status,omitempty"` // Either ListingStatusForSale or ListingStatusDraft.
+	ExternalID      *string          `json:"external_id,omitempty"`
+	Location        *string          `json:"location,omitempty"`
+	Weight          *int64           `json:"weight,omitempty"`
+	FormatQuantity  *int64           `json:"format_quantity,omitempty"`
+}
+
+// OrderStatus represents the status of a marketplace order.
+type OrderStatus string
+
+// OrderStatus constants representing the statuses of an order. OrderStatusAll and OrderStatusCancelled can only be
+// used to filter orders; the latter matches every cancelled status.
+const (
+	OrderStatusAll                       OrderStatus = "All"
+	OrderStatusNewOrder                  OrderStatus = "New Order"
+	OrderStatusBuyerContacted            OrderStatus = "Buyer Contacted"
+	OrderStatusInvoiceSent               OrderStatus = "Invoice Sent"
+	OrderStatusPaymentPending            OrderStatus = "Payment Pending"
+	OrderStatusPaymentReceived           OrderStatus = "Payment Received"
+	OrderStatusInProgress                OrderStatus = "In Progress"
+	OrderStatusShipped                   OrderStatus = "Shipped"
+	OrderStatusMerged                    OrderStatus = "Merged"
+	OrderStatusOrderChanged              OrderStatus = "Order Changed"
+	OrderStatusRefundSent                OrderStatus = "Refund Sent"
+	OrderStatusCancelled                 OrderStatus = "Cancelled"
+	OrderStatusCancelledNonPayingBuyer   OrderStatus = "Cancelled (Non-Paying Buyer)"
+	OrderStatusCancelledItemUnavailable  OrderStatus = "Cancelled (Item Unavailable)"
+	OrderStatusCancelledPerBuyersRequest OrderStatus = "Cancelled (Per Buyer's Request)"
+	OrderStatusCancelledRefundReceived   OrderStatus = "Cancelled (Refund Received)"
+)
+
+// OrderSort represents a field orders can be sorted by.
+type OrderSort string
+
+// OrderSort constants representing the fields orders can be sorted by.
+const (
+	OrderSortID           OrderSort = "id"
+	OrderSortBuyer        OrderSort = "buyer"
+	OrderSortCreated      OrderSort = "created"
+	OrderSortStatus       OrderSort = "status"
+	OrderSortLastActivity OrderSort = "last_activity"
+)
+
+// OrdersOptions represents the options for listing the orders of the authenticated seller.
+//
+// See https://www.discogs.com/developers#page:marketplace,header:marketplace-list-orders
+type OrdersOptions struct {
+	PaginationParams
+	Status    OrderStatus `url:"status,omitempty"`
+	Sort      OrderSort   `url:"sort,omitempty"`
+	SortOrder SortOrder   `url:"sort_order,omitempty"`
+	// CreatedAfter only includes orders created after the given time, if set.
+	CreatedAfter time.Time `url:"-"`
+	// CreatedBefore only includes orders created before the given time, if set.
+	CreatedBefore time.Time `url:"-"`
+	// Archived only includes archived orders if true, or only orders that are not archived if false. Both are
+	// included if unset.
+	Archived *bool `url:"archived,omitempty"`
+}
+
+// OrdersResponse represents the response from the Discogs API for a list of orders.
+type OrdersResponse struct {
+	RawResponse
+	ExtraFields
+	Pagination *Pagination `json:"pagination,omitempty"`
+	Orders     []Order     `json:"orders"`
+}
+
+// Order represents a marketplace order.
+type Order struct {
+	RawResponse
+	ExtraFields
+	ID                     string      `json:"id"`
+	ResourceURL            string      `json:"resource_url"`
+	MessagesURL            string      `json:"messages_url"`
+	URI                    string      `json:"uri"`
+	Status                 OrderStatus `json:"status"`
+	NextStatus             []string    `json:"next_status"`
+	Fee                    *Price      `json:"fee,omitempty"`
+	Created                *Timestamp  `json:"created,omitempty"`
+	LastActivity           *Timestamp  `json:"last_activity,omitempty"`
+	Items                  []OrderItem `json:"items"`
+	Shipping               *Shipping   `json:"shipping,omitempty"`
+	ShippingAddress        string      `json:"shipping_address"`
+	AdditionalInstructions string      `json:"additional_instructions"`
+	Archived               bool        `json:"archived"`
+	Seller                 *MarketUser `json:"seller,omitempty"`
+	Buyer                  *MarketUser `json:"buyer,omitempty"`
+	Total                  *Price      `json:"total,omitempty"`
+}
+
+// UpdateOrderRequest represents the changes to a marketplace order. Only the fields that are set are sent.
+//
+// See https://www.discogs.com/developers#page:marketplace,header:marketplace-order-post
+type UpdateOrderRequest struct {
+	// Status is the new status of the order, which must be one of the next statuses of the order.
+	Status *OrderStatus `json:"status,omitempty"`
+	// Shipping is the new shipping amount of the order, in the currency of the order.
+	Shipping *Amount `json:"shipping,omitempty"`
+}
+
+// OrderMessage represents a message of the message log of an order.
+type OrderMessage struct {
+	RawResponse
+	ExtraFields
+	Subject   string      `json:"subject"`
+	Message   string      `json:"message"`
+	Type      string      `json:"type"`
+	Timestamp *Timestamp  `json:"timestamp,omitempty"`
+	From      *MarketUser `json:"from,omitempty"`
+}
+
+// OrderItem represents a single item of an order.
+type OrderItem struct {
+	ID              int64           `json:"id"`
+	Release         *OrderRelease   `json:"release,omitempty"`
+	Price           *Price          `json:"price,omitempty"`
+	MediaCondition  Condition       `json:"media_condition"`
+	SleeveCondition SleeveCondition `json:"sleeve_condition"`
+}
+
+// OrderRelease represents the release of an order item.
+type OrderRelease struct {
+	ID          int64  `json:"id"`
+	Description string `json:"description"`
+}
+
+// Price represents an amount in a currency.
+type Price struct {
+	Currency Currency `json:"currency"`
+	Value    Amount   `json:"value"`
+}
+
+// Shipping represents the shipping costs and method of an order.
+type Shipping struct {
+	Currency Currency `json:"currency"`
+	Method   string   `json:"method"`
+	Value    float64  `json:"value"`
+}
+
+// MarketUser represents the buyer or seller of an order.
+type MarketUser struct {
+	ID          int64  `json:"id"`
+	Username    string `json:"username"`
+	ResourceURL string `json:"resource_url"`
+}
+
+// MarketplaceStatsOptions represents the options for retrieving the marketplace statistics of a release.
+type MarketplaceStatsOptions struct {
+	CurrAbr Currency `url:"curr_abbr,omitempty"`
+}
+
+// MarketplaceStatsResponse represents the response from the Discogs API for the marketplace statistics of a release.
+type MarketplaceStatsResponse struct {
+	RawResponse
+	ExtraFields
+	LowestPrice     *Price `json:"lowest_price,omitempty"`
+	NumForSale      *int64 `json:"num_for_sale,omitempty"`
+	BlockedFromSale bool   `json:"blocked_from_sale"`
+}
+
+// PriceSuggestionsResponse represents the response from the Discogs API for the price suggestions of a release. It
+// maps each condition to the suggested price for an item in that condition.
+type PriceSuggestionsResponse map[Condition]Price
+
+// ListingOptions represents the options for retrieving a marketplace listing.
+type ListingOptions struct {
+	CurrAbr Currency `url:"curr_abbr,omitempty"`
+}
+
+// Listing represents a marketplace listing.
+type Listing struct {
+	RawResponse
+	ExtraFields
+	ID              int64           `json:"id"`
+	Status          ListingStatus   `json:"status"`
+	Price           *Price          `json:"price,omitempty"`
+	OriginalPrice   *Price          `json:"original_price,omitempty"`
+	AllowOffers     bool            `json:"allow_offers"`
+	Condition       Condition       `json:"condition"`
+	SleeveCondition SleeveCondition `json:"sleeve_condition"`
+	ShipsFrom       string          `json:"ships_from"`
+	Posted          *Timestamp      `json:"posted,omitempty"`
+	Comments        string          `json:"comments"`
+	Seller          *MarketUser     `json:"seller,omitempty"`
+	Release         *ListingRelease `json:"release,omitempty"`
+	Audio           bool            `json:"audio"`
+	ResourceURL     string          `json:"resource_url"`
+	URI             string          `json:"uri"`
+	// The following fields are only returned to the seller of the listing.
+	ExternalID     string `json:"external_id,omitempty"`
+	Location       string `json:"location,omitempty"`
+	Weight         *int64 `json:"weight,omitempty"`
+	FormatQuantity *int64 `json:"format_quantity,omitempty"`
+}
+
+// InventoryResponse represents the response from the Discogs API for a user's inventory.
+type InventoryResponse struct {
+	RawResponse
+	ExtraFields
+	Pagination *Pagination `json:"pagination,omitempty"`
+	Listings   []Listing   `json:"listings"`
+}
+
+// ListingRelease represents the release of a marketplace listing.
+type ListingRelease struct {
+	ID            int64  `json:"id"`
+	Artist        string `json:"artist"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	Format        string `json:"format"`
+	CatalogNumber string `json:"catalog_number"`
+	Year          *int64 `json:"year,omitempty"`
+	Thumbnail     string `json:"thumbnail"`
+	ResourceURL   string `json:"resource_url"`
+}
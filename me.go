@@ -0,0 +1,112 @@
+package discogs
+
+import (
+	"context"
+	"sync"
+)
+
+// identityCache holds the identity of the authenticated user once it was fetched.
+type identityCache struct {
+	mu       sync.Mutex
+	identity *IdentityResponse
+}
+
+// CachedIdentity returns the identity of the authenticated user, fetching it with Identity on the first call only.
+// Concurrent first calls share a single request. Failed lookups are not cached, so the next call tries again.
+func (dc *DiscogsClient) CachedIdentity(ctx context.Context) (*IdentityResponse, error) {
+	dc.identity.mu.Lock()
+	defer dc.identity.mu.Unlock()
+
+	if dc.identity.identity != nil {
+		return dc.identity.identity, nil
+	}
+
+	identity, err := dc.Identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dc.identity.identity = identity
+	return identity, nil
+}
+
+// ForgetIdentity clears the cached identity, so the next call to CachedIdentity or to a "My" method fetches it again.
+// Call it after changing the credentials of the client, or after renaming the authenticated user.
+func (dc *DiscogsClient) ForgetIdentity() {
+	dc.identity.mu.Lock()
+	defer dc.identity.mu.Unlock()
+	dc.identity.identity = nil
+}
+
+// myUsername returns the username of the authenticated user, see CachedIdentity.
+func (dc *DiscogsClient) myUsername(ctx context.Context) (string, error) {
+	identity, err := dc.CachedIdentity(ctx)
+	if err != nil {
+		return "", err
+	}
+	return identity.Username, nil
+}
+
+// MyProfile fetches the profile of the authenticated user. See User for details.
+func (dc *DiscogsClient) MyProfile(ctx context.Context, opts ...RequestOption) (*UserResponse, error) {
+	username, err := dc.myUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dc.User(ctx, username, opts...)
+}
+
+// UpdateMyProfile updates the profile of the authenticated user. See UpdateUser for details.
+func (dc *DiscogsClient) UpdateMyProfile(ctx context.Context, update *UserUpdate, opts ...RequestOption) (*UserResponse, error) {
+	username, err := dc.myUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dc.UpdateUser(ctx, username, update, opts...)
+}
+
+// MyCollectionItems fetches a page of the items in a folder of the authenticated user's collection. See
+// CollectionItems for details.
+func (dc *DiscogsClient) MyCollectionItems(ctx context.Context, folderID int64, options *CollectionItemsOptions, opts ...RequestOption) (*CollectionItemsResponse, error) {
+	username, err := dc.myUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dc.CollectionItems(ctx, username, folderID, options, opts...)
+}
+
+// MyCollectionFields fetches the custom collection fields of the authenticated user. See CollectionFields for details.
+func (dc *DiscogsClient) MyCollectionFields(ctx context.Context, opts ...RequestOption) (*CollectionFieldsResponse, error) {
+	username, err := dc.myUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dc.CollectionFields(ctx, username, opts...)
+}
+
+// AddToMyCollectionFolder adds a release to a folder of the authenticated user's collection. See
+// AddToCollectionFolder for details.
+func (dc *DiscogsClient) AddToMyCollectionFolder(ctx context.Context, folderID, releaseID int64, opts ...RequestOption) (*AddToFolderResponse, error) {
+	username, err := dc.myUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dc.AddToCollectionFolder(ctx, username, folderID, releaseID, opts...)
+}
+
+// RateReleaseAsMe rates a release on behalf of the authenticated user. See UpdateReleaseRating for details.
+func (dc *DiscogsClient) RateReleaseAsMe(ctx context.Context, releaseID int64, rating int, opts ...RequestOption) (*UserReleaseRatingResponse, error) {
+	username, err := dc.myUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dc.UpdateReleaseRating(ctx, releaseID, username, rating, opts...)
+}
+
+// MyWantlist fetches a page of the releases in the authenticated user's wantlist. See Wantlist for details.
+func (dc *DiscogsClient) MyWantlist(ctx context.Context, options *PaginationParams, opts ...RequestOption) (*WantlistResponse, error) {
+	username, err := dc.myUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dc.Wantlist(ctx, username, options, opts...)
+}
@@ -0,0 +1,71 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_CachedIdentity(t *testing.T) {
+	var identityRequests atomic.Int64
+	var paths []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/oauth/identity" {
+			identityRequests.Add(1)
+			_, _ = rw.Write([]byte(`{"id":1,"username":"me"}`))
+			return
+		}
+
+		mu.Lock()
+		paths = append(paths, req.Method+" "+req.URL.Path)
+		mu.Unlock()
+		switch req.URL.Path {
+		case "/users/me":
+			_, _ = rw.Write([]byte(`{"username":"me"}`))
+		default:
+			_, _ = rw.Write([]byte(`{"username":"me","release_id":1,"rating":4}`))
+		}
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			identity, err := client.CachedIdentity(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, "me", identity.Username)
+		}()
+	}
+	wg.Wait()
+
+	profile, err := client.MyProfile(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "me", profile.Username)
+
+	rating, err := client.RateReleaseAsMe(ctx, 1, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, rating.Rating)
+
+	_, err = client.MyWantlist(ctx, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(1), identityRequests.Load())
+	assert.Equal(t, []string{"GET /users/me", "PUT /releases/1/rating/me", "GET /users/me/wants"}, paths)
+
+	client.ForgetIdentity()
+	_, err = client.CachedIdentity(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), identityRequests.Load())
+}
@@ -0,0 +1,41 @@
+package discogs
+
+import "context"
+
+// AcceptHeader is the header used to negotiate the media type of responses.
+const AcceptHeader = "Accept"
+
+// MediaType represents a media type the Discogs API can format text fields in, such as release notes and profiles.
+type MediaType string
+
+// MediaType constants representing the formats supported by the Discogs API.
+const (
+	// MediaTypeDiscogs returns text fields in Discogs markup, such as "[a=Artist]". It is the default of the API.
+	MediaTypeDiscogs MediaType = "application/vnd.discogs.v2.discogs+json"
+	// MediaTypeHTML returns text fields rendered as HTML.
+	MediaTypeHTML MediaType = "application/vnd.discogs.v2.html+json"
+	// MediaTypePlaintext returns text fields rendered as plain text.
+	MediaTypePlaintext MediaType = "application/vnd.discogs.v2.plaintext+json"
+)
+
+// mediaTypeKey is the context key for the media type of a request.
+type mediaTypeKey struct{}
+
+// WithMediaType returns a copy of ctx requesting responses in the given media type, overriding the MediaType of the
+// DiscogsConfig for the requests made with it.
+//
+// Example:
+//
+//	release, err := client.Release(discogs.WithMediaType(ctx, discogs.MediaTypeHTML), releaseID, nil)
+func WithMediaType(ctx context.Context, mediaType MediaType) context.Context {
+	return context.WithValue(ctx, mediaTypeKey{}, mediaType)
+}
+
+// mediaType returns the media type requested for a request made with ctx, or an empty MediaType if the API default
+// is used.
+func (dc *DiscogsClient) mediaType(ctx context.Context) MediaType {
+	if mediaType, ok := ctx.Value(mediaTypeKey{}).(MediaType); ok {
+		return mediaType
+	}
+	return dc.Config.MediaType
+}
@@ -0,0 +1,33 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_MediaType(t *testing.T) {
+	var accepts []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		accepts = append(accepts, req.Header.Get(discogs.AcceptHeader))
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{MediaType: discogs.MediaTypePlaintext, Cache: discogs.NewMemoryCache(0)})
+	client.Host = server.URL
+
+	assert.NoError(t, client.Get(ctx, "/releases/1", nil, nil, nil))
+	assert.NoError(t, client.Get(discogs.WithMediaType(ctx, discogs.MediaTypeHTML), "/releases/1", nil, nil, nil))
+	assert.NoError(t, client.Get(ctx, "/releases/1", nil, map[string]string{discogs.AcceptHeader: "application/json"}, nil))
+
+	// The cache keeps responses in different media types apart
+	assert.NoError(t, client.Get(ctx, "/releases/1", nil, nil, nil))
+
+	assert.Equal(t, []string{
+		string(discogs.MediaTypePlaintext), string(discogs.MediaTypeHTML), "application/json",
+	}, accepts)
+}
@@ -0,0 +1,94 @@
+package discogs
+
+import (
+	"context"
+	"strconv"
+)
+
+// FolderRef identifies a folder of a user's collection, along with the client used to access it.
+type FolderRef struct {
+	Client   *DiscogsClient
+	Username string
+	FolderID int64
+}
+
+// MirrorOptions represents the options for mirroring a collection folder.
+type MirrorOptions struct {
+	// DryRun computes the instances missing from the destination folder without adding them.
+	DryRun bool
+	// OnAdd is called after every instance added to the destination folder.
+	OnAdd func(item CollectionItem, added *AddToFolderResponse)
+}
+
+// MirrorResult represents the outcome of mirroring a collection folder.
+type MirrorResult struct {
+	// Missing are the instances of the source folder that were missing from the destination folder.
+	Missing []CollectionItem
+	// Added are the instances added to the destination folder, in the same order as Missing. It is empty for dry runs,
+	// and shorter than Missing if mirroring failed midway.
+	Added []AddToFolderResponse
+}
+
+// MirrorFolder replicates the contents of a collection folder into another folder, which can belong to another
+// account or to the same one. The folders are diffed first and only the missing instances are added: a release
+// appearing twice in the source folder and once in the destination folder is added once. Nothing is ever removed
+// from the destination folder. Custom field values are not copied, since fields differ between accounts. If options
+// is nil, the default options are used.
+//
+// Releases cannot be added to FolderAll, so an ErrInvalidOption is returned if it is the destination folder. If adding
+// an instance fails, the result so far is returned along with the error, so mirroring can be retried.
+func MirrorFolder(ctx context.Context, from, to FolderRef, options *MirrorOptions) (*MirrorResult, error) {
+	var opts MirrorOptions
+	if options != nil {
+		opts = *options
+	}
+
+	if to.FolderID == FolderAll {
+		return nil, &ErrInvalidOption{Option: "destination folder", Value: strconv.FormatInt(to.FolderID, 10)}
+	}
+
+	// Count the instances of every release in the destination folder
+	have := make(map[int64]int)
+	err := ForEachPage(ctx, to.Client.CollectionItemsPages(to.Username, to.FolderID, nil), &IteratorOptions{PerPage: DefaultCrawlPerPage}, func(items []CollectionItem, _ *Pagination) error {
+		for _, item := range items {
+			have[item.ID]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &MirrorResult{}
+	err = ForEachPage(ctx, from.Client.CollectionItemsPages(from.Username, from.FolderID, nil), &IteratorOptions{PerPage: DefaultCrawlPerPage}, func(items []CollectionItem, _ *Pagination) error {
+		for _, item := range items {
+			if have[item.ID] > 0 {
+				have[item.ID]--
+				continue
+			}
+			res.Missing = append(res.Missing, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun {
+		return res, nil
+	}
+
+	for _, item := range res.Missing {
+		added, err := to.Client.AddToCollectionFolder(ctx, to.Username, to.FolderID, item.ID)
+		if err != nil {
+			return res, err
+		}
+		res.Added = append(res.Added, *added)
+
+		if opts.OnAdd != nil {
+			opts.OnAdd(item, added)
+		}
+	}
+
+	return res, nil
+}
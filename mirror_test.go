@@ -0,0 +1,92 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCollectionServer creates a mock server holding the collection folders of a single user, keyed by folder ID.
+// Releases added to a folder are appended to it.
+func newCollectionServer(t *testing.T, username string, folders map[string][]int64) *httptest.Server {
+	var mu sync.Mutex
+	prefix := "/users/" + username + "/collection/folders/"
+
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		parts := strings.Split(strings.TrimPrefix(req.URL.Path, prefix), "/")
+		folder := parts[0]
+
+		var res interface{}
+		switch {
+		case req.Method == http.MethodGet && len(parts) == 2:
+			items := make([]discogs.CollectionItem, len(folders[folder]))
+			for i, id := range folders[folder] {
+				items[i] = discogs.CollectionItem{ID: id, InstanceID: int64(i + 1)}
+			}
+			res = discogs.CollectionItemsResponse{Pagination: &discogs.Pagination{Page: 1, Pages: 1}, Releases: items}
+		case req.Method == http.MethodPost && len(parts) == 3:
+			var id int64
+			_ = json.Unmarshal([]byte(parts[2]), &id)
+			folders[folder] = append(folders[folder], id)
+			res = discogs.AddToFolderResponse{InstanceID: int64(len(folders[folder]))}
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err := json.NewEncoder(rw).Encode(res); err != nil {
+			assert.FailNow(t, "failed to write the response body: %w", err)
+		}
+	}))
+}
+
+func TestMirrorFolder(t *testing.T) {
+	token := "token"
+
+	tests := []struct {
+		name    string
+		options *discogs.MirrorOptions
+		want    []int64
+	}{
+		{"MirrorFolder dry run", &discogs.MirrorOptions{DryRun: true}, []int64{3, 4}},
+		{"MirrorFolder adds missing instances", nil, []int64{3, 4, 1, 1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := newCollectionServer(t, "alice", map[string][]int64{"5": {1, 1, 2, 3}})
+			defer source.Close()
+			destinationFolders := map[string][]int64{"1": {3, 4}, "7": {1, 2}}
+			destination := newCollectionServer(t, "bob", destinationFolders)
+			defer destination.Close()
+
+			from := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+			from.Host = source.URL
+			to := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+			to.Host = destination.URL
+
+			res, err := discogs.MirrorFolder(ctx,
+				discogs.FolderRef{Client: from, Username: "alice", FolderID: 5},
+				discogs.FolderRef{Client: to, Username: "bob", FolderID: 1},
+				tt.options)
+			assert.NoError(t, err)
+
+			// Release 3 is already in the destination folder, while both instances of release 1 are missing
+			var missing []int64
+			for _, item := range res.Missing {
+				missing = append(missing, item.ID)
+			}
+			assert.Equal(t, []int64{1, 1, 2}, missing)
+			assert.Equal(t, tt.want, destinationFolders["1"])
+			assert.Equal(t, []int64{1, 2}, destinationFolders["7"])
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package discogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Amount represents an amount of money as an integer number of hundredths of the currency unit, such as cents, so
+// prices are added and compared exactly instead of accumulating floating point rounding errors. Every currency
+// accepted by the Discogs API has at most two decimal places.
+//
+// Amount(1250) is 12.50. Amounts are marshaled to and from JSON numbers, such as 12.5; numbers with more than two
+// decimal places are rounded half away from zero.
+type Amount int64
+
+// ErrInvalidAmount indicates that a string is not a decimal amount of money.
+type ErrInvalidAmount struct {
+	Amount string
+}
+
+func (e *ErrInvalidAmount) Error() string {
+	return fmt.Sprintf("invalid amount: %q", e.Amount)
+}
+
+// ParseAmount parses a decimal amount of money, such as "12.50", "-3" or "0.999". It does not go through a float, so
+// the amount is exact; digits beyond the second decimal place are rounded half away from zero. It returns an
+// ErrInvalidAmount if s is not a decimal number.
+func ParseAmount(s string) (Amount, error) {
+	digits := strings.TrimSpace(s)
+	negative := strings.HasPrefix(digits, "-")
+	digits = strings.TrimPrefix(strings.TrimPrefix(digits, "-"), "+")
+
+	units, fraction, _ := strings.Cut(digits, ".")
+	if units == "" && fraction == "" || !isDigits(units) || !isDigits(fraction) {
+		return 0, &ErrInvalidAmount{Amount: s}
+	}
+
+	cents := fraction + "00"
+	value, err := strconv.ParseInt(units+cents[:2], 10, 64)
+	if err != nil {
+		return 0, &ErrInvalidAmount{Amount: s}
+	}
+	if len(fraction) > 2 && fraction[2] >= '5' {
+		value++
+	}
+	if negative {
+		value = -value
+	}
+	return Amount(value), nil
+}
+
+// isDigits reports whether s only holds ASCII digits.
+func isDigits(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
+
+// AmountFromFloat returns the amount closest to f, rounded to the hundredth. It is meant for amounts computed as
+// floats elsewhere, such as by a pricing formula.
+func AmountFromFloat(f float64) Amount {
+	return Amount(math.Round(f * 100))
+}
+
+// Float64 returns the amount as a float, such as for display in a chart. The conversion may be inexact.
+func (a Amount) Float64() float64 {
+	return float64(a) / 100
+}
+
+// String returns the amount with two decimal places, such as "12.50" or "-3.00".
+func (a Amount) String() string {
+	return a.format(2)
+}
+
+// format returns the amount with the given number of decimal places, which is either 0 or 2. Amounts are rounded
+// half away from zero when decimals is 0.
+func (a Amount) format(decimals int) string {
+	sign := ""
+	cents := int64(a)
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	if decimals == 0 {
+		return sign + strconv.FormatInt((cents+50)/100, 10)
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON encodes the amount as a JSON number with two decimal places.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number, or a string holding one, into the amount.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	s := strings.Trim(string(data), `"`)
+	amount, err := ParseAmount(s)
+	if err != nil {
+		// Numbers in exponent notation are valid JSON but rare enough to go through a float
+		var f float64
+		if json.Unmarshal([]byte(s), &f) != nil {
+			return err
+		}
+		amount = AmountFromFloat(f)
+	}
+	*a = amount
+	return nil
+}
+
+// ErrCurrencyMismatch indicates that prices in different currencies were combined.
+type ErrCurrencyMismatch struct {
+	Left  Currency
+	Right Currency
+}
+
+func (e *ErrCurrencyMismatch) Error() string {
+	return fmt.Sprintf("currency mismatch: %s and %s", e.Left, e.Right)
+}
+
+// Add returns the sum of two prices. It returns an ErrCurrencyMismatch if the prices are in different currencies,
+// since Discogs does not provide exchange rates.
+func (p Price) Add(other Price) (Price, error) {
+	if p.Currency != other.Currency {
+		return Price{}, &ErrCurrencyMismatch{Left: p.Currency, Right: other.Currency}
+	}
+	return Price{Currency: p.Currency, Value: p.Value + other.Value}, nil
+}
+
+// Sub returns the difference of two prices. It returns an ErrCurrencyMismatch if the prices are in different
+// currencies.
+func (p Price) Sub(other Price) (Price, error) {
+	if p.Currency != other.Currency {
+		return Price{}, &ErrCurrencyMismatch{Left: p.Currency, Right: other.Currency}
+	}
+	return Price{Currency: p.Currency, Value: p.Value - other.Value}, nil
+}
+
+// Mul returns the price multiplied by a quantity, such as the total of several copies of an item.
+func (p Price) Mul(quantity int64) Price {
+	return Price{Currency: p.Currency, Value: p.Value * Amount(quantity)}
+}
+
+// String formats the price for display in its currency.
+//
+// Example: "€12.50".
+func (p Price) String() string {
+	return p.Currency.FormatAmount(p.Value)
+}
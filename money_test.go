@@ -0,0 +1,76 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAmount(t *testing.T) {
+	type want struct {
+		amount discogs.Amount
+		err    error
+	}
+	tests := []struct {
+		name string
+		args string
+		want want
+	}{
+		{"ParseAmount decimal", "12.50", want{1250, nil}},
+		{"ParseAmount units", "7", want{700, nil}},
+		{"ParseAmount one decimal", "0.1", want{10, nil}},
+		{"ParseAmount negative", "-3.2", want{-320, nil}},
+		{"ParseAmount rounds half up", "0.995", want{100, nil}},
+		{"ParseAmount rounds down", "2.994", want{299, nil}},
+		{"ParseAmount rounds negative away from zero", "-0.005", want{-1, nil}},
+		{"ParseAmount empty", "", want{0, &discogs.ErrInvalidAmount{Amount: ""}}},
+		{"ParseAmount not a number", "12,50", want{0, &discogs.ErrInvalidAmount{Amount: "12,50"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := discogs.ParseAmount(tt.args)
+			assert.Equal(t, tt.want.err, err)
+			assert.Equal(t, tt.want.amount, amount)
+		})
+	}
+}
+
+func TestAmount_JSON(t *testing.T) {
+	var price discogs.Price
+	assert.NoError(t, json.Unmarshal([]byte(`{"currency":"EUR","value":0.1}`), &price))
+	assert.Equal(t, discogs.Price{Currency: discogs.CurrencyEUR, Value: 10}, price)
+
+	// Adding amounts is exact, unlike 0.1 + 0.2 with floats
+	sum, err := price.Add(discogs.Price{Currency: discogs.CurrencyEUR, Value: 20})
+	assert.NoError(t, err)
+	marshaled, err := json.Marshal(sum)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"currency":"EUR","value":0.30}`, string(marshaled))
+	assert.Equal(t, "€0.30", sum.String())
+
+	var amount discogs.Amount
+	assert.NoError(t, json.Unmarshal([]byte(`"4.5"`), &amount))
+	assert.Equal(t, discogs.Amount(450), amount)
+	assert.NoError(t, json.Unmarshal([]byte(`1e2`), &amount))
+	assert.Equal(t, discogs.Amount(10000), amount)
+	assert.Error(t, json.Unmarshal([]byte(`"free"`), &amount))
+}
+
+func TestPrice_Arithmetic(t *testing.T) {
+	price := discogs.Price{Currency: discogs.CurrencyUSD, Value: 1999}
+
+	total := price.Mul(3)
+	assert.Equal(t, discogs.Price{Currency: discogs.CurrencyUSD, Value: 5997}, total)
+
+	diff, err := total.Sub(price)
+	assert.NoError(t, err)
+	assert.Equal(t, discogs.Amount(3998), diff.Value)
+
+	_, err = price.Add(discogs.Price{Currency: discogs.CurrencyEUR, Value: 100})
+	assert.Equal(t, &discogs.ErrCurrencyMismatch{Left: discogs.CurrencyUSD, Right: discogs.CurrencyEUR}, err)
+
+	assert.Equal(t, discogs.Amount(1235), discogs.AmountFromFloat(12.345))
+	assert.Equal(t, 19.99, price.Value.Float64())
+}
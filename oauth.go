@@ -0,0 +1,289 @@
+package discogs
+
+import (
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OAuthAuthorizeURL is the page of the Discogs website where users authorize an application to act on their behalf.
+const OAuthAuthorizeURL = "https://www.discogs.com/oauth/authorize"
+
+// OAuthCallbackOutOfBand is the callback URL of applications that cannot receive a redirect, such as command line
+// tools. Discogs then shows the verifier to the user, who enters it in the application.
+const OAuthCallbackOutOfBand = "oob"
+
+// ErrNoRequestToken indicates that Discogs did not return a request token.
+var ErrNoRequestToken = errors.New("no request token in response")
+
+// ErrNoAccessToken indicates that Discogs did not return an access token.
+var ErrNoAccessToken = errors.New("no access token in response")
+
+// RequestToken represents a temporary OAuth token, which the user authorizes on the Discogs website before it is
+// exchanged for an access token.
+type RequestToken struct {
+	Token  string
+	Secret string
+	// CallbackConfirmed reports whether Discogs accepted the callback URL.
+	CallbackConfirmed bool
+	// AuthorizeURL is the page the user is sent to, to authorize the application.
+	AuthorizeURL string
+}
+
+// GetRequestToken fetches a request token by sending a GET request to the /oauth/request_token endpoint, signed with
+// the consumer key and secret of the DiscogsConfig, which is the first step of the OAuth 1.0a flow. The callbackURL
+// parameter is where Discogs redirects the user after the authorization; OAuthCallbackOutOfBand is used if empty. The
+// context.Context provides control over the request's lifecycle. It returns an ErrMissingCredentials if the consumer
+// key or secret is not set.
+//
+// Documentation: https://www.discogs.com/developers#page:authentication,header:authentication-request-token-url
+func (dc *DiscogsClient) GetRequestToken(ctx context.Context, callbackURL string, opts ...RequestOption) (*RequestToken, error) {
+	endpoint := "/oauth/request_token"
+
+	creds, err := dc.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !creds.hasKeySecret() {
+		return nil, &ErrMissingCredentials{RequiredAuthType: AuthTypeKeySecret, Endpoint: endpoint}
+	}
+	if callbackURL == "" {
+		callbackURL = OAuthCallbackOutOfBand
+	}
+
+	options := requestOptionsFrom(WithRequestOptions(ctx, opts...))
+	ctx, cancel := options.withTimeout(withRoute(ctx, routePattern(endpoint, EndpointAuthMap)))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dc.Host+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := options.apply(req); err != nil {
+		return nil, err
+	}
+	req.Header.Set(UserAgentHeader, dc.Config.AppName)
+	dc.oauthSigner(Credentials{ConsumerKey: creds.ConsumerKey, ConsumerSecret: creds.ConsumerSecret}).sign(req, map[string]string{"oauth_callback": callbackURL})
+
+	_, responseBody, err := dc.send(ctx, req)
+	if err != nil {
+		dc.recordError(err)
+		return nil, err
+	}
+
+	values, err := url.ParseQuery(string(responseBody))
+	if err != nil {
+		return nil, err
+	}
+	if values.Get("oauth_token") == "" {
+		return nil, ErrNoRequestToken
+	}
+
+	return &RequestToken{
+		Token:             values.Get("oauth_token"),
+		Secret:            values.Get("oauth_token_secret"),
+		CallbackConfirmed: values.Get("oauth_callback_confirmed") == "true",
+		AuthorizeURL:      AuthorizeURL(values.Get("oauth_token")),
+	}, nil
+}
+
+// GetAccessToken exchanges a request token the user authorized for an access token by sending a POST request to the
+// /oauth/access_token endpoint, which is the last step of the OAuth 1.0a flow. The verifier is the oauth_verifier
+// parameter of the callback URL, or the code Discogs showed the user for OAuthCallbackOutOfBand. The access token does
+// not expire, so it is usually persisted, such as in a CredentialStore. The context.Context provides control over the
+// request's lifecycle. It returns an ErrMissingCredentials if the consumer key or secret is not set, or if requestToken
+// is nil or has no token.
+//
+// Documentation: https://www.discogs.com/developers#page:authentication,header:authentication-access-token-url
+func (dc *DiscogsClient) GetAccessToken(ctx context.Context, requestToken *RequestToken, verifier string, opts ...RequestOption) (*TokenSet, error) {
+	endpoint := "/oauth/access_token"
+
+	creds, err := dc.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !creds.hasKeySecret() {
+		return nil, &ErrMissingCredentials{RequiredAuthType: AuthTypeKeySecret, Endpoint: endpoint}
+	}
+	if requestToken == nil || requestToken.Token == "" {
+		return nil, &ErrMissingCredentials{RequiredAuthType: AuthTypeOAuth, Endpoint: endpoint}
+	}
+
+	options := requestOptionsFrom(WithRequestOptions(ctx, opts...))
+	ctx, cancel := options.withTimeout(withRoute(ctx, routePattern(endpoint, EndpointAuthMap)))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dc.Host+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := options.apply(req); err != nil {
+		return nil, err
+	}
+	req.Header.Set(UserAgentHeader, dc.Config.AppName)
+	dc.oauthSigner(Credentials{
+		ConsumerKey:       creds.ConsumerKey,
+		ConsumerSecret:    creds.ConsumerSecret,
+		AccessToken:       requestToken.Token,
+		AccessTokenSecret: requestToken.Secret,
+	}).sign(req, map[string]string{"oauth_verifier": verifier})
+
+	_, responseBody, err := dc.send(ctx, req)
+	if err != nil {
+		dc.recordError(err)
+		return nil, err
+	}
+
+	values, err := url.ParseQuery(string(responseBody))
+	if err != nil {
+		return nil, err
+	}
+	if values.Get("oauth_token") == "" {
+		return nil, ErrNoAccessToken
+	}
+
+	return &TokenSet{AccessToken: values.Get("oauth_token"), AccessTokenSecret: values.Get("oauth_token_secret")}, nil
+}
+
+// AuthorizeURL returns the page of the Discogs website where the user authorizes the request token, so web
+// applications can redirect users to it without hardcoding Discogs URLs.
+//
+// Example: "https://www.discogs.com/oauth/authorize?oauth_token=abc".
+func AuthorizeURL(requestToken string) string {
+	return OAuthAuthorizeURL + "?" + url.Values{"oauth_token": {requestToken}}.Encode()
+}
+
+// OAuthSignatureMethod represents the method used to sign OAuth 1.0a requests.
+type OAuthSignatureMethod string
+
+// OAuthSignatureMethod constants representing the signature methods supported by Discogs. PLAINTEXT sends the secrets
+// themselves, so it relies on HTTPS; HMAC-SHA1 only sends a signature of the request.
+const (
+	OAuthSignaturePlaintext OAuthSignatureMethod = "PLAINTEXT"
+	OAuthSignatureHMACSHA1  OAuthSignatureMethod = "HMAC-SHA1"
+)
+
+// oauthSigner signs requests with the OAuth 1.0a credentials of a consumer and, once authorized, of a user.
+type oauthSigner struct {
+	method         OAuthSignatureMethod
+	consumerKey    string
+	consumerSecret string
+	token          string
+	tokenSecret    string
+}
+
+// oauthSigner returns a signer using the credentials and the signature method of the DiscogsConfig. The access token
+// and its secret are empty before the user authorized the application.
+func (dc *DiscogsClient) oauthSigner(creds Credentials) *oauthSigner {
+	return &oauthSigner{
+		method:         cmp.Or(dc.Config.OAuthSignatureMethod, OAuthSignaturePlaintext),
+		consumerKey:    creds.ConsumerKey,
+		consumerSecret: creds.ConsumerSecret,
+		token:          creds.AccessToken,
+		tokenSecret:    creds.AccessTokenSecret,
+	}
+}
+
+// sign sets the Authorization header of req to the OAuth parameters and the signature of the request, with a fresh
+// nonce and timestamp. The extra parameters, such as the callback URL, are sent and signed along with them.
+func (s *oauthSigner) sign(req *http.Request, extra map[string]string) {
+	params := map[string]string{
+		"oauth_consumer_key":     s.consumerKey,
+		"oauth_signature_method": string(s.method),
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_nonce":            oauthNonce(),
+		"oauth_version":          "1.0",
+	}
+	if s.token != "" {
+		params["oauth_token"] = s.token
+	}
+	for name, value := range extra {
+		params[name] = value
+	}
+	params["oauth_signature"] = s.signature(req, params)
+	req.Header.Set(AuthHeader, oauthHeader(params))
+}
+
+// oauthSignerKey is the context key for the signer of a request signed with OAuth 1.0a.
+type oauthSignerKey struct{}
+
+// signAttempt signs req again with a fresh nonce and timestamp if it is signed with OAuth 1.0a, right before it is
+// sent, so retried and hedged attempts are not rejected as replays nor carry a timestamp that went stale while waiting.
+func signAttempt(req *http.Request) {
+	if signer, ok := req.Context().Value(oauthSignerKey{}).(*oauthSigner); ok {
+		signer.sign(req, nil)
+	}
+}
+
+// signature returns the signature of a request with the OAuth parameters, as described in section 3.4 of RFC 5849.
+func (s *oauthSigner) signature(req *http.Request, params map[string]string) string {
+	signingKey := oauthEscape(s.consumerSecret) + "&" + oauthEscape(s.tokenSecret)
+	if s.method != OAuthSignatureHMACSHA1 {
+		return signingKey
+	}
+
+	// The query parameters are signed along with the OAuth parameters; request bodies are JSON, so they are not
+	var pairs [][2]string
+	for name, value := range params {
+		pairs = append(pairs, [2]string{oauthEscape(name), oauthEscape(value)})
+	}
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			pairs = append(pairs, [2]string{oauthEscape(name), oauthEscape(value)})
+		}
+	}
+	slices.SortFunc(pairs, func(a, b [2]string) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	})
+	normalized := make([]string, len(pairs))
+	for i, pair := range pairs {
+		normalized[i] = pair[0] + "=" + pair[1]
+	}
+
+	baseURL := url.URL{Scheme: strings.ToLower(req.URL.Scheme), Host: strings.ToLower(req.URL.Host), Path: req.URL.Path}
+	base := req.Method + "&" + oauthEscape(baseURL.String()) + "&" + oauthEscape(strings.Join(normalized, "&"))
+
+	mac := hmac.New(sha1.New, []byte(signingKey))
+	mac.Write([]byte(base))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// oauthHeader returns the value of the Authorization header holding the OAuth parameters, sorted by name so the
+// header is deterministic.
+func oauthHeader(params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + oauthEscape(params[name]) + `"`
+	}
+	return "OAuth " + strings.Join(pairs, ", ")
+}
+
+// oauthEscape percent-encodes s as required by OAuth 1.0a, which only leaves letters, digits and "-._~" unescaped.
+func oauthEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// oauthNonce returns a random string that is unique to a request, so Discogs can reject replayed requests.
+func oauthNonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,205 @@
+package discogs_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_GetRequestToken(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/oauth/request_token", req.URL.Path)
+		assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+		header = req.Header.Get(discogs.AuthHeader)
+		_, _ = rw.Write([]byte("oauth_token=request-token&oauth_token_secret=request-secret&oauth_callback_confirmed=true"))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret})
+	client.Host = server.URL
+
+	token, err := client.GetRequestToken(ctx, "https://example.com/callback?a=1")
+	assert.NoError(t, err)
+	assert.Equal(t, &discogs.RequestToken{
+		Token:             "request-token",
+		Secret:            "request-secret",
+		CallbackConfirmed: true,
+		AuthorizeURL:      "https://www.discogs.com/oauth/authorize?oauth_token=request-token",
+	}, token)
+
+	assert.Regexp(t, regexp.MustCompile(`^OAuth `+
+		`oauth_callback="https%3A%2F%2Fexample.com%2Fcallback%3Fa%3D1", `+
+		`oauth_consumer_key="`+key+`", `+
+		`oauth_nonce="[0-9a-f]{32}", `+
+		`oauth_signature="`+secret+`%26", `+
+		`oauth_signature_method="PLAINTEXT", `+
+		`oauth_timestamp="[0-9]+", `+
+		`oauth_version="1.0"$`), header)
+
+	_, err = discogs.NewDiscogsClient(&discogs.DiscogsConfig{}).GetRequestToken(ctx, "")
+	assert.IsType(t, &discogs.ErrMissingCredentials{}, err)
+}
+
+func TestAuthorizeURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{"token", "abc", "https://www.discogs.com/oauth/authorize?oauth_token=abc"},
+		{"escaped", "a b&c", "https://www.discogs.com/oauth/authorize?oauth_token=a+b%26c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, discogs.AuthorizeURL(tt.token))
+		})
+	}
+}
+
+// oauthParams parses the parameters of an OAuth Authorization header.
+func oauthParams(t *testing.T, header string) map[string]string {
+	params := map[string]string{}
+	for _, pair := range strings.Split(strings.TrimPrefix(header, "OAuth "), ", ") {
+		name, value, _ := strings.Cut(pair, "=")
+		value, err := url.PathUnescape(strings.Trim(value, `"`))
+		assert.NoError(t, err)
+		params[name] = value
+	}
+	return params
+}
+
+func TestDiscogsClient_OAuthSigning(t *testing.T) {
+	tests := []struct {
+		name   string
+		method discogs.OAuthSignatureMethod
+		verify func(t *testing.T, req *http.Request, params map[string]string)
+	}{
+		{
+			name:   "plaintext by default",
+			method: "",
+			verify: func(t *testing.T, req *http.Request, params map[string]string) {
+				assert.Equal(t, "PLAINTEXT", params["oauth_signature_method"])
+				assert.Equal(t, secret+"&token-secret", params["oauth_signature"])
+			},
+		},
+		{
+			name:   "hmac-sha1",
+			method: discogs.OAuthSignatureHMACSHA1,
+			verify: func(t *testing.T, req *http.Request, params map[string]string) {
+				assert.Equal(t, "HMAC-SHA1", params["oauth_signature_method"])
+
+				var pairs []string
+				for name, value := range params {
+					if name != "oauth_signature" {
+						pairs = append(pairs, name+"="+url.QueryEscape(value))
+					}
+				}
+				for name, values := range req.URL.Query() {
+					pairs = append(pairs, name+"="+strings.ReplaceAll(url.QueryEscape(values[0]), "+", "%20"))
+				}
+				sort.Strings(pairs)
+				base := "GET&" + url.QueryEscape("http://"+req.Host+req.URL.Path) + "&" +
+					url.QueryEscape(strings.Join(pairs, "&"))
+
+				mac := hmac.New(sha1.New, []byte(secret+"&token-secret"))
+				mac.Write([]byte(base))
+				assert.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), params["oauth_signature"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				params := oauthParams(t, req.Header.Get(discogs.AuthHeader))
+				assert.Equal(t, key, params["oauth_consumer_key"])
+				assert.Equal(t, "token", params["oauth_token"])
+				assert.NotEmpty(t, params["oauth_nonce"])
+				assert.NotEmpty(t, params["oauth_timestamp"])
+				tt.verify(t, req, params)
+				_, _ = rw.Write([]byte(`{"id":1,"username":"me"}`))
+			}))
+			defer server.Close()
+
+			token, tokenSecret := "token", "token-secret"
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+				ConsumerKey:          &key,
+				ConsumerSecret:       &secret,
+				AccessToken:          &token,
+				AccessTokenSecret:    &tokenSecret,
+				OAuthSignatureMethod: tt.method,
+			})
+			client.Host = server.URL
+
+			_, err := client.Wantlist(ctx, "me", &discogs.PaginationParams{Page: discogs.Int(2), PerPage: discogs.Int(50)})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDiscogsClient_OAuthSigningPerAttempt(t *testing.T) {
+	var nonces []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		nonces = append(nonces, oauthParams(t, req.Header.Get(discogs.AuthHeader))["oauth_nonce"])
+		if len(nonces) < 3 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	token, tokenSecret := "token", "token-secret"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+		ConsumerKey:       &key,
+		ConsumerSecret:    &secret,
+		AccessToken:       &token,
+		AccessTokenSecret: &tokenSecret,
+		MaxRetries:        2,
+		RetryWait:         time.Millisecond,
+	})
+	client.Host = server.URL
+
+	_, err := client.Wantlist(ctx, "me", nil)
+	assert.NoError(t, err)
+	assert.Len(t, nonces, 3)
+	assert.NotEqual(t, nonces[0], nonces[1])
+	assert.NotEqual(t, nonces[1], nonces[2])
+}
+
+func TestDiscogsClient_GetAccessToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/oauth/access_token", req.URL.Path)
+
+		params := oauthParams(t, req.Header.Get(discogs.AuthHeader))
+		assert.Equal(t, "request-token", params["oauth_token"])
+		assert.Equal(t, "verifier", params["oauth_verifier"])
+		assert.Equal(t, secret+"&request-secret", params["oauth_signature"])
+		_, _ = rw.Write([]byte("oauth_token=access-token&oauth_token_secret=access-secret"))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret})
+	client.Host = server.URL
+
+	tokens, err := client.GetAccessToken(ctx, &discogs.RequestToken{Token: "request-token", Secret: "request-secret"}, "verifier")
+	assert.NoError(t, err)
+	assert.Equal(t, &discogs.TokenSet{AccessToken: "access-token", AccessTokenSecret: "access-secret"}, tokens)
+
+	_, err = client.GetAccessToken(ctx, nil, "verifier")
+	assert.Equal(t, &discogs.ErrMissingCredentials{RequiredAuthType: discogs.AuthTypeOAuth, Endpoint: "/oauth/access_token"}, err)
+}
@@ -0,0 +1,135 @@
+package discogs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// OrderChange represents an order that was placed or changed since the previous poll of an OrderWatcher.
+type OrderChange struct {
+	Order Order
+	// New reports whether the order was not known to the watcher before.
+	New bool
+	// PreviousStatus is the status the order had at the previous poll. It is empty for new orders.
+	PreviousStatus OrderStatus
+}
+
+// OrderWatcherOptions represents the options for an OrderWatcher.
+type OrderWatcherOptions struct {
+	// Interval is the interval between two polls. DefaultWatchInterval is used if unset.
+	Interval time.Duration
+	// Status restricts the watched orders to a status. All orders are watched if unset.
+	Status OrderStatus
+	// OnChange is called for every order placed or changed since the previous poll.
+	OnChange func(change OrderChange)
+	// OnError is called when Run fails to poll the orders.
+	OnError func(err error)
+	// Feed receives an EventOrderChanged event for every change, after OnChange is called.
+	Feed *EventFeed
+}
+
+// An OrderWatcher periodically polls the orders of the authenticated seller and reports the orders placed or changed
+// since the previous poll, based on their last activity. The first poll only records the current orders, so existing
+// orders are not reported as new. Every poll goes through the client, so it respects the rate limit.
+//
+// Orders require OAuth or a personal access token. An OrderWatcher is safe for concurrent use.
+type OrderWatcher struct {
+	client  *DiscogsClient
+	options OrderWatcherOptions
+
+	mu sync.Mutex
+	// initialized reports whether the first poll recorded the current orders.
+	initialized bool
+	// lastActivity is the latest activity seen across all orders, or the zero time if no order was seen yet.
+	lastActivity time.Time
+	// lastIDs holds the IDs of the orders whose last activity is lastActivity, so orders sharing that timestamp are
+	// reported once.
+	lastIDs map[string]bool
+	// statuses holds the last known status of every order seen, keyed by order ID.
+	statuses map[string]OrderStatus
+}
+
+// NewOrderWatcher creates a new OrderWatcher that polls orders using the DiscogsClient. If options is nil, the default
+// options are used.
+func (dc *DiscogsClient) NewOrderWatcher(options *OrderWatcherOptions) *OrderWatcher {
+	w := &OrderWatcher{client: dc, statuses: make(map[string]OrderStatus)}
+	if options != nil {
+		w.options = *options
+	}
+	if w.options.Interval <= 0 {
+		w.options.Interval = DefaultWatchInterval
+	}
+	return w
+}
+
+// Run polls the orders every Interval until ctx is canceled, and then returns the context's error.
+func (w *OrderWatcher) Run(ctx context.Context) error {
+	return pollEvery(ctx, w.options.Interval, func(ctx context.Context) error {
+		_, err := w.Poll(ctx)
+		return err
+	}, w.options.OnError)
+}
+
+// Poll fetches the orders with activity since the previous poll, reports their changes and returns them. Orders are
+// fetched by descending last activity, so only the pages holding new activity are requested.
+func (w *OrderWatcher) Poll(ctx context.Context) ([]OrderChange, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	options := &OrdersOptions{Status: w.options.Status, Sort: OrderSortLastActivity, SortOrder: SortOrderDesc}
+	baseline := !w.initialized
+
+	var changed []Order
+	err := ForEachPage(ctx, w.client.OrdersPages(options), &IteratorOptions{PerPage: DefaultCrawlPerPage}, func(orders []Order, _ *Pagination) error {
+		for _, order := range orders {
+			if !baseline {
+				activity := order.GetLastActivity().Time
+				if activity.Before(w.lastActivity) {
+					return ErrStopPaging
+				}
+				if activity.Equal(w.lastActivity) && w.lastIDs[order.ID] {
+					continue
+				}
+			}
+			changed = append(changed, order)
+		}
+		// The first poll only needs the latest activity and the status of the recent orders
+		if baseline {
+			return ErrStopPaging
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	w.initialized = true
+
+	var changes []OrderChange
+	for _, order := range changed {
+		previous, known := w.statuses[order.ID]
+		w.statuses[order.ID] = order.Status
+		switch activity := order.GetLastActivity().Time; {
+		case activity.After(w.lastActivity) || w.lastIDs == nil:
+			w.lastActivity = activity
+			w.lastIDs = map[string]bool{order.ID: true}
+		case activity.Equal(w.lastActivity):
+			w.lastIDs[order.ID] = true
+		}
+		if !baseline {
+			changes = append(changes, OrderChange{Order: order, New: !known, PreviousStatus: previous})
+		}
+	}
+
+	for i := range changes {
+		if w.options.OnChange != nil {
+			w.options.OnChange(changes[i])
+		}
+		if err := w.options.Feed.Publish(ctx, Event{Kind: EventOrderChanged, Order: &changes[i]}); err != nil {
+			return changes, err
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,97 @@
+package discogs_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderWatcher_Poll(t *testing.T) {
+	polls := []string{
+		`[{"id":"1-2","status":"New Order","last_activity":"2024-01-02T10:00:00-08:00"},
+		  {"id":"1-1","status":"Shipped","last_activity":"2024-01-01T10:00:00-08:00"}]`,
+		`[{"id":"1-3","status":"New Order","last_activity":"2024-01-03T10:00:00-08:00"},
+		  {"id":"1-2","status":"Payment Received","last_activity":"2024-01-02T12:00:00-08:00"},
+		  {"id":"1-1","status":"Shipped","last_activity":"2024-01-01T10:00:00-08:00"}]`,
+	}
+
+	var poll int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "last_activity", req.URL.Query().Get("sort"))
+		assert.Equal(t, "desc", req.URL.Query().Get("sort_order"))
+
+		_, _ = fmt.Fprintf(rw, `{"pagination":{"page":1,"pages":1},"orders":%s}`, polls[poll])
+		poll++
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	feed := discogs.NewEventFeed()
+	sub := feed.Subscribe(4)
+	defer sub.Close()
+
+	watcher := client.NewOrderWatcher(&discogs.OrderWatcherOptions{Feed: feed})
+
+	changes, err := watcher.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, changes, "the first poll only records the orders")
+
+	changes, err = watcher.Poll(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, "1-3", changes[0].Order.ID)
+		assert.True(t, changes[0].New)
+		assert.Equal(t, "1-2", changes[1].Order.ID)
+		assert.False(t, changes[1].New)
+		assert.Equal(t, discogs.OrderStatusNewOrder, changes[1].PreviousStatus)
+	}
+
+	event := <-sub.Events()
+	assert.Equal(t, discogs.EventOrderChanged, event.Kind)
+	assert.Equal(t, "1-3", event.Order.Order.ID)
+}
+
+func TestOrderWatcher_PollAfterEmptyBaseline(t *testing.T) {
+	polls := []string{
+		`[]`,
+		`[{"id":"1-1","status":"New Order","last_activity":"2024-01-01T10:00:00-08:00"}]`,
+		`[{"id":"1-2","status":"New Order","last_activity":"2024-01-01T10:00:00-08:00"},
+		  {"id":"1-1","status":"New Order","last_activity":"2024-01-01T10:00:00-08:00"}]`,
+	}
+
+	var poll int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprintf(rw, `{"pagination":{"page":1,"pages":1},"orders":%s}`, polls[poll])
+		poll++
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	watcher := client.NewOrderWatcher(nil)
+
+	changes, err := watcher.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, err = watcher.Poll(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 1, "the first order after an empty baseline is reported") {
+		assert.Equal(t, "1-1", changes[0].Order.ID)
+	}
+
+	changes, err = watcher.Poll(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 1, "an order sharing the last activity of a reported order is reported once") {
+		assert.Equal(t, "1-2", changes[0].Order.ID)
+	}
+}
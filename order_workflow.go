@@ -0,0 +1,60 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+)
+
+// ErrWorkflowIncomplete indicates that a seller workflow changed the status of an order, but failed to message the
+// buyer afterwards. The status change is not rolled back, so the message can be sent again with AddOrderMessage.
+type ErrWorkflowIncomplete struct {
+	OrderID string
+	Status  OrderStatus
+	Err     error
+}
+
+func (e *ErrWorkflowIncomplete) Error() string {
+	return fmt.Sprintf("order %s was set to %q but the buyer was not messaged: %v", e.OrderID, e.Status, e.Err)
+}
+
+// Unwrap returns the error of the message.
+func (e *ErrWorkflowIncomplete) Unwrap() error {
+	return e.Err
+}
+
+// ShipOrder marks an order of the authenticated seller as shipped and messages the buyer, such as with the tracking
+// number of the parcel. The status is changed first, and checked against the next statuses of the order, so the buyer
+// is not told about a shipment the order does not allow. The message is skipped if empty.
+//
+// It returns the updated order. If the status was changed but the message failed, the updated order is returned
+// along with an ErrWorkflowIncomplete.
+func (dc *DiscogsClient) ShipOrder(ctx context.Context, orderID, message string, opts ...RequestOption) (*Order, error) {
+	return dc.orderWorkflow(ctx, orderID, OrderStatusShipped, message, opts)
+}
+
+// RefundOrder marks an order of the authenticated seller as refunded and messages the buyer, such as with the reason
+// of the refund. The refund itself is sent through the payment provider of the order; Discogs only records it. The
+// order is checked and updated the same way as in ShipOrder.
+//
+// It returns the updated order. If the status was changed but the message failed, the updated order is returned
+// along with an ErrWorkflowIncomplete.
+func (dc *DiscogsClient) RefundOrder(ctx context.Context, orderID, message string, opts ...RequestOption) (*Order, error) {
+	return dc.orderWorkflow(ctx, orderID, OrderStatusRefundSent, message, opts)
+}
+
+// orderWorkflow changes the status of an order, and then messages the buyer unless message is empty. The options apply
+// to both writes, but not to the lookup of the order made by UpdateOrder.
+func (dc *DiscogsClient) orderWorkflow(ctx context.Context, orderID string, status OrderStatus, message string, opts []RequestOption) (*Order, error) {
+	order, err := dc.UpdateOrder(ctx, orderID, &UpdateOrderRequest{Status: &status}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if message != "" {
+		if _, err := dc.AddOrderMessage(ctx, orderID, message, opts...); err != nil {
+			return order, &ErrWorkflowIncomplete{OrderID: orderID, Status: status, Err: err}
+		}
+	}
+
+	return order, nil
+}
@@ -0,0 +1,62 @@
+package discogs_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_ShipOrder(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		requests = append(requests, req.Method+" "+req.URL.Path+" "+string(body))
+
+		switch {
+		case req.URL.Path == "/marketplace/orders/1-2/messages":
+			rw.WriteHeader(http.StatusInternalServerError)
+			_, _ = rw.Write([]byte(`{"message":"An internal server error occurred."}`))
+		case req.URL.Path == "/marketplace/orders/1-1/messages":
+			_, _ = rw.Write([]byte(`{"subject":"Discogs Order #1-1","message":"Tracking: 123"}`))
+		case req.Method == http.MethodPost:
+			_, _ = rw.Write([]byte(`{"id":"1-1","status":"Shipped","next_status":["Refund Sent"]}`))
+		default:
+			_, _ = rw.Write([]byte(`{"id":"1-1","status":"Payment Received","next_status":["Shipped"]}`))
+		}
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	order, err := client.ShipOrder(ctx, "1-1", "Tracking: 123")
+	assert.NoError(t, err)
+	assert.Equal(t, discogs.OrderStatusShipped, order.Status)
+
+	// The status must be one of the next statuses, so nothing is sent
+	_, err = client.RefundOrder(ctx, "1-1", "Sorry, the record was damaged.")
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "status", Value: "Refund Sent"}, err)
+
+	// A failed message is reported along with the updated order
+	order, err = client.ShipOrder(ctx, "1-2", "Tracking: 456")
+	assert.NotNil(t, order)
+	var incomplete *discogs.ErrWorkflowIncomplete
+	if assert.ErrorAs(t, err, &incomplete) {
+		assert.Equal(t, discogs.OrderStatusShipped, incomplete.Status)
+	}
+
+	assert.Equal(t, []string{
+		"GET /marketplace/orders/1-1 ",
+		`POST /marketplace/orders/1-1 {"status":"Shipped"}`,
+		`POST /marketplace/orders/1-1/messages {"message":"Tracking: 123"}`,
+		"GET /marketplace/orders/1-1 ",
+		"GET /marketplace/orders/1-2 ",
+		`POST /marketplace/orders/1-2 {"status":"Shipped"}`,
+		`POST /marketplace/orders/1-2/messages {"message":"Tracking: 456"}`,
+	}, requests)
+}
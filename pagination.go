@@ -0,0 +1,223 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrStopPaging can be returned by the callback passed to ForEachPage to stop paging without returning an error.
+var ErrStopPaging = errors.New("stop paging")
+
+// PageFetcher fetches a single page of results from a paginated endpoint. It returns the items of the page along with
+// the pagination information of the response.
+type PageFetcher[T any] func(ctx context.Context, page PaginationParams) ([]T, *Pagination, error)
+
+// IteratorOptions represents the options for iterating over a paginated endpoint.
+type IteratorOptions struct {
+	// PerPage is the number of items requested per page. The Discogs API default of 50 is used if unset.
+	PerPage int
+	// StartPage is the first page that is fetched. Iteration starts at page 1 if unset.
+	StartPage int
+	// Prefetch fetches the next page in the background while the current page is being consumed. Prefetched requests
+	// go through the same client, so they still respect the rate limit.
+	Prefetch bool
+}
+
+// pageResult holds the outcome of fetching a single page.
+type pageResult[T any] struct {
+	items      []T
+	pagination *Pagination
+	err        error
+}
+
+// An Iterator iterates over all items of a paginated endpoint, fetching pages as needed. An Iterator is not safe for
+// concurrent use.
+//
+// Example:
+//
+//	it := discogs.NewIterator(fetch, nil)
+//	defer it.Close()
+//	for it.Next(ctx) {
+//		item := it.Value()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type Iterator[T any] struct {
+	fetch   PageFetcher[T]
+	options IteratorOptions
+
+	items      []T
+	index      int
+	page       int
+	skip       int
+	pagination *Pagination
+	done       bool
+	err        error
+
+	prefetched chan pageResult[T]
+	cancel     context.CancelFunc
+}
+
+// NewIterator creates a new Iterator that fetches pages using fetch. If options is nil, the default options are used.
+func NewIterator[T any](fetch PageFetcher[T], options *IteratorOptions) *Iterator[T] {
+	it := &Iterator[T]{fetch: fetch, index: -1}
+	if options != nil {
+		it.options = *options
+	}
+	it.page = max(it.options.StartPage, 1)
+	return it
+}
+
+// Next advances the iterator to the next item, fetching the next page if the current one is exhausted. It returns
+// false when there are no more items or an error occurred, in which case Err returns the error.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index+1 >= len(it.items) {
+		if it.done {
+			return false
+		}
+
+		res := it.nextPage(ctx)
+		if res.err != nil {
+			it.err = res.err
+			return false
+		}
+
+		it.items = res.items
+		it.index = -1
+		if it.skip > 0 {
+			// Resume from the offset of a cursor
+			it.index = min(it.skip, len(res.items)) - 1
+			it.skip = 0
+		}
+		it.pagination = res.pagination
+		it.page++
+
+		if res.pagination == nil || int64(it.page) > res.pagination.Pages || len(res.items) == 0 {
+			it.done = true
+		} else if it.options.Prefetch {
+			it.startPrefetch(ctx)
+		}
+	}
+
+	it.index++
+	return true
+}
+
+// Value returns the current item. It is only valid after a call to Next that returned true.
+func (it *Iterator[T]) Value() T {
+	if it.index < 0 || it.index >= len(it.items) {
+		var zero T
+		return zero
+	}
+	return it.items[it.index]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Pagination returns the pagination information of the most recently fetched page.
+func (it *Iterator[T]) Pagination() *Pagination {
+	return it.pagination
+}
+
+// Close stops any background prefetching, and waits for it to return. It should be called when an iterator is
+// abandoned before it is exhausted.
+func (it *Iterator[T]) Close() {
+	if it.cancel != nil {
+		it.cancel()
+		it.cancel = nil
+	}
+	if it.prefetched != nil {
+		// The prefetch goroutine returns once it sent its result
+		<-it.prefetched
+		it.prefetched = nil
+	}
+	it.done = true
+}
+
+// nextPage returns the next page, either from a pending prefetch or by fetching it directly.
+func (it *Iterator[T]) nextPage(ctx context.Context) pageResult[T] {
+	if it.prefetched != nil {
+		prefetched := it.prefetched
+		it.prefetched = nil
+
+		select {
+		case res := <-prefetched:
+			it.cancel()
+			it.cancel = nil
+			return res
+		case <-ctx.Done():
+			it.cancel()
+			it.cancel = nil
+			return pageResult[T]{err: ctx.Err()}
+		}
+	}
+	return it.fetchPage(ctx, it.page)
+}
+
+// startPrefetch fetches the next page in the background.
+func (it *Iterator[T]) startPrefetch(ctx context.Context) {
+	prefetchCtx, cancel := context.WithCancel(ctx)
+	it.cancel = cancel
+	it.prefetched = make(chan pageResult[T], 1)
+
+	go func(page int, prefetched chan<- pageResult[T]) {
+		prefetched <- it.fetchPage(prefetchCtx, page)
+	}(it.page, it.prefetched)
+}
+
+// fetchPage fetches the given page.
+func (it *Iterator[T]) fetchPage(ctx context.Context, page int) pageResult[T] {
+	params := PaginationParams{Page: Int(page)}
+	if it.options.PerPage > 0 {
+		params.PerPage = Int(it.options.PerPage)
+	}
+
+	items, pagination, err := it.fetch(ctx, params)
+	return pageResult[T]{items: items, pagination: pagination, err: err}
+}
+
+// ForEachPage fetches every page using fetch and passes the items of each page to fn, one page at a time. Unlike an
+// Iterator, no page is fetched ahead, so a page can be released as soon as fn returns. This keeps memory usage flat
+// when processing very large listings. The Prefetch field of options is ignored.
+//
+// Paging stops when fn returns an error, which is returned by ForEachPage unless it is ErrStopPaging.
+func ForEachPage[T any](ctx context.Context, fetch PageFetcher[T], options *IteratorOptions, fn func(items []T, pagination *Pagination) error) error {
+	var iterOptions IteratorOptions
+	if options != nil {
+		iterOptions = *options
+	}
+
+	for page := max(iterOptions.StartPage, 1); ; page++ {
+		params := PaginationParams{Page: Int(page)}
+		if iterOptions.PerPage > 0 {
+			params.PerPage = Int(iterOptions.PerPage)
+		}
+
+		items, pagination, err := fetch(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if len(items) > 0 {
+			if err := fn(items, pagination); err != nil {
+				if errors.Is(err, ErrStopPaging) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if pagination == nil || int64(page) >= pagination.Pages || len(items) == 0 {
+			return nil
+		}
+	}
+}
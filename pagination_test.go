@@ -0,0 +1,217 @@
+package discogs_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedFetcher returns a PageFetcher serving items 1 through total in pages of perPage items. The pages it was
+// asked for are recorded in fetched.
+func pagedFetcher(total, perPage int, fetched *[]int, mu *sync.Mutex) discogs.PageFetcher[int] {
+	pages := (total + perPage - 1) / perPage
+	return func(ctx context.Context, params discogs.PaginationParams) ([]int, *discogs.Pagination, error) {
+		page := discogs.IntValue(params.Page)
+
+		mu.Lock()
+		*fetched = append(*fetched, page)
+		mu.Unlock()
+
+		var items []int
+		for i := (page-1)*perPage + 1; i <= min(page*perPage, total); i++ {
+			items = append(items, i)
+		}
+		return items, &discogs.Pagination{Page: int64(page), Pages: int64(pages), Items: int64(total), PerPage: int64(perPage)}, nil
+	}
+}
+
+func TestIterator(t *testing.T) {
+	type args struct {
+		total   int
+		perPage int
+		options *discogs.IteratorOptions
+	}
+	type want struct {
+		items   []int
+		fetched []int
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"Iterator no results",
+			args{0, 2, nil},
+			want{nil, []int{1}},
+		},
+		{
+			"Iterator multiple pages",
+			args{5, 2, nil},
+			want{[]int{1, 2, 3, 4, 5}, []int{1, 2, 3}},
+		},
+		{
+			"Iterator with start page",
+			args{5, 2, &discogs.IteratorOptions{StartPage: 2}},
+			want{[]int{3, 4, 5}, []int{2, 3}},
+		},
+		{
+			"Iterator with prefetch",
+			args{5, 2, &discogs.IteratorOptions{Prefetch: true}},
+			want{[]int{1, 2, 3, 4, 5}, []int{1, 2, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var fetched []int
+
+			it := discogs.NewIterator(pagedFetcher(tt.args.total, tt.args.perPage, &fetched, &mu), tt.args.options)
+			defer it.Close()
+
+			var items []int
+			for it.Next(ctx) {
+				items = append(items, it.Value())
+			}
+
+			assert.NoError(t, it.Err())
+			assert.Equal(t, tt.want.items, items)
+			assert.Equal(t, tt.want.fetched, fetched)
+		})
+	}
+}
+
+func TestIterator_Error(t *testing.T) {
+	fetchErr := errors.New("fetch failed")
+	it := discogs.NewIterator(func(ctx context.Context, params discogs.PaginationParams) ([]int, *discogs.Pagination, error) {
+		if discogs.IntValue(params.Page) == 2 {
+			return nil, nil, fetchErr
+		}
+		return []int{1}, &discogs.Pagination{Page: 1, Pages: 3}, nil
+	}, &discogs.IteratorOptions{Prefetch: true})
+	defer it.Close()
+
+	var items []int
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+
+	assert.Equal(t, []int{1}, items)
+	assert.ErrorIs(t, it.Err(), fetchErr)
+	assert.False(t, it.Next(ctx))
+}
+
+func TestDiscogsClient_SearchIter(t *testing.T) {
+	results := []discogs.SearchResult{{Title: "First"}, {Title: "Second"}, {Title: "Third"}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "Test Search", req.URL.Query().Get("track"))
+		assert.Equal(t, "1", req.URL.Query().Get("per_page"))
+
+		page, err := strconv.Atoi(req.URL.Query().Get("page"))
+		if err != nil {
+			assert.FailNow(t, "invalid page parameter: %v", err)
+		}
+
+		responseBody, err := json.Marshal(discogs.SearchResponse{
+			Pagination: &discogs.Pagination{Page: int64(page), Pages: int64(len(results)), PerPage: 1},
+			Results:    results[page-1 : page],
+		})
+		if err != nil {
+			assert.FailNow(t, "unable to marshal json response: %w", err)
+		}
+
+		if _, err := rw.Write(responseBody); err != nil {
+			assert.FailNow(t, "failed to write the response body: %w", err)
+		}
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret})
+	client.Host = server.URL
+
+	it := client.SearchIter(&discogs.SearchOptions{Track: "Test Search"}, &discogs.IteratorOptions{PerPage: 1, Prefetch: true})
+	defer it.Close()
+
+	var got []discogs.SearchResult
+	for it.Next(ctx) {
+		got = append(got, it.Value())
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, results, got)
+}
+
+func TestForEachPage(t *testing.T) {
+	callbackErr := errors.New("callback failed")
+
+	type args struct {
+		total   int
+		options *discogs.IteratorOptions
+		// stopAt is the page at which the callback returns err.
+		stopAt int
+		err    error
+	}
+	type want struct {
+		pages   [][]int
+		fetched []int
+		err     error
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"ForEachPage no results",
+			args{0, nil, 0, nil},
+			want{nil, []int{1}, nil},
+		},
+		{
+			"ForEachPage all pages",
+			args{5, &discogs.IteratorOptions{PerPage: 2}, 0, nil},
+			want{[][]int{{1, 2}, {3, 4}, {5}}, []int{1, 2, 3}, nil},
+		},
+		{
+			"ForEachPage stopped",
+			args{5, &discogs.IteratorOptions{PerPage: 2}, 2, discogs.ErrStopPaging},
+			want{[][]int{{1, 2}, {3, 4}}, []int{1, 2}, nil},
+		},
+		{
+			"ForEachPage callback error",
+			args{5, &discogs.IteratorOptions{PerPage: 2, StartPage: 2}, 2, callbackErr},
+			want{[][]int{{3, 4}}, []int{2}, callbackErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var fetched []int
+			perPage := 2
+			if tt.args.options != nil {
+				perPage = tt.args.options.PerPage
+			}
+
+			var pages [][]int
+			err := discogs.ForEachPage(ctx, pagedFetcher(tt.args.total, perPage, &fetched, &mu), tt.args.options, func(items []int, pagination *discogs.Pagination) error {
+				pages = append(pages, items)
+				if int(pagination.Page) == tt.args.stopAt {
+					return tt.args.err
+				}
+				return nil
+			})
+
+			assert.ErrorIs(t, err, tt.want.err)
+			assert.Equal(t, tt.want.pages, pages)
+			assert.Equal(t, tt.want.fetched, fetched)
+		})
+	}
+}
@@ -4,14 +4,17 @@ package discogs
 //
 // See https://www.discogs.com/developers#page:home,header:home-pagination
 type Pagination struct {
-	Page    int64 `json:"page"`
-	Pages   int64 `json:"pages"`
-	Items   int64 `json:"items"`
-	PerPage int64 `json:"per_page"`
-	Urls    *struct {
-		First string `json:"first"`
-		Prev  string `json:"prev"`
-		Next  string `json:"next"`
-		Last  string `json:"last"`
-	} `json:"urls"`
+	Page    int64           `json:"page"`
+	Pages   int64           `json:"pages"`
+	Items   int64           `json:"items"`
+	PerPage int64           `json:"per_page"`
+	Urls    *PaginationURLs `json:"urls,omitempty"`
+}
+
+// PaginationURLs represents the URLs of the neighboring pages of a paginated response.
+type PaginationURLs struct {
+	First string `json:"first"`
+	Prev  string `json:"prev"`
+	Next  string `json:"next"`
+	Last  string `json:"last"`
 }
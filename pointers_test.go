@@ -0,0 +1,40 @@
+package discogs_test
+
+import (
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointerHelpers(t *testing.T) {
+	assert.Equal(t, "test", *discogs.String("test"))
+	assert.Equal(t, 1, *discogs.Int(1))
+	assert.Equal(t, int64(1), *discogs.Int64(1))
+	assert.Equal(t, 1.5, *discogs.Float64(1.5))
+	assert.Equal(t, true, *discogs.Bool(true))
+}
+
+func TestPointerValueHelpers(t *testing.T) {
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"StringValue nil", discogs.StringValue(nil), ""},
+		{"StringValue set", discogs.StringValue(discogs.String("test")), "test"},
+		{"IntValue nil", discogs.IntValue(nil), 0},
+		{"IntValue set", discogs.IntValue(discogs.Int(1)), 1},
+		{"Int64Value nil", discogs.Int64Value(nil), int64(0)},
+		{"Int64Value set", discogs.Int64Value(discogs.Int64(1)), int64(1)},
+		{"Float64Value nil", discogs.Float64Value(nil), float64(0)},
+		{"Float64Value set", discogs.Float64Value(discogs.Float64(1.5)), 1.5},
+		{"BoolValue nil", discogs.BoolValue(nil), false},
+		{"BoolValue set", discogs.BoolValue(discogs.Bool(true)), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package discogs
+
+import (
+	"context"
+	"net/url"
+	"sync"
+)
+
+// Priority represents the priority of a request when the rate limit is reached. Requests waiting for the rate limiter
+// of a host are sent by decreasing priority, and in the order they were made for the same priority.
+type Priority int
+
+// Priority constants representing common priorities. Any other value can be used to order requests more finely.
+const (
+	// PriorityLow is meant for background jobs, such as bulk imports and crawls, which can wait for the quota.
+	PriorityLow Priority = -10
+	// PriorityNormal is the priority of requests made without WithPriority.
+	PriorityNormal Priority = 0
+	// PriorityHigh is meant for interactive requests, such as the lookups of a user waiting for a page.
+	PriorityHigh Priority = 10
+)
+
+// priorityKey is the context key for the priority of a request.
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx giving the requests made with it the given priority, so they are sent before
+// requests of lower priority while the quota is scarce.
+//
+// Example:
+//
+//	release, err := client.Release(discogs.WithPriority(ctx, discogs.PriorityHigh), releaseID, nil)
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// requestPriority returns the priority of a request made with ctx.
+func requestPriority(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityNormal
+}
+
+// requestScheduler lets the requests to a host wait for its rate limiter one at a time, by decreasing priority. Since
+// the limiter grants tokens in the order they are asked for, the request waiting for the limiter holds the turn, and
+// the others queue until it is released.
+type requestScheduler struct {
+	mu      sync.Mutex
+	busy    bool
+	waiting []*schedulerTicket // in the order the requests were made
+}
+
+// schedulerTicket represents a request queued by a requestScheduler. ready is closed when the request gets the turn.
+type schedulerTicket struct {
+	priority Priority
+	ready    chan struct{}
+}
+
+// acquire waits for the turn of a request of the given priority, or returns an error if ctx is done first. The turn
+// must be released once the request was allowed by the rate limiter.
+func (s *requestScheduler) acquire(ctx context.Context, priority Priority) error {
+	s.mu.Lock()
+	if !s.busy {
+		s.busy = true
+		s.mu.Unlock()
+		return nil
+	}
+	ticket := &schedulerTicket{priority: priority, ready: make(chan struct{})}
+	s.waiting = append(s.waiting, ticket)
+	s.mu.Unlock()
+
+	select {
+	case <-ticket.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		for i, t := range s.waiting {
+			if t == ticket {
+				s.waiting = append(s.waiting[:i], s.waiting[i+1:]...)
+				s.mu.Unlock()
+				return ctx.Err()
+			}
+		}
+		s.mu.Unlock()
+		// The turn was given while ctx was done, so it is passed on
+		s.release()
+		return ctx.Err()
+	}
+}
+
+// release gives the turn to the waiting request of highest priority, which has waited the longest among requests of
+// the same priority.
+func (s *requestScheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.waiting) == 0 {
+		s.busy = false
+		return
+	}
+	next := 0
+	for i, t := range s.waiting {
+		if t.priority > s.waiting[next].priority {
+			next = i
+		}
+	}
+	ticket := s.waiting[next]
+	s.waiting = append(s.waiting[:next], s.waiting[next+1:]...)
+	close(ticket.ready)
+}
+
+// waitRateLimit waits until the rate limiter of the host of u allows a request made with ctx, letting requests of
+// higher priority go first.
+func (dc *DiscogsClient) waitRateLimit(ctx context.Context, u *url.URL) error {
+	limiter := dc.limiterFor(u)
+	if limiter == Limiter(unlimited) {
+		return dc.waitLimiter(ctx, limiter)
+	}
+
+	scheduler := dc.schedulerFor(u)
+	if err := scheduler.acquire(ctx, requestPriority(ctx)); err != nil {
+		return err
+	}
+	defer scheduler.release()
+	return dc.waitLimiter(ctx, limiter)
+}
+
+// hostScheduler returns the request scheduler for the Host of the DiscogsClient.
+func (dc *DiscogsClient) hostScheduler() *requestScheduler {
+	u, err := url.Parse(dc.Host)
+	if err != nil {
+		u = &url.URL{Host: dc.Host}
+	}
+	return dc.schedulerFor(u)
+}
+
+// schedulerFor returns the request scheduler for the host of u, creating it on first use.
+func (dc *DiscogsClient) schedulerFor(u *url.URL) *requestScheduler {
+	key := u.Scheme + "://" + u.Host
+
+	hosts := dc.hostRegistry()
+	hosts.mu.Lock()
+	defer hosts.mu.Unlock()
+
+	scheduler, ok := hosts.schedulers[key]
+	if !ok {
+		scheduler = &requestScheduler{}
+		if hosts.schedulers == nil {
+			hosts.schedulers = make(map[string]*requestScheduler)
+		}
+		hosts.schedulers[key] = scheduler
+	}
+	return scheduler
+}
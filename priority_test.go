@@ -0,0 +1,76 @@
+package discogs_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestDiscogsClient_WithPriority(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		paths = append(paths, req.URL.Path)
+		mu.Unlock()
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+		NewLimiter: func(string, int) discogs.Limiter { return rate.NewLimiter(rate.Every(50*time.Millisecond), 1) },
+	})
+	client.Host = server.URL
+
+	// The first request uses the burst, so the next ones queue for the limiter
+	assert.NoError(t, client.Get(ctx, "/releases/1", nil, nil, nil))
+
+	var wg sync.WaitGroup
+	get := func(ctx context.Context, path string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.Get(ctx, path, nil, nil, nil))
+		}()
+	}
+	get(discogs.WithPriority(ctx, discogs.PriorityLow), "/releases/2")
+	get(discogs.WithPriority(ctx, discogs.PriorityLow), "/releases/2")
+	time.Sleep(10 * time.Millisecond)
+	get(discogs.WithPriority(ctx, discogs.PriorityHigh), "/releases/3")
+	wg.Wait()
+
+	// The first low priority request was already waiting for the limiter, the high priority one goes before the
+	// second
+	assert.Equal(t, []string{"/releases/1", "/releases/2", "/releases/3", "/releases/2"}, paths)
+}
+
+func TestDiscogsClient_WithPriorityCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+		NewLimiter: func(string, int) discogs.Limiter { return rate.NewLimiter(rate.Every(100*time.Millisecond), 1) },
+	})
+	client.Host = server.URL
+	assert.NoError(t, client.Get(ctx, "/releases/1", nil, nil, nil))
+
+	done := make(chan error)
+	go func() { done <- client.Get(ctx, "/releases/2", nil, nil, nil) }()
+	time.Sleep(10 * time.Millisecond)
+
+	// A queued request whose context is done leaves the queue without blocking the others
+	cancelCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, client.Get(cancelCtx, "/releases/3", nil, nil, nil), context.DeadlineExceeded)
+	assert.NoError(t, <-done)
+	assert.NoError(t, client.Get(ctx, "/releases/4", nil, nil, nil))
+}
@@ -0,0 +1,109 @@
+package discogs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ProfileChange represents a change of the profile of a user between two polls of a ProfileWatcher.
+type ProfileChange struct {
+	Previous *UserResponse
+	Current  *UserResponse
+}
+
+// ProfileWatcherOptions represents the options for a ProfileWatcher.
+type ProfileWatcherOptions struct {
+	// Interval is the interval between two polls. DefaultWatchInterval is used if unset.
+	Interval time.Duration
+	// OnChange is called for every change of the profile.
+	OnChange func(change ProfileChange)
+	// OnError is called when Run fails to poll the profile.
+	OnError func(err error)
+	// Feed receives an EventProfileChanged event for every change, after OnChange is called.
+	Feed *EventFeed
+}
+
+// A ProfileWatcher periodically polls the profile of a user and reports its changes, such as edits of the profile or
+// changes of the collection, wantlist and inventory counts. The first poll only records the profile. Every poll goes
+// through the client, so it respects the rate limit.
+//
+// A ProfileWatcher is safe for concurrent use.
+type ProfileWatcher struct {
+	client   *DiscogsClient
+	username string
+	options  ProfileWatcherOptions
+
+	mu      sync.Mutex
+	profile *UserResponse
+}
+
+// NewProfileWatcher creates a new ProfileWatcher that polls the profile of a user using the DiscogsClient. If options
+// is nil, the default options are used.
+func (dc *DiscogsClient) NewProfileWatcher(username string, options *ProfileWatcherOptions) *ProfileWatcher {
+	w := &ProfileWatcher{client: dc, username: username}
+	if options != nil {
+		w.options = *options
+	}
+	if w.options.Interval <= 0 {
+		w.options.Interval = DefaultWatchInterval
+	}
+	return w
+}
+
+// Run polls the profile every Interval until ctx is canceled, and then returns the context's error.
+func (w *ProfileWatcher) Run(ctx context.Context) error {
+	return pollEvery(ctx, w.options.Interval, func(ctx context.Context) error {
+		_, err := w.Poll(ctx)
+		return err
+	}, w.options.OnError)
+}
+
+// Poll fetches the profile once and reports and returns its change since the previous poll, or nil if it did not
+// change.
+func (w *ProfileWatcher) Poll(ctx context.Context) (*ProfileChange, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	profile, err := w.client.User(ctx, w.username)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := w.profile
+	w.profile = profile
+	if previous == nil || newProfileState(previous) == newProfileState(profile) {
+		return nil, nil
+	}
+
+	change := &ProfileChange{Previous: previous, Current: profile}
+	if w.options.OnChange != nil {
+		w.options.OnChange(*change)
+	}
+	if err := w.options.Feed.Publish(ctx, Event{Kind: EventProfileChanged, Profile: change}); err != nil {
+		return change, err
+	}
+	return change, nil
+}
+
+// profileState holds the fields of a profile compared by a ProfileWatcher.
+type profileState struct {
+	name, email, homePage, location, profile string
+	currAbr                                  Currency
+	rank, ratingAvg                          float64
+	numCollection, numWantlist, numForSale   int64
+	numLists, releasesContributed            int64
+	releasesRated                            int64
+	avatarURL, bannerURL                     string
+}
+
+// newProfileState returns the compared fields of a profile.
+func newProfileState(u *UserResponse) profileState {
+	return profileState{
+		name: u.Name, email: u.Email, homePage: u.HomePage, location: u.Location, profile: u.Profile,
+		currAbr: u.CurrAbr, rank: u.Rank, ratingAvg: u.RatingAvg,
+		numCollection: u.NumCollection, numWantlist: u.NumWantlist, numForSale: u.NumForSale,
+		numLists: u.NumLists, releasesContributed: u.ReleasesContributed, releasesRated: u.ReleasesRated,
+		avatarURL: u.AvatarURL, bannerURL: u.BannerURL,
+	}
+}
@@ -0,0 +1,43 @@
+package discogs_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileWatcher_Poll(t *testing.T) {
+	wantlistSizes := []int{10, 10, 11}
+
+	var poll int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/users/user", req.URL.Path)
+
+		_, _ = fmt.Fprintf(rw, `{"username":"user","num_wantlist":%d}`, wantlistSizes[poll])
+		poll++
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	var changes []discogs.ProfileChange
+	watcher := client.NewProfileWatcher("user", &discogs.ProfileWatcherOptions{
+		OnChange: func(change discogs.ProfileChange) { changes = append(changes, change) },
+	})
+
+	for range wantlistSizes {
+		_, err := watcher.Poll(ctx)
+		assert.NoError(t, err)
+	}
+
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, int64(10), changes[0].Previous.NumWantlist)
+		assert.Equal(t, int64(11), changes[0].Current.NumWantlist)
+	}
+}
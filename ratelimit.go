@@ -0,0 +1,301 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// A Limiter spaces out the requests sent to a host. Every host has its own Limiter, which the DiscogsClient adjusts to
+// the rate limit reported by Discogs. *rate.Limiter is the default implementation; other implementations can share a
+// limit across processes, or disable limiting. Implementations must be safe for concurrent use.
+//
+// Limiters that also have the Limit, Burst, Tokens and SetBurst methods of *rate.Limiter are reported by Limit, Tokens
+// and Diagnostics, have their burst adjusted along with their limit, and can be used with AdaptiveRateLimit and
+// HedgeDelay.
+type Limiter interface {
+	// Wait blocks until a request can be sent, or returns an error if ctx is done first.
+	Wait(ctx context.Context) error
+	// SetLimit sets the number of requests allowed per second.
+	SetLimit(limit rate.Limit)
+}
+
+// limiterState is implemented by limiters that report their state, like *rate.Limiter.
+type limiterState interface {
+	Limit() rate.Limit
+	Burst() int
+	Tokens() float64
+}
+
+// burstSetter is implemented by limiters whose burst can be adjusted, like *rate.Limiter.
+type burstSetter interface {
+	SetBurst(burst int)
+}
+
+// rateLimitUpdate represents a change of the limit of a rate limiter, in requests per minute.
+type rateLimitUpdate struct {
+	old, new int
+}
+
+// setLimit sets the limit of a limiter, and its burst if it has one. It returns the change of the limit, to be
+// reported with reportRateLimitUpdates.
+func setLimit(limiter Limiter, limit rate.Limit, burst int) rateLimitUpdate {
+	old := limiterLimit(limiter)
+	limiter.SetLimit(limit)
+	if setter, ok := limiter.(burstSetter); ok {
+		setter.SetBurst(burst)
+	}
+	return rateLimitUpdate{old: requestsPerMinute(old), new: requestsPerMinute(limit)}
+}
+
+// requestsPerMinute converts a limit to a number of requests per minute, or 0 if unlimited.
+func requestsPerMinute(limit rate.Limit) int {
+	if limit == rate.Inf {
+		return 0
+	}
+	return int(math.Round(float64(limit) * time.Minute.Seconds()))
+}
+
+// reportRateLimitUpdates calls the OnRateLimitUpdate hook for the updates that changed a limit. It must be called
+// without holding dc.mu, so the hook can use the client.
+func (dc *DiscogsClient) reportRateLimitUpdates(updates ...rateLimitUpdate) {
+	if dc.Config.OnRateLimitUpdate == nil {
+		return
+	}
+	for _, update := range updates {
+		if update != (rateLimitUpdate{}) && update.old != update.new {
+			dc.Config.OnRateLimitUpdate(update.old, update.new)
+		}
+	}
+}
+
+// waitLimiter waits until the limiter allows a request, calling the OnRateLimitWait hook if the request is delayed:
+// before waiting if the limiter reports its state, and after waiting otherwise.
+func (dc *DiscogsClient) waitLimiter(ctx context.Context, limiter Limiter) error {
+	hook := dc.Config.OnRateLimitWait
+	if hook == nil {
+		return limiter.Wait(ctx)
+	}
+
+	state, ok := limiter.(limiterState)
+	if !ok {
+		start := time.Now()
+		err := limiter.Wait(ctx)
+		if waited := time.Since(start); err == nil && waited >= time.Millisecond {
+			hook(waited)
+		}
+		return err
+	}
+
+	if tokens, limit := state.Tokens(), state.Limit(); tokens < 1 && limit > 0 && limit != rate.Inf {
+		hook(time.Duration((1 - tokens) / float64(limit) * float64(time.Second)))
+	}
+	return limiter.Wait(ctx)
+}
+
+// limiterLimit returns the limit of a limiter, or 0 if it does not report it.
+func limiterLimit(limiter Limiter) rate.Limit {
+	if state, ok := limiter.(limiterState); ok {
+		return state.Limit()
+	}
+	return 0
+}
+
+// limiterTokens returns the number of requests a limiter allows without waiting, or 0 if it does not report it.
+func limiterTokens(limiter Limiter) float64 {
+	if state, ok := limiter.(limiterState); ok {
+		return state.Tokens()
+	}
+	return 0
+}
+
+// limiterBurst returns the burst of a limiter, or 0 if it does not report it.
+func limiterBurst(limiter Limiter) int {
+	if state, ok := limiter.(limiterState); ok {
+		return state.Burst()
+	}
+	return 0
+}
+
+// unlimited is the rate limiter used for hosts that are not rate limited.
+var unlimited = rate.NewLimiter(rate.Inf, 0)
+
+// newRateLimiter creates the rate limiter of a host for the configuration, using the NewLimiter hook if set. It
+// allows MaxRequests requests per minute if set, and otherwise the Discogs API limit for authenticated or
+// unauthenticated requests, scaled down by WarmUpFraction if set.
+func newRateLimiter(config *DiscogsConfig, host string) Limiter {
+	requestsPerMinute := config.MaxRequests
+	if requestsPerMinute <= 0 {
+		if config.ConsumerKey != nil && config.ConsumerSecret != nil {
+			requestsPerMinute = RateLimitAuth
+		} else {
+			requestsPerMinute = RateLimitUnauth
+		}
+	}
+	requestsPerMinute = warmUpStart(config, requestsPerMinute)
+	if config.NewLimiter != nil {
+		return config.NewLimiter(host, requestsPerMinute)
+	}
+	return rate.NewLimiter(rate.Every(time.Minute/time.Duration(requestsPerMinute)), requestsPerMinute)
+}
+
+// hostKey returns the key identifying the host of rawURL in the rate limiters of a DiscogsClient.
+func hostKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// hostRegistry holds the rate limiters and request schedulers of the hosts requests are sent to, creating them on
+// first use. Clients sharing a rate limit, such as the clients of a SessionManager with ShareRateLimit, share a
+// registry, so hosts first used by any of them are shared as well.
+type hostRegistry struct {
+	mu           sync.Mutex
+	rateLimiters map[string]Limiter
+	schedulers   map[string]*requestScheduler
+}
+
+// hostRegistry returns the registry of the hosts of the DiscogsClient, creating it on first use.
+func (dc *DiscogsClient) hostRegistry() *hostRegistry {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if dc.hosts == nil {
+		dc.hosts = &hostRegistry{}
+	}
+	return dc.hosts
+}
+
+// hostLimiter returns the rate limiter for the Host of the DiscogsClient.
+func (dc *DiscogsClient) hostLimiter() Limiter {
+	u, err := url.Parse(dc.Host)
+	if err != nil {
+		u = &url.URL{Host: dc.Host}
+	}
+	return dc.limiterFor(u)
+}
+
+// limiterFor returns the rate limiter for the host of u, creating it on first use. Requests to hosts other than
+// BaseURL are not rate limited if DisableCustomHostRateLimit is set.
+func (dc *DiscogsClient) limiterFor(u *url.URL) Limiter {
+	key := u.Scheme + "://" + u.Host
+	if key != hostKey(BaseURL) && dc.Config.DisableCustomHostRateLimit {
+		return unlimited
+	}
+
+	hosts := dc.hostRegistry()
+	hosts.mu.Lock()
+	defer hosts.mu.Unlock()
+
+	limiter, ok := hosts.rateLimiters[key]
+	if !ok {
+		limiter = newRateLimiter(&dc.Config, key)
+		if hosts.rateLimiters == nil {
+			hosts.rateLimiters = make(map[string]Limiter)
+		}
+		hosts.rateLimiters[key] = limiter
+	}
+	return limiter
+}
+
+// ErrRateLimited indicates that Discogs rejected a request because the rate limit was exceeded. It wraps the
+// HTTPError of the 429 response, so it is also matched by errors.As with an *HTTPError.
+type ErrRateLimited struct {
+	// RetryAfter is the time to wait before sending another request, or 0 if the response did not tell.
+	RetryAfter time.Duration
+	Err        *HTTPError
+}
+
+func (e *ErrRateLimited) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited, retry after %s: %v", e.RetryAfter, e.Err)
+	}
+	return fmt.Sprintf("rate limited: %v", e.Err)
+}
+
+func (e *ErrRateLimited) Unwrap() error {
+	return e.Err
+}
+
+// statusError returns the error of a response whose status code is not 2xx: an ErrRateLimited for a 429 response,
+// and an HTTPError otherwise.
+func statusError(response *http.Response, body []byte) error {
+	httpErr := &HTTPError{StatusCode: response.StatusCode, Message: string(body)}
+	if response.StatusCode != http.StatusTooManyRequests {
+		return httpErr
+	}
+	return &ErrRateLimited{RetryAfter: retryAfter(response.Header, time.Now()), Err: httpErr}
+}
+
+// retryAfter returns the time to wait before the next request according to the headers of a 429 response. The
+// Retry-After header is used if set, either as a number of seconds or as a date. Otherwise, since Discogs limits
+// requests over a moving minute, the time for one request to leave the window is estimated from the
+// X-Discogs-Ratelimit header. It returns 0 if neither header is set.
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return max(date.Sub(now), 0)
+		}
+	}
+	if limit, err := strconv.Atoi(header.Get(RateLimitHeader)); err == nil && limit > 0 {
+		return time.Minute / time.Duration(limit)
+	}
+	return 0
+}
+
+// RateLimitStatus represents the rate limit quota reported by the headers of the last response from the Discogs API.
+type RateLimitStatus struct {
+	// Limit is the number of requests allowed in a moving minute.
+	Limit int
+	// Used is the number of requests made in the current minute.
+	Used int
+	// Remaining is the number of requests that can still be made in the current minute.
+	Remaining int
+	// ObservedAt is the time the response was received, or the zero time if no response reported a quota yet.
+	ObservedAt time.Time
+}
+
+// RateLimitStatus returns the rate limit quota reported by the last response with rate limit headers, so applications
+// can display the quota or schedule work around it. Discogs counts requests over a moving minute, so the quota is
+// only accurate shortly after ObservedAt.
+func (dc *DiscogsClient) RateLimitStatus() RateLimitStatus {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	return dc.rateLimitStatus
+}
+
+// recordRateLimitStatus stores the quota reported by the rate limit headers of a response, if it has any. Headers
+// that are missing or cannot be parsed keep the value reported by a previous response.
+func (dc *DiscogsClient) recordRateLimitStatus(res *http.Response) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	status := dc.rateLimitStatus
+	var observed bool
+	for header, value := range map[string]*int{
+		RateLimitHeader:          &status.Limit,
+		RateLimitUsedHeader:      &status.Used,
+		RateLimitRemainingHeader: &status.Remaining,
+	} {
+		if n, err := strconv.Atoi(res.Header.Get(header)); err == nil && n >= 0 {
+			*value = n
+			observed = true
+		}
+	}
+	if observed {
+		status.ObservedAt = time.Now()
+		dc.rateLimitStatus = status
+	}
+}
@@ -0,0 +1,238 @@
+package discogs_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestDiscogsClient_PerHostRateLimit(t *testing.T) {
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set(discogs.RateLimitHeader, "10")
+		_, _ = rw.Write([]byte(`{"success":true}`))
+	})
+	first := httptest.NewServer(handler)
+	defer first.Close()
+	second := httptest.NewServer(handler)
+	defer second.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{MaxRequests: 5})
+	defaultLimit := client.Limit()
+
+	client.Host = first.URL
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, client.Get(ctx, "/test", nil, nil, nil))
+	}
+	assert.Less(t, client.Tokens(), float64(3))
+
+	client.Host = second.URL
+	assert.InDelta(t, float64(5), client.Tokens(), 0.1)
+
+	client.Host = discogs.BaseURL
+	assert.Equal(t, defaultLimit, client.Limit())
+	assert.InDelta(t, float64(5), client.Tokens(), 0.1)
+}
+
+func TestDiscogsClient_DisableCustomHostRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set(discogs.RateLimitHeader, "1")
+		_, _ = rw.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{MaxRequests: 1, DisableCustomHostRateLimit: true})
+	client.Host = server.URL
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, client.Get(ctx, "/test", nil, nil, nil))
+	}
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, rate.Inf, client.Limit())
+
+	client.Host = discogs.BaseURL
+	assert.Equal(t, rate.Every(time.Minute), client.Limit())
+}
+
+func TestDiscogsClient_RateLimitStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/releases/1":
+			rw.Header().Set(discogs.RateLimitHeader, "60")
+			rw.Header().Set(discogs.RateLimitUsedHeader, "12")
+			rw.Header().Set(discogs.RateLimitRemainingHeader, "48")
+		case "/releases/3":
+			rw.Header().Set(discogs.RateLimitHeader, "unknown")
+			rw.Header().Set(discogs.RateLimitUsedHeader, "13")
+			rw.Header().Set(discogs.RateLimitRemainingHeader, "")
+		}
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+	assert.True(t, client.RateLimitStatus().ObservedAt.IsZero())
+
+	before := time.Now()
+	assert.NoError(t, client.Get(ctx, "/releases/1", nil, nil, nil))
+	status := client.RateLimitStatus()
+	assert.Equal(t, 60, status.Limit)
+	assert.Equal(t, 12, status.Used)
+	assert.Equal(t, 48, status.Remaining)
+	assert.False(t, status.ObservedAt.Before(before))
+
+	// Responses without rate limit headers keep the last status
+	assert.NoError(t, client.Get(ctx, "/releases/2", nil, nil, nil))
+	assert.Equal(t, status, client.RateLimitStatus())
+
+	// Headers that cannot be parsed keep their last value
+	assert.NoError(t, client.Get(ctx, "/releases/3", nil, nil, nil))
+	status = client.RateLimitStatus()
+	assert.Equal(t, 60, status.Limit)
+	assert.Equal(t, 13, status.Used)
+	assert.Equal(t, 48, status.Remaining)
+}
+
+// countingLimiter is a Limiter that never waits, and records its calls.
+type countingLimiter struct {
+	mu     sync.Mutex
+	waits  int
+	limits []rate.Limit
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.waits++
+	return nil
+}
+
+func (l *countingLimiter) SetLimit(limit rate.Limit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits = append(l.limits, limit)
+}
+
+func TestDiscogsClient_NewLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set(discogs.RateLimitHeader, "30")
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	limiters := map[string]*countingLimiter{}
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+		NewLimiter: func(host string, requestsPerMinute int) discogs.Limiter {
+			assert.Equal(t, discogs.RateLimitUnauth, requestsPerMinute)
+			limiters[host] = &countingLimiter{}
+			return limiters[host]
+		},
+	})
+	client.Host = server.URL
+
+	assert.NoError(t, client.Get(ctx, "/releases/1", nil, nil, nil))
+	assert.NoError(t, client.Get(ctx, "/releases/2", nil, nil, nil))
+
+	limiter := limiters[server.URL]
+	assert.Equal(t, 2, limiter.waits)
+	assert.Equal(t, []rate.Limit{rate.Every(2 * time.Second), rate.Every(2 * time.Second)}, limiter.limits)
+	assert.Contains(t, limiters, discogs.BaseURL)
+
+	// The state of limiters that do not report it is unknown
+	assert.Equal(t, rate.Limit(0), client.Limit())
+	assert.Equal(t, 0.0, client.Tokens())
+}
+
+func TestDiscogsClient_NewLimiterUnlimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set(discogs.RateLimitHeader, "1")
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+		NewLimiter: func(string, int) discogs.Limiter { return rate.NewLimiter(rate.Inf, 0) },
+	})
+	client.Host = server.URL
+
+	// The rate limit reported by the API does not re-enable limiting
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, client.Get(ctx, "/releases/1", nil, nil, nil))
+	}
+	assert.Equal(t, rate.Inf, client.Limit())
+}
+
+func TestDiscogsClient_OnRateLimitUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set(discogs.RateLimitHeader, "30")
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var updates [][2]int
+	var client *discogs.DiscogsClient
+	client = discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+		MaxRequests: 60,
+		OnRateLimitUpdate: func(old, new int) {
+			// The hook can use the client
+			assert.Equal(t, rate.Limit(float64(new)/60), client.Limit())
+			updates = append(updates, [2]int{old, new})
+		},
+	})
+	client.Host = server.URL
+
+	assert.NoError(t, client.Get(ctx, "/releases/1", nil, nil, nil))
+	// The limit is unchanged by the second response, so the hook is not called again
+	assert.NoError(t, client.Get(ctx, "/releases/2", nil, nil, nil))
+	assert.Equal(t, [][2]int{{60, 30}}, updates)
+}
+
+func TestDiscogsClient_OnRateLimitWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		limiter func() discogs.Limiter
+	}{
+		{
+			name:    "limiter reporting its state",
+			limiter: func() discogs.Limiter { return rate.NewLimiter(rate.Every(100*time.Millisecond), 1) },
+		},
+		{
+			name: "limiter not reporting its state",
+			limiter: func() discogs.Limiter {
+				return struct{ discogs.Limiter }{rate.NewLimiter(rate.Every(100*time.Millisecond), 1)}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var waits []time.Duration
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+				NewLimiter:      func(string, int) discogs.Limiter { return tt.limiter() },
+				OnRateLimitWait: func(d time.Duration) { waits = append(waits, d) },
+			})
+			client.Host = server.URL
+
+			// The first request uses the burst, the second one waits
+			assert.NoError(t, client.Get(ctx, "/releases/1", nil, nil, nil))
+			assert.Empty(t, waits)
+			assert.NoError(t, client.Get(ctx, "/releases/2", nil, nil, nil))
+			if assert.Len(t, waits, 1) {
+				assert.InDelta(t, 100*time.Millisecond, waits[0], float64(50*time.Millisecond))
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package redislimiter implements a discogs.LimiterBackend storing the rate limiter buckets in Redis, so the pods of a
+// horizontally scaled service sharing a Discogs token stay under its limit together. Tokens are taken by a Lua script
+// using the clock of Redis, so they are taken atomically and the clocks of the pods do not need to be in sync.
+//
+// The package does not depend on a Redis client: any client able to run a script can be used through the Evaler
+// interface, such as go-redis:
+//
+//	backend := redislimiter.New(redislimiter.EvalerFunc(func(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+//		return rdb.Eval(ctx, script, keys, args...).Result()
+//	}), "discogs:ratelimit:")
+package redislimiter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"golang.org/x/time/rate"
+)
+
+// script takes a token from the bucket stored at KEYS[1], with the generic cell rate algorithm: the key holds the time
+// the bucket is full again, in microseconds. ARGV[1] is the interval between two tokens in microseconds and ARGV[2]
+// the burst. It returns the wait in microseconds. The key expires once the bucket is full again.
+const script = `
+local now = redis.call('TIME')
+now = tonumber(now[1]) * 1000000 + tonumber(now[2])
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local full = tonumber(redis.call('GET', KEYS[1])) or now
+if full < now then
+	full = now
+end
+full = full + interval
+redis.call('SET', KEYS[1], string.format('%d', full), 'PX', string.format('%d', math.ceil((full - now) / 1000) + 1))
+local wait = full - now - burst * interval
+if wait < 0 then
+	wait = 0
+end
+return wait
+`
+
+// An Evaler runs a Lua script on Redis, like the EVAL command, and returns its result.
+type Evaler interface {
+	Eval(ctx context.Context, script string, keys []string, args ...any) (any, error)
+}
+
+// EvalerFunc is an adapter to use an ordinary function as an Evaler.
+type EvalerFunc func(ctx context.Context, script string, keys []string, args ...any) (any, error)
+
+// Eval calls f(ctx, script, keys, args...).
+func (f EvalerFunc) Eval(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+	return f(ctx, script, keys, args...)
+}
+
+// Backend is a discogs.LimiterBackend storing the buckets in Redis.
+type Backend struct {
+	client Evaler
+	prefix string
+}
+
+// New creates a new Backend running its script through client. The keys of the buckets, usually the host of the
+// limiter, are prefixed with prefix in Redis.
+func New(client Evaler, prefix string) *Backend {
+	return &Backend{client: client, prefix: prefix}
+}
+
+// Take takes a token from the bucket of key and returns how long the request must wait before being sent.
+func (b *Backend) Take(ctx context.Context, key string, limit rate.Limit, burst int) (time.Duration, error) {
+	if limit == rate.Inf {
+		return 0, nil
+	}
+	if limit <= 0 {
+		return 0, &discogs.ErrInvalidOption{Option: "limit", Value: fmt.Sprint(float64(limit))}
+	}
+	interval := max(int64(float64(time.Second/time.Microsecond)/float64(limit)), 1)
+
+	result, err := b.client.Eval(ctx, script, []string{b.prefix + key}, interval, max(burst, 1))
+	if err != nil {
+		return 0, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	wait, ok := result.(int64)
+	if !ok {
+		return 0, fmt.Errorf("failed to take rate limit token: unexpected result %v", result)
+	}
+	return time.Duration(wait) * time.Microsecond, nil
+}
@@ -0,0 +1,82 @@
+package redislimiter_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs/redislimiter"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+var ctx = context.Background()
+
+func TestBackend_Take(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    rate.Limit
+		result   any
+		err      error
+		wantArgs []any
+		want     time.Duration
+		wantErr  bool
+	}{
+		{
+			name:     "token available",
+			limit:    rate.Every(time.Second),
+			result:   int64(0),
+			wantArgs: []any{int64(1000000), 60},
+			want:     0,
+		},
+		{
+			name:     "empty bucket",
+			limit:    rate.Every(2 * time.Second),
+			result:   int64(1500000),
+			wantArgs: []any{int64(2000000), 60},
+			want:     1500 * time.Millisecond,
+		},
+		{
+			name:    "redis error",
+			limit:   rate.Every(time.Second),
+			err:     errors.New("connection refused"),
+			wantErr: true,
+		},
+		{
+			name:    "unexpected result",
+			limit:   rate.Every(time.Second),
+			result:  "OK",
+			wantErr: true,
+		},
+		{
+			name:  "unlimited",
+			limit: rate.Inf,
+			want:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotKeys []string
+			var gotArgs []any
+			backend := redislimiter.New(redislimiter.EvalerFunc(func(_ context.Context, script string, keys []string, args ...any) (any, error) {
+				assert.Contains(t, script, "redis.call('TIME')")
+				gotKeys, gotArgs = keys, args
+				return tt.result, tt.err
+			}), "discogs:ratelimit:")
+
+			wait, err := backend.Take(ctx, "https://api.discogs.com", tt.limit, 60)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, wait)
+			if tt.wantArgs != nil {
+				assert.Equal(t, []string{"discogs:ratelimit:https://api.discogs.com"}, gotKeys)
+				assert.Equal(t, tt.wantArgs, gotArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package discogs
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// A RequestOption customizes a single call, such as adding a header or a query parameter, without changing the
+// DiscogsConfig of the client. Endpoint methods accept RequestOptions as their last arguments, and apply them to the
+// request of their endpoint only, not to the lookups some of them make first, such as the order fetched by UpdateOrder.
+// They can be used with Get, Post, Put, Delete and the methods making several requests through WithRequestOptions.
+type RequestOption func(options *requestOptions)
+
+// requestOptions holds the customizations of a call made with RequestOptions.
+type requestOptions struct {
+	headers  map[string]string
+	params   url.Values
+	timeout  time.Duration
+	currency Currency
+}
+
+// WithHeader sets a header of the request, replacing the value set by the method, if any. The User-Agent and
+// authentication headers cannot be replaced.
+func WithHeader(key, value string) RequestOption {
+	return func(options *requestOptions) {
+		if options.headers == nil {
+			options.headers = make(map[string]string)
+		}
+		options.headers[key] = value
+	}
+}
+
+// WithQueryParam sets a query parameter of the request, replacing the value set by the method, if any, so parameters
+// that are not modeled by the options of the method yet can be sent.
+func WithQueryParam(key, value string) RequestOption {
+	return func(options *requestOptions) {
+		if options.params == nil {
+			options.params = url.Values{}
+		}
+		options.params.Set(key, value)
+	}
+}
+
+// WithTimeout limits the time spent on each request of the call, including rate limit waits and retries.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(options *requestOptions) {
+		options.timeout = timeout
+	}
+}
+
+// WithCurrency sets the currency prices are returned in, for the endpoints accepting the curr_abbr parameter, such as
+// Release and Listing. The call fails with an ErrInvalidCurrency if the currency is not accepted by the Discogs API.
+func WithCurrency(currency Currency) RequestOption {
+	return func(options *requestOptions) {
+		options.currency = currency
+	}
+}
+
+// requestOptionsKey is the context key for the RequestOptions of a call.
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a copy of ctx applying the given RequestOptions to the requests made with it, on top of
+// the RequestOptions ctx already applies. Requests made while sending one of them, such as the lookups of a
+// WriteVerifier, do not inherit the options.
+//
+// Example:
+//
+//	ctx = discogs.WithRequestOptions(ctx, discogs.WithCurrency(discogs.CurrencyEUR))
+//	release, err := client.EnrichRelease(ctx, releaseID, nil)
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+
+	options := requestOptionsFrom(ctx)
+	options.headers = maps.Clone(options.headers)
+	options.params = maps.Clone(options.params)
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, options)
+}
+
+// requestOptionsFrom returns the RequestOptions applied to the requests made with ctx.
+func requestOptionsFrom(ctx context.Context) requestOptions {
+	options, _ := ctx.Value(requestOptionsKey{}).(requestOptions)
+	return options
+}
+
+// withTimeout returns a copy of ctx bounded by the timeout of the options, if any, and the function releasing it.
+func (options *requestOptions) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if options.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, options.timeout)
+}
+
+// query returns params with the query parameters and the currency of the options set. The parameters are copied so
+// the options of the call do not leak into the caller's values. It returns an ErrInvalidCurrency if the currency is
+// not accepted by the Discogs API.
+func (options *requestOptions) query(params url.Values) (url.Values, error) {
+	if options.params == nil && options.currency == "" {
+		return params, nil
+	}
+	if err := validateCurrency(options.currency); err != nil {
+		return nil, err
+	}
+
+	params = maps.Clone(params)
+	if params == nil {
+		params = url.Values{}
+	}
+	for key, values := range options.params {
+		params[key] = values
+	}
+	if options.currency != "" {
+		params.Set("curr_abbr", string(options.currency))
+	}
+	return params, nil
+}
+
+// apply sets the query parameters and headers of the options on req, for requests that are not sent through
+// requestWithAuth, such as downloads and the requests of the OAuth flow. The query of req is left as is if the
+// options set no parameter, since the URLs of some files are signed.
+func (options *requestOptions) apply(req *http.Request) error {
+	if options.params != nil || options.currency != "" {
+		params, err := options.query(req.URL.Query())
+		if err != nil {
+			return err
+		}
+		req.URL.RawQuery = params.Encode()
+	}
+	for key, value := range options.headers {
+		req.Header.Set(key, value)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package discogs_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_RequestOptions(t *testing.T) {
+	var got *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		got = req
+		_, _ = rw.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	_, err := client.Release(ctx, 1, &discogs.ReleaseOptions{CurrAbr: discogs.CurrencyUSD},
+		discogs.WithHeader("X-Trace", "abc"),
+		discogs.WithQueryParam("extra", "1"),
+		discogs.WithCurrency(discogs.CurrencyEUR),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", got.Header.Get("X-Trace"))
+	assert.Equal(t, "1", got.URL.Query().Get("extra"))
+	assert.Equal(t, []string{"EUR"}, got.URL.Query()["curr_abbr"])
+
+	// Options only apply to the call they are given to
+	_, err = client.Release(ctx, 1, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, got.Header.Get("X-Trace"))
+	assert.Empty(t, got.URL.Query().Get("extra"))
+
+	// Options do not change the parameters of the caller
+	params := url.Values{"page": {"2"}}
+	err = client.Get(discogs.WithRequestOptions(ctx, discogs.WithQueryParam("per_page", "5")), "/test", params, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "5", got.URL.Query().Get("per_page"))
+	assert.Equal(t, url.Values{"page": {"2"}}, params)
+
+	// Options of the context are combined with the options of the call
+	ctx := discogs.WithRequestOptions(ctx, discogs.WithHeader("X-Trace", "abc"))
+	_, err = client.Artist(ctx, 1, discogs.WithQueryParam("extra", "2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", got.Header.Get("X-Trace"))
+	assert.Equal(t, "2", got.URL.Query().Get("extra"))
+}
+
+func TestDiscogsClient_WithCurrencyInvalid(t *testing.T) {
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+
+	_, err := client.Release(ctx, 1, nil, discogs.WithCurrency("XYZ"))
+	var invalid *discogs.ErrInvalidCurrency
+	assert.ErrorAs(t, err, &invalid)
+}
+
+func TestDiscogsClient_WithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	_, err := client.Release(ctx, 1, nil, discogs.WithTimeout(10*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDiscogsClient_RequestOptionsNotInherited(t *testing.T) {
+	traces := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		traces[req.Method] = req.Header.Get("X-Trace")
+		_, _ = rw.Write([]byte(`{"id":"1-1","status":"Payment Received","next_status":["Shipped"]}`))
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	status := discogs.OrderStatusShipped
+	_, err := client.UpdateOrder(ctx, "1-1", &discogs.UpdateOrderRequest{Status: &status}, discogs.WithHeader("X-Trace", "abc"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{http.MethodGet: "", http.MethodPost: "abc"}, traces)
+}
+
+func TestDiscogsClient_RequestOptionsStreamed(t *testing.T) {
+	traces := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		traces[req.URL.Path] = req.Header.Get("X-Trace")
+		switch req.URL.Path {
+		case "/oauth/request_token":
+			_, _ = rw.Write([]byte("oauth_token=request-token&oauth_token_secret=request-secret"))
+		case "/inventory/export/1/download":
+			_, _ = rw.Write([]byte("listing_id,price\n"))
+		case "/inventory/export/2/download":
+			// The body never ends, so only the timeout stops reading it
+			_, _ = rw.Write([]byte("listing_id,price\n"))
+			rw.(http.Flusher).Flush()
+			<-req.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret, AccessToken: &token})
+	client.Host = server.URL
+
+	var buf bytes.Buffer
+	_, err := client.DownloadInventoryExport(ctx, 1, &buf, discogs.WithHeader("X-Trace", "download"))
+	assert.NoError(t, err)
+	_, err = client.GetRequestToken(ctx, "", discogs.WithHeader("X-Trace", "oauth"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"/inventory/export/1/download": "download", "/oauth/request_token": "oauth"}, traces)
+
+	_, err = client.DownloadInventoryExport(ctx, 2, &buf, discogs.WithTimeout(50*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,250 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultResolveCandidates is the default number of search results considered by ResolveRelease.
+const DefaultResolveCandidates = 25
+
+// Weights of the criteria ResolveRelease scores candidates with.
+const (
+	resolveTitleWeight   = 3
+	resolveArtistWeight  = 2
+	resolveFormatWeight  = 1.5
+	resolveYearWeight    = 1
+	resolveCatNoWeight   = 1.5
+	resolveBarcodeWeight = 2.5
+	resolveCountryWeight = 0.5
+)
+
+// ResolveOptions represents the hints used by ResolveRelease to pick the right release among the search results.
+// Unset hints are not used.
+type ResolveOptions struct {
+	// Format is the format of the release, such as "Vinyl" or "CD".
+	Format string
+	// Year is the year the release was released.
+	Year int
+	// CatNo is the catalog number of the release. Spaces, dashes and case are ignored.
+	CatNo string
+	// Barcode is the barcode of the release. Only its digits are compared.
+	Barcode string
+	// Country is the country the release was released in.
+	Country string
+	// MaxCandidates is the number of search results considered. DefaultResolveCandidates is used if unset.
+	MaxCandidates int
+}
+
+// ErrReleaseNotResolved indicates that a search found no release to resolve.
+type ErrReleaseNotResolved struct {
+	Artist string
+	Title  string
+}
+
+func (e *ErrReleaseNotResolved) Error() string {
+	return fmt.Sprintf("no release found for %q by %q", e.Title, e.Artist)
+}
+
+// ResolveCandidate represents a release considered by ResolveRelease, along with its score.
+type ResolveCandidate struct {
+	ReleaseID int64
+	Result    SearchResult
+	// Score is the sum of the weights of the criteria the candidate matches.
+	Score float64
+}
+
+// ResolvedRelease represents the outcome of ResolveRelease.
+type ResolvedRelease struct {
+	// ReleaseID is the ID of the best candidate.
+	ReleaseID int64
+	// Confidence is the fraction of the highest possible score the best candidate reached, from 0 to 1. It is lowered
+	// when other candidates score as well, since the hints then cannot tell them apart.
+	Confidence float64
+	Best       ResolveCandidate
+	// Alternatives are the other candidates, by descending score.
+	Alternatives []ResolveCandidate
+}
+
+// ResolveRelease finds the release best matching an artist and a title. It searches releases by artist and title, and
+// scores the results on how well they match the artist, the title and the hints of options, such as the format,
+// year, catalog number or barcode. It returns the best release along with a confidence value and the alternatives
+// that were considered. If options is nil, no hints are used.
+//
+// It returns an ErrReleaseNotResolved if the search finds no release.
+func (dc *DiscogsClient) ResolveRelease(ctx context.Context, artist, title string, options *ResolveOptions) (*ResolvedRelease, error) {
+	var opts ResolveOptions
+	if options != nil {
+		opts = *options
+	}
+	if opts.MaxCandidates <= 0 {
+		opts.MaxCandidates = DefaultResolveCandidates
+	}
+
+	searchOptions := &SearchOptions{Type: TypeRelease, Artist: artist, ReleaseTitle: title}
+	// The Discogs API returns at most 100 results per page
+	perPage := min(opts.MaxCandidates, 100)
+
+	var candidates []ResolveCandidate
+	err := ForEachPage(ctx, dc.SearchPages(searchOptions), &IteratorOptions{PerPage: perPage}, func(results []SearchResult, _ *Pagination) error {
+		for _, result := range results {
+			if result.ID == nil {
+				continue
+			}
+			candidates = append(candidates, ResolveCandidate{
+				ReleaseID: *result.ID,
+				Result:    result,
+				Score:     scoreCandidate(&result, artist, title, &opts),
+			})
+			if len(candidates) == opts.MaxCandidates {
+				return ErrStopPaging
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, &ErrReleaseNotResolved{Artist: artist, Title: title}
+	}
+
+	// Keep the relevance order of the search among candidates with the same score
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+
+	best := candidates[0]
+	res := &ResolvedRelease{ReleaseID: best.ReleaseID, Best: best, Alternatives: candidates[1:]}
+	if maxScore := maxResolveScore(&opts); maxScore > 0 {
+		res.Confidence = best.Score / maxScore
+	}
+	if len(candidates) > 1 && candidates[1].Score == best.Score {
+		res.Confidence /= 2
+	}
+	return res, nil
+}
+
+// scoreCandidate scores a search result on how well it matches the artist, the title and the hints of opts.
+func scoreCandidate(result *SearchResult, artist, title string, opts *ResolveOptions) float64 {
+	// Search results are titled "Artist - Title"
+	resultArtist, resultTitle, ok := strings.Cut(result.Title, " - ")
+	if !ok {
+		resultTitle = result.Title
+	}
+
+	score := matchScore(resultTitle, title)*resolveTitleWeight + matchScore(resultArtist, artist)*resolveArtistWeight
+
+	if opts.Format != "" && containsFold(result.Format, opts.Format) {
+		score += resolveFormatWeight
+	}
+	if opts.Year != 0 {
+		if year, err := strconv.Atoi(result.Year); err == nil {
+			switch year - opts.Year {
+			case 0:
+				score += resolveYearWeight
+			case -1, 1:
+				score += resolveYearWeight / 2
+			}
+		}
+	}
+	if opts.CatNo != "" && normalizeCatNo(result.CatNo) == normalizeCatNo(opts.CatNo) {
+		score += resolveCatNoWeight
+	}
+	if opts.Barcode != "" {
+		barcode := digits(opts.Barcode)
+		for _, b := range result.Barcode {
+			if digits(b) == barcode {
+				score += resolveBarcodeWeight
+				break
+			}
+		}
+	}
+	if opts.Country != "" && strings.EqualFold(result.Country, opts.Country) {
+		score += resolveCountryWeight
+	}
+
+	return score
+}
+
+// maxResolveScore returns the highest score a candidate can reach with the hints of opts.
+func maxResolveScore(opts *ResolveOptions) float64 {
+	score := float64(resolveTitleWeight + resolveArtistWeight)
+	if opts.Format != "" {
+		score += resolveFormatWeight
+	}
+	if opts.Year != 0 {
+		score += resolveYearWeight
+	}
+	if opts.CatNo != "" {
+		score += resolveCatNoWeight
+	}
+	if opts.Barcode != "" {
+		score += resolveBarcodeWeight
+	}
+	if opts.Country != "" {
+		score += resolveCountryWeight
+	}
+	return score
+}
+
+// matchScore returns 1 if two names are equal ignoring case and punctuation, 0.5 if one contains the other, and 0
+// otherwise.
+func matchScore(got, want string) float64 {
+	got, want = normalizeName(got), normalizeName(want)
+	switch {
+	case want == "" || got == "":
+		return 0
+	case got == want:
+		return 1
+	case strings.Contains(got, want) || strings.Contains(want, got):
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// normalizeName lowercases a name and reduces it to its letters and digits separated by single spaces. Discogs
+// numbering of homonymous artists, such as "Nirvana (2)", is removed.
+func normalizeName(name string) string {
+	if i := strings.LastIndex(name, " ("); i >= 0 && strings.HasSuffix(name, ")") {
+		if _, err := strconv.Atoi(name[i+2 : len(name)-1]); err == nil {
+			name = name[:i]
+		}
+	}
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// normalizeCatNo lowercases a catalog number and removes its spaces and dashes.
+func normalizeCatNo(catNo string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, catNo)
+}
+
+// digits returns the digits of s.
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// containsFold reports whether values contains value, ignoring case.
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_ResolveRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "release", req.URL.Query().Get("type"))
+		assert.Equal(t, "Nirvana", req.URL.Query().Get("artist"))
+		assert.Equal(t, "Nevermind", req.URL.Query().Get("release_title"))
+
+		_, _ = rw.Write([]byte(`{"pagination":{"page":1,"pages":1},"results":[
+			{"id":1,"title":"Nirvana - Nevermind","format":["CD","Album"],"year":"1991","catno":"DGCD-24425","barcode":["7 20642 44252 7"],"country":"US"},
+			{"id":2,"title":"Nirvana - Nevermind","format":["Vinyl","LP"],"year":"1991","catno":"DGC-24425","barcode":["720642442517"],"country":"US"},
+			{"id":3,"title":"Nirvana - Nevermind","format":["Vinyl","LP"],"year":"2011","catno":"DGC-24425","country":"Europe"},
+			{"id":4,"title":"Nirvana (2) - Nevermind Demos","format":["Cassette"],"year":"1990"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret})
+	client.Host = server.URL
+
+	tests := []struct {
+		name           string
+		options        *discogs.ResolveOptions
+		wantID         int64
+		wantConfidence float64
+	}{
+		{
+			name:           "no hints",
+			options:        nil,
+			wantID:         1,
+			wantConfidence: 0.5,
+		},
+		{
+			name:           "format and year",
+			options:        &discogs.ResolveOptions{Format: "vinyl", Year: 1991},
+			wantID:         2,
+			wantConfidence: 1,
+		},
+		{
+			name:           "catalog number",
+			options:        &discogs.ResolveOptions{CatNo: "dgc 24425", Country: "Europe"},
+			wantID:         3,
+			wantConfidence: 1,
+		},
+		{
+			name:           "barcode",
+			options:        &discogs.ResolveOptions{Barcode: "720642442527", Format: "Vinyl"},
+			wantID:         1,
+			wantConfidence: 7.5 / 9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ResolveRelease(ctx, "Nirvana", "Nevermind", tt.options)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, got.ReleaseID)
+			assert.InDelta(t, tt.wantConfidence, got.Confidence, 0.001)
+			assert.Len(t, got.Alternatives, 3)
+		})
+	}
+}
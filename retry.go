@@ -0,0 +1,285 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultRetryWait is the default time waited before retrying a request.
+	DefaultRetryWait = time.Second
+
+	// retryBudgetReserve is the number of retries the retry budget allows regardless of the number of requests sent.
+	retryBudgetReserve = 10
+)
+
+// ErrRetriesExhausted indicates that a request kept failing with a transient network error, such as a connection
+// reset, until MaxTransportRetries was reached or no retry could be started. Retries is the number of retries made,
+// and Err the error of the last attempt.
+type ErrRetriesExhausted struct {
+	Retries int
+	Err     error
+}
+
+func (e *ErrRetriesExhausted) Error() string {
+	return fmt.Sprintf("failed after %d retries: %v", e.Retries, e.Err)
+}
+
+func (e *ErrRetriesExhausted) Unwrap() error {
+	return e.Err
+}
+
+// sendWithRetry sends an HTTP request, retrying it according to the RetryPolicy of the DiscogsConfig, and returns
+// the response of the first successful attempt along with its body. If every attempt fails, the error of the last
+// attempt is returned, wrapped in an ErrRetriesExhausted if it is a network error that was retried.
+func (dc *DiscogsClient) sendWithRetry(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	start := time.Now()
+	dc.retryBudget.deposit(dc.Config.RetryBudget)
+	policy := dc.retryPolicy()
+
+	var wait time.Duration
+	var retries, rateLimitRetries, transportRetries int
+	giveUp := func(err error) error {
+		if transportRetries > 0 && isTransientNetworkError(err) {
+			return &ErrRetriesExhausted{Retries: retries + rateLimitRetries + transportRetries, Err: err}
+		}
+		return err
+	}
+	for {
+		response, responseBody, err := dc.sendAttempt(ctx, req)
+		if err == nil {
+			return response, responseBody, nil
+		}
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			// The body was consumed and cannot be sent again
+			return response, responseBody, giveUp(err)
+		}
+
+		var rateLimited *ErrRateLimited
+		isRateLimited := errors.As(err, &rateLimited)
+		switch {
+		case isRateLimited && rateLimitRetries < dc.Config.MaxRateLimitRetries:
+			// Wait as long as Discogs asks, falling back to the backoff if it did not tell
+			rateLimitRetries++
+			wait = rateLimited.RetryAfter
+			if wait <= 0 {
+				wait = policy.Backoff(rateLimitRetries, 0)
+			}
+		case transportRetries < dc.Config.MaxTransportRetries && isRetryable(req) && isTransientNetworkError(err):
+			// The request may not have reached Discogs; writes are verified before being sent again
+			transportRetries++
+			wait = policy.Backoff(transportRetries, 0)
+		case policy.ShouldRetry(req, response, err, retries):
+			retries++
+			wait = policy.Backoff(retries, wait)
+			if isRateLimited {
+				wait = max(wait, rateLimited.RetryAfter)
+			}
+			if dc.Config.RetryBudget > 0 && !dc.retryBudget.withdraw() {
+				return nil, nil, giveUp(err)
+			}
+		default:
+			return response, responseBody, giveUp(err)
+		}
+
+		if dc.Config.MaxRetryElapsedTime > 0 && time.Since(start)+wait > dc.Config.MaxRetryElapsedTime {
+			return nil, nil, giveUp(err)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, giveUp(err)
+		case <-timer.C:
+		}
+
+		// Rejected writes were not applied, others may have been
+		if !isRateLimited {
+			applied, verifyErr := verifyWrite(ctx, req, err)
+			if verifyErr != nil {
+				return nil, nil, verifyErr
+			}
+			if applied != nil {
+				return applied, nil, nil
+			}
+		}
+
+		// The body of the previous attempt was consumed
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, nil, giveUp(err)
+			}
+			req.Body = body
+		}
+
+		dc.stats.recordRetry(req)
+	}
+}
+
+// isTransientNetworkError reports whether err is a network failure that is likely to succeed if the request is sent
+// again: a connection reset or closed by Discogs, a timeout that is not the deadline of the caller, or a temporary
+// DNS failure. Errors of the caller's context are never transient.
+func isTransientNetworkError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// sendAttempt sends a single attempt of an HTTP request, hedging it if enabled.
+func (dc *DiscogsClient) sendAttempt(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	if req.Method == http.MethodGet && dc.Config.HedgeDelay > 0 {
+		return dc.sendHedged(ctx, req, dc.Config.HedgeDelay)
+	}
+	return dc.send(ctx, req)
+}
+
+// A RetryPolicy decides which failed requests are retried, and how long to wait before each retry. It is consulted for
+// every failed attempt, except for requests rejected with a 429 status code while MaxRateLimitRetries allows retrying
+// them. Implementations must be safe for concurrent use, as a single RetryPolicy is shared by all requests of a
+// DiscogsClient.
+//
+// Example, only retrying GET requests rejected with a 429 status code, with MaxRateLimitRetries unset:
+//
+//	type readsOnly struct{ discogs.DefaultRetryPolicy }
+//
+//	func (p readsOnly) ShouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+//		var rateLimited *discogs.ErrRateLimited
+//		return req.Method == http.MethodGet && attempt < p.MaxRetries && errors.As(err, &rateLimited)
+//	}
+type RetryPolicy interface {
+	// ShouldRetry reports whether an attempt of req that failed with err is retried. The response is nil if no
+	// response was received, and its body has already been read. The attempt parameter is the number of retries
+	// already made, 0 after the first attempt failed.
+	ShouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool
+	// Backoff returns the time to wait before the given retry, where attempt is 1 for the first retry. The previous
+	// parameter is the time waited before the previous retry, or 0 before the first retry. Requests rejected with a
+	// 429 status code wait at least the time Discogs asked for.
+	Backoff(attempt int, previous time.Duration) time.Duration
+}
+
+// DefaultRetryPolicy is the RetryPolicy used if none is set, built from MaxRetries, ShouldRetry, Backoff and RetryWait
+// of the DiscogsConfig. It retries idempotent requests that failed with a 500, 502 or 503 status code up to MaxRetries
+// times. It can be embedded by policies that only change one of its methods.
+type DefaultRetryPolicy struct {
+	MaxRetries int
+	// RetryIf decides which failures of idempotent requests are retried, replacing the default status codes if set.
+	// MaxRetries still applies.
+	RetryIf func(resp *http.Response, err error) bool
+	// Wait determines the time waited before each retry. DefaultRetryWait is waited before every retry if unset.
+	Wait Backoff
+}
+
+// ShouldRetry reports whether an attempt of req that failed with err is retried. Only idempotent requests and writes
+// made with WithWriteVerifier are retried, up to MaxRetries times. RetryIf decides which failures are retried if set,
+// and otherwise failures with a transient status code are retried.
+func (p DefaultRetryPolicy) ShouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if attempt >= p.MaxRetries || !isRetryable(req) {
+		return false
+	}
+
+	if p.RetryIf != nil {
+		return p.RetryIf(resp, err)
+	}
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	switch httpErr.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable:
+		// Such as the "Query time exceeded" errors of search, or Discogs being briefly unavailable
+		return true
+	default:
+		return false
+	}
+}
+
+// Backoff returns the time to wait before the given retry according to Wait.
+func (p DefaultRetryPolicy) Backoff(attempt int, previous time.Duration) time.Duration {
+	if p.Wait == nil {
+		return DefaultRetryWait
+	}
+	return p.Wait.Next(attempt, previous)
+}
+
+// retryPolicy returns the RetryPolicy of the DiscogsConfig, falling back to a DefaultRetryPolicy built from its retry
+// settings.
+func (dc *DiscogsClient) retryPolicy() RetryPolicy {
+	if dc.Config.RetryPolicy != nil {
+		return dc.Config.RetryPolicy
+	}
+
+	backoff := dc.Config.Backoff
+	if backoff == nil {
+		wait := dc.Config.RetryWait
+		if wait <= 0 {
+			wait = DefaultRetryWait
+		}
+		backoff = ConstantBackoff{Wait: wait}
+	}
+	return DefaultRetryPolicy{MaxRetries: dc.Config.MaxRetries, RetryIf: dc.Config.ShouldRetry, Wait: backoff}
+}
+
+// isIdempotent reports whether requests of the method can be sent again without changing data twice. Writes are not
+// retried, since a POST whose response was lost would, for instance, create a listing twice.
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// retryBudget limits the fraction of requests that may be retries using a token bucket. Every request deposits a
+// fraction of a token and every retry withdraws a whole token. The zero value starts with a full reserve.
+type retryBudget struct {
+	mu          sync.Mutex
+	initialized bool
+	tokens      float64
+}
+
+// deposit adds ratio tokens to the budget for a new request, up to the reserve.
+func (b *retryBudget) deposit(ratio float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.init()
+	b.tokens = min(b.tokens+ratio, retryBudgetReserve)
+}
+
+// withdraw takes a token from the budget for a retry. It reports false if the budget is exhausted.
+func (b *retryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.init()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// init fills the reserve of the budget on first use. The caller must hold b.mu.
+func (b *retryBudget) init() {
+	if !b.initialized {
+		b.initialized = true
+		b.tokens = retryBudgetReserve
+	}
+}
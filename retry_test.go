@@ -0,0 +1,436 @@
+package discogs_test
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_Retry(t *testing.T) {
+	type args struct {
+		config *discogs.DiscogsConfig
+		method string
+		// failures is the number of requests that fail before the mock server succeeds.
+		failures int64
+		status   int
+	}
+	type want struct {
+		requests int64
+		err      bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"Retry disabled",
+			args{&discogs.DiscogsConfig{}, http.MethodGet, 1, http.StatusInternalServerError},
+			want{1, true},
+		},
+		{
+			"Retry until success",
+			args{&discogs.DiscogsConfig{MaxRetries: 3, RetryWait: time.Millisecond}, http.MethodGet, 2, http.StatusBadGateway},
+			want{3, false},
+		},
+		{
+			"Retry attempts exhausted",
+			args{&discogs.DiscogsConfig{MaxRetries: 2, RetryWait: time.Millisecond}, http.MethodGet, 5, http.StatusServiceUnavailable},
+			want{3, true},
+		},
+		{
+			"Retry skips client errors",
+			args{&discogs.DiscogsConfig{MaxRetries: 2, RetryWait: time.Millisecond}, http.MethodGet, 1, http.StatusNotFound},
+			want{1, true},
+		},
+		{
+			"Retry skips permanent server errors",
+			args{&discogs.DiscogsConfig{MaxRetries: 2, RetryWait: time.Millisecond}, http.MethodGet, 1, http.StatusNotImplemented},
+			want{1, true},
+		},
+		{
+			"Retry skips POST requests",
+			args{&discogs.DiscogsConfig{MaxRetries: 2, RetryWait: time.Millisecond}, http.MethodPost, 1, http.StatusInternalServerError},
+			want{1, true},
+		},
+		{
+			"Retry stops at max elapsed time",
+			args{&discogs.DiscogsConfig{MaxRetries: 5, RetryWait: 50 * time.Millisecond, MaxRetryElapsedTime: 80 * time.Millisecond}, http.MethodGet, 5, http.StatusInternalServerError},
+			want{2, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if requests.Add(1) <= tt.args.failures {
+					rw.WriteHeader(tt.args.status)
+					return
+				}
+				_, _ = rw.Write([]byte(`{"success":true}`))
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(tt.args.config)
+			client.Host = server.URL
+
+			var err error
+			var res TestClientResponse
+			if tt.args.method == http.MethodPost {
+				err = client.Post(ctx, "/test", nil, nil, nil, &res)
+			} else {
+				err = client.Get(ctx, "/test", nil, nil, &res)
+			}
+
+			if tt.want.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, res.Success)
+			}
+			assert.Equal(t, tt.want.requests, requests.Load())
+			assert.Equal(t, tt.want.requests-1, client.Stats().Retries)
+		})
+	}
+}
+
+func TestDiscogsClient_RetryBudget(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+		MaxRequests: 1000,
+		MaxRetries:  1,
+		RetryWait:   time.Millisecond,
+		RetryBudget: 0.01,
+	})
+	client.Host = server.URL
+
+	for i := 0; i < 12; i++ {
+		assert.Error(t, client.Get(ctx, "/test", nil, nil, nil))
+	}
+
+	// The reserve allows 10 retries, after which the budget is exhausted
+	assert.Equal(t, int64(22), requests.Load())
+	assert.Equal(t, int64(10), client.Stats().Retries)
+}
+
+func TestDiscogsClient_ShouldRetry(t *testing.T) {
+	queryTimeExceeded := func(resp *http.Response, err error) bool {
+		var httpErr *discogs.HTTPError
+		return errors.As(err, &httpErr) && strings.Contains(httpErr.Message, "Query time exceeded")
+	}
+	notFound := func(resp *http.Response, err error) bool {
+		return resp != nil && resp.StatusCode == http.StatusNotFound
+	}
+
+	type args struct {
+		shouldRetry func(resp *http.Response, err error) bool
+		status      int
+		message     string
+	}
+	tests := []struct {
+		name string
+		args args
+		want int64
+	}{
+		{
+			"ShouldRetry retries matching error",
+			args{queryTimeExceeded, http.StatusInternalServerError, "Query time exceeded. Please try a simpler query."},
+			3,
+		},
+		{
+			"ShouldRetry skips other server errors",
+			args{queryTimeExceeded, http.StatusInternalServerError, "Internal server error."},
+			1,
+		},
+		{
+			"ShouldRetry retries client errors",
+			args{notFound, http.StatusNotFound, "Not found."},
+			3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				requests.Add(1)
+				rw.WriteHeader(tt.args.status)
+				_, _ = rw.Write([]byte(tt.args.message))
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+				MaxRetries:  2,
+				RetryWait:   time.Millisecond,
+				ShouldRetry: tt.args.shouldRetry,
+			})
+			client.Host = server.URL
+
+			assert.Error(t, client.Get(ctx, "/test", nil, nil, nil))
+			assert.Equal(t, tt.want, requests.Load())
+		})
+	}
+}
+
+// rateLimitOnlyPolicy retries requests rejected with a 429 status code, of any method, up to twice.
+type rateLimitOnlyPolicy struct {
+	discogs.DefaultRetryPolicy
+}
+
+func (p rateLimitOnlyPolicy) ShouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	var rateLimited *discogs.ErrRateLimited
+	return attempt < 2 && errors.As(err, &rateLimited)
+}
+
+func TestDiscogsClient_RetryPolicy(t *testing.T) {
+	rateLimitOnly := rateLimitOnlyPolicy{discogs.DefaultRetryPolicy{Wait: discogs.ConstantBackoff{Wait: time.Millisecond}}}
+
+	tests := []struct {
+		name   string
+		policy discogs.RetryPolicy
+		method string
+		status int
+		want   int64
+	}{
+		{"RetryPolicy retries rate limited GET", rateLimitOnly, http.MethodGet, http.StatusTooManyRequests, 3},
+		{"RetryPolicy retries rate limited POST", rateLimitOnly, http.MethodPost, http.StatusTooManyRequests, 3},
+		{"RetryPolicy skips server errors", rateLimitOnly, http.MethodGet, http.StatusServiceUnavailable, 1},
+		{
+			"DefaultRetryPolicy retries server errors",
+			discogs.DefaultRetryPolicy{MaxRetries: 1, Wait: discogs.ConstantBackoff{Wait: time.Millisecond}},
+			http.MethodGet,
+			http.StatusServiceUnavailable,
+			2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				requests.Add(1)
+				rw.Header().Set("Retry-After", "0")
+				rw.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			// The settings replaced by the policy are ignored
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+				MaxRetries:  5,
+				RetryWait:   time.Hour,
+				RetryPolicy: tt.policy,
+			})
+			client.Host = server.URL
+
+			if tt.method == http.MethodPost {
+				assert.Error(t, client.Post(ctx, "/test", nil, nil, nil, nil))
+			} else {
+				assert.Error(t, client.Get(ctx, "/test", nil, nil, nil))
+			}
+			assert.Equal(t, tt.want, requests.Load())
+		})
+	}
+}
+
+func TestDiscogsClient_RateLimited(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  discogs.DiscogsConfig
+		header  http.Header
+		method  string
+		want    int64
+		wantErr *discogs.ErrRateLimited
+	}{
+		{
+			name:   "retry-after seconds",
+			header: http.Header{"Retry-After": {"120"}},
+			method: http.MethodGet,
+			want:   1,
+			wantErr: &discogs.ErrRateLimited{RetryAfter: 2 * time.Minute, Err: &discogs.HTTPError{
+				StatusCode: http.StatusTooManyRequests, Message: `{"message":"You are making requests too quickly."}`,
+			}},
+		},
+		{
+			name:   "rate limit header",
+			header: http.Header{discogs.RateLimitHeader: {"60"}},
+			method: http.MethodGet,
+			want:   1,
+			wantErr: &discogs.ErrRateLimited{RetryAfter: time.Second, Err: &discogs.HTTPError{
+				StatusCode: http.StatusTooManyRequests, Message: `{"message":"You are making requests too quickly."}`,
+			}},
+		},
+		{
+			name:   "retried post",
+			config: discogs.DiscogsConfig{MaxRateLimitRetries: 2, RetryWait: time.Millisecond},
+			header: http.Header{"Retry-After": {"0"}},
+			method: http.MethodPost,
+			want:   2,
+		},
+		{
+			name:   "retries exhausted",
+			config: discogs.DiscogsConfig{MaxRateLimitRetries: 1, MaxRetries: 3, RetryWait: time.Millisecond},
+			header: http.Header{},
+			method: http.MethodGet,
+			want:   2,
+			wantErr: &discogs.ErrRateLimited{Err: &discogs.HTTPError{
+				StatusCode: http.StatusTooManyRequests, Message: `{"message":"You are making requests too quickly."}`,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.Method == http.MethodPost {
+					body, _ := io.ReadAll(req.Body)
+					assert.Equal(t, `{"rating":5}`, string(body))
+				}
+				// Only the first request is rejected, unless the retries are meant to be exhausted
+				if requests.Add(1) == 1 || tt.name == "retries exhausted" {
+					for name, values := range tt.header {
+						rw.Header()[name] = values
+					}
+					rw.WriteHeader(http.StatusTooManyRequests)
+					_, _ = rw.Write([]byte(`{"message":"You are making requests too quickly."}`))
+					return
+				}
+				_, _ = rw.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&tt.config)
+			client.Host = server.URL
+
+			var body any
+			if tt.method == http.MethodPost {
+				body = map[string]int{"rating": 5}
+			}
+			err := client.SendRequest(ctx, &discogs.EndpointRequest{
+				Method:   tt.method,
+				Endpoint: "/releases/1",
+				AuthType: discogs.AuthTypeNone,
+				Body:     body,
+			}, nil)
+			assert.Equal(t, tt.want, requests.Load())
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.wantErr, err)
+
+			var httpErr *discogs.HTTPError
+			assert.ErrorAs(t, err, &httpErr)
+		})
+	}
+}
+
+func TestDiscogsClient_DoRetry(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if requests.Add(1)%2 == 1 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = rw.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{MaxRateLimitRetries: 1})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/releases/1", strings.NewReader(`{}`))
+	assert.NoError(t, err)
+	var res map[string]int
+	assert.NoError(t, client.Do(ctx, req, &res))
+	assert.Equal(t, map[string]int{"id": 1}, res)
+	assert.Equal(t, int64(2), requests.Load())
+
+	// Bodies that cannot be read again are not sent again
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/releases/1", io.NopCloser(strings.NewReader(`{}`)))
+	assert.NoError(t, err)
+	var rateLimited *discogs.ErrRateLimited
+	assert.ErrorAs(t, client.Do(ctx, req, nil), &rateLimited)
+	assert.Equal(t, int64(3), requests.Load())
+}
+
+// flakyTransport fails the first requests with a connection reset, and forwards the next ones to the default
+// transport.
+type flakyTransport struct {
+	failures int64
+	requests atomic.Int64
+}
+
+func (t *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.requests.Add(1) <= t.failures {
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestDiscogsClient_TransportRetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		failures    int64
+		wantReqs    int64
+		wantRetries int
+		wantErr     bool
+	}{
+		{name: "retried until success", method: http.MethodGet, failures: 2, wantReqs: 3},
+		{name: "retries exhausted", method: http.MethodGet, failures: 5, wantReqs: 4, wantRetries: 3, wantErr: true},
+		{name: "POST not retried", method: http.MethodPost, failures: 1, wantReqs: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				_, _ = rw.Write([]byte(`{"success":true}`))
+			}))
+			defer server.Close()
+
+			transport := &flakyTransport{failures: tt.failures}
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+				MaxTransportRetries: 3,
+				RetryWait:           time.Millisecond,
+			})
+			client.Host = server.URL
+			client.Client.Transport = transport
+
+			var err error
+			if tt.method == http.MethodPost {
+				err = client.Post(ctx, "/test", nil, nil, nil, nil)
+			} else {
+				err = client.Get(ctx, "/test", nil, nil, nil)
+			}
+			assert.Equal(t, tt.wantReqs, transport.requests.Load())
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, syscall.ECONNRESET)
+			var exhausted *discogs.ErrRetriesExhausted
+			if tt.wantRetries == 0 {
+				assert.False(t, errors.As(err, &exhausted))
+			} else if assert.ErrorAs(t, err, &exhausted) {
+				assert.Equal(t, tt.wantRetries, exhausted.Retries)
+			}
+		})
+	}
+}
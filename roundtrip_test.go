@@ -0,0 +1,57 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+// assertRoundTrip checks that a response type unmarshaled from a testdata fixture marshals back to JSON that
+// unmarshals into an identical value.
+func assertRoundTrip[T any](t *testing.T, fixture string) {
+	t.Helper()
+
+	data, err := os.ReadFile(fixture)
+	if err != nil {
+		assert.FailNow(t, "unable to read fixture: %v", err)
+	}
+
+	var first T
+	if err := json.Unmarshal(data, &first); err != nil {
+		assert.FailNow(t, "unable to unmarshal fixture: %v", err)
+	}
+
+	marshaled, err := json.Marshal(first)
+	assert.NoError(t, err)
+
+	var second T
+	assert.NoError(t, json.Unmarshal(marshaled, &second))
+	assert.Equal(t, first, second)
+
+	remarshaled, err := json.Marshal(second)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(marshaled), string(remarshaled))
+}
+
+func TestRoundTrip_ReleaseResponse(t *testing.T) {
+	assertRoundTrip[discogs.ReleaseResponse](t, "testdata/release.json")
+}
+
+func TestRoundTrip_SearchResponse(t *testing.T) {
+	assertRoundTrip[discogs.SearchResponse](t, "testdata/search.json")
+}
+
+func TestRoundTrip_OmitsUnsetPointers(t *testing.T) {
+	marshaled, err := json.Marshal(discogs.ReleaseResponse{Title: "Test Release", ID: 1})
+	assert.NoError(t, err)
+
+	var fields map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(marshaled, &fields))
+
+	for _, name := range []string{"community", "date_added", "date_changed", "year", "lowest_price", "master_id"} {
+		assert.NotContains(t, fields, name)
+	}
+}
@@ -0,0 +1,321 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// MaxSearchResults is the maximum number of results of a search query the Discogs API returns. Pages past this depth
+// are unreachable, no matter how many results the query matches.
+const MaxSearchResults = 10000
+
+// defaultSearchPerPage is the number of results per page the Discogs API returns if none is requested.
+const defaultSearchPerPage = 50
+
+// ErrSearchDepthExceeded indicates that a page of search results is past MaxSearchResults and cannot be fetched.
+type ErrSearchDepthExceeded struct {
+	Page    int
+	PerPage int
+}
+
+func (e *ErrSearchDepthExceeded) Error() string {
+	return fmt.Sprintf("search page %d is unreachable: only the first %d results of a query can be paged through", e.Page, MaxSearchResults)
+}
+
+// searchPageReachable reports whether the given page of search results is within MaxSearchResults.
+func searchPageReachable(page, perPage int) bool {
+	return (page-1)*perPage < MaxSearchResults
+}
+
+// checkSearchDepth returns an ErrSearchDepthExceeded if the page requested by options is past MaxSearchResults.
+func checkSearchDepth(options *SearchOptions) error {
+	if options == nil || options.Page == nil {
+		return nil
+	}
+	perPage := defaultSearchPerPage
+	if options.PerPage != nil {
+		perPage = *options.PerPage
+	}
+	if !searchPageReachable(*options.Page, perPage) {
+		return &ErrSearchDepthExceeded{Page: *options.Page, PerPage: perPage}
+	}
+	return nil
+}
+
+// A SearchNarrower splits a search query matching more than MaxSearchResults results into narrower queries that
+// together cover its results. It returns no queries if the query cannot be narrowed any further.
+type SearchNarrower func(options SearchOptions) []SearchOptions
+
+// NarrowSearchByYear returns a SearchNarrower that splits a query into one query per year from the first to the last
+// year, inclusive. Queries already restricted to a year are not narrowed. Results without a year, or outside of the
+// years, are not covered by the narrowed queries; set SearchCrawlOptions.CrawlRemainder to crawl them as well.
+func NarrowSearchByYear(first, last int) SearchNarrower {
+	return func(options SearchOptions) []SearchOptions {
+		if options.Year != "" {
+			return nil
+		}
+
+		var narrowed []SearchOptions
+		for year := first; year <= last; year++ {
+			yearOptions := options
+			yearOptions.Year = strconv.Itoa(year)
+			narrowed = append(narrowed, yearOptions)
+		}
+		return narrowed
+	}
+}
+
+// SearchCrawlOptions represents the options for crawling all results of a search query.
+type SearchCrawlOptions struct {
+	// PerPage is the number of results requested per page. DefaultCrawlPerPage is used if unset.
+	PerPage int
+	// Narrow splits queries matching more results than can be paged through into narrower queries, which are crawled
+	// instead. Queries that cannot be narrowed are crawled as deep as possible.
+	Narrow SearchNarrower
+	// CrawlRemainder crawls a narrowed query itself after its narrowed queries, reporting the results they did not
+	// report, such as the results without a year left out by NarrowSearchByYear. Results are told apart by their Type
+	// and ID, which are kept in memory for the whole crawl.
+	CrawlRemainder bool
+}
+
+// CrawlSearch pages through all results of a search query and streams them to fn one at a time. If options is nil,
+// no search options are used; if crawlOptions is nil, the default crawl options are used.
+//
+// Since only the first MaxSearchResults results of a query can be paged through, queries matching more results are
+// split using crawlOptions.Narrow, if set. If results are still unreachable, the reachable results are crawled and an
+// ErrSearchDepthExceeded is returned at the end, so missing results never go unnoticed. Results matching several
+// narrowed queries are reported once per query.
+//
+// The crawl stops when fn returns an error, which is returned by CrawlSearch unless it is ErrStopPaging.
+func (dc *DiscogsClient) CrawlSearch(ctx context.Context, options *SearchOptions, crawlOptions *SearchCrawlOptions, fn func(result SearchResult) error) error {
+	var searchOptions SearchOptions
+	if options != nil {
+		searchOptions = *options
+	}
+	var opts SearchCrawlOptions
+	if crawlOptions != nil {
+		opts = *crawlOptions
+	}
+	if opts.PerPage <= 0 {
+		opts.PerPage = DefaultCrawlPerPage
+	}
+
+	err := dc.crawlSearch(ctx, searchOptions, &opts, fn)
+	if errors.Is(err, ErrStopPaging) {
+		return nil
+	}
+	return err
+}
+
+// crawlSearch crawls the results of a single search query, narrowing it if needed. Unlike CrawlSearch, it returns
+// ErrStopPaging as is so the caller can stop crawling the remaining narrowed queries.
+func (dc *DiscogsClient) crawlSearch(ctx context.Context, options SearchOptions, opts *SearchCrawlOptions, fn func(result SearchResult) error) error {
+	for page := 1; ; page++ {
+		if !searchPageReachable(page, opts.PerPage) {
+			return &ErrSearchDepthExceeded{Page: page, PerPage: opts.PerPage}
+		}
+
+		options.Page, options.PerPage = Int(page), Int(opts.PerPage)
+		res, err := dc.Search(ctx, &options)
+		if err != nil {
+			return err
+		}
+
+		if page == 1 && res.Pagination != nil && res.Pagination.Items > MaxSearchResults && opts.Narrow != nil {
+			if narrowed := opts.Narrow(options); len(narrowed) > 0 {
+				return dc.crawlNarrowed(ctx, options, narrowed, res.Pagination.Items, opts, fn)
+			}
+		}
+
+		for _, result := range res.Results {
+			if err := fn(result); err != nil {
+				return err
+			}
+		}
+
+		if res.Pagination == nil || int64(page) >= res.Pagination.Pages || len(res.Results) == 0 {
+			return nil
+		}
+	}
+}
+
+// crawlNarrowed crawls the narrowed queries of a query matching total results, followed by the results of the query
+// they did not report if CrawlRemainder is set. It returns an ErrSearchDepthExceeded at the end if fewer than total
+// results may have been reported.
+func (dc *DiscogsClient) crawlNarrowed(ctx context.Context, options SearchOptions, narrowed []SearchOptions, total int64, opts *SearchCrawlOptions, fn func(result SearchResult) error) error {
+	type resultKey struct {
+		resultType Type
+		id         int64
+	}
+	seen := make(map[resultKey]struct{})
+	key := func(result SearchResult) resultKey {
+		var id int64
+		if result.ID != nil {
+			id = *result.ID
+		}
+		return resultKey{result.Type, id}
+	}
+
+	narrowedFn := fn
+	if opts.CrawlRemainder {
+		narrowedFn = func(result SearchResult) error {
+			seen[key(result)] = struct{}{}
+			return fn(result)
+		}
+	}
+
+	var depthErr error
+	for _, narrowedOptions := range narrowed {
+		err := dc.crawlSearch(ctx, narrowedOptions, opts, narrowedFn)
+		var exceeded *ErrSearchDepthExceeded
+		if errors.As(err, &exceeded) {
+			// Keep crawling the other queries, but report the unreachable results at the end
+			depthErr = err
+		} else if err != nil {
+			return err
+		}
+	}
+	if !opts.CrawlRemainder {
+		return depthErr
+	}
+
+	// Page through the query itself until every result it matches has been reported
+	for page := 1; int64(len(seen)) < total; page++ {
+		if !searchPageReachable(page, opts.PerPage) {
+			return &ErrSearchDepthExceeded{Page: page, PerPage: opts.PerPage}
+		}
+
+		options.Page, options.PerPage = Int(page), Int(opts.PerPage)
+		res, err := dc.Search(ctx, &options)
+		if err != nil {
+			return err
+		}
+
+		for _, result := range res.Results {
+			if _, ok := seen[key(result)]; ok {
+				continue
+			}
+			seen[key(result)] = struct{}{}
+			if err := fn(result); err != nil {
+				return err
+			}
+		}
+
+		if res.Pagination == nil || int64(page) >= res.Pagination.Pages || len(res.Results) == 0 {
+			return depthErr
+		}
+	}
+	return nil
+}
+
+// Decode returns the search result as the result type matching its Type: a *ReleaseResult, *MasterResult,
+// *ArtistResult or *LabelResult. It returns nil if the type is unknown.
+//
+// Example:
+//
+//	switch result := result.Decode().(type) {
+//	case *discogs.ReleaseResult:
+//		fmt.Println(result.Title, result.CatNo)
+//	case *discogs.ArtistResult:
+//		fmt.Println(result.Name)
+//	}
+func (s *SearchResult) Decode() any {
+	switch s.Type {
+	case TypeRelease:
+		release, _ := s.AsRelease()
+		return release
+	case TypeMaster:
+		master, _ := s.AsMaster()
+		return master
+	case TypeArtist:
+		artist, _ := s.AsArtist()
+		return artist
+	case TypeLabel:
+		label, _ := s.AsLabel()
+		return label
+	}
+	return nil
+}
+
+// AsRelease returns the search result as a ReleaseResult, and reports whether it is a release.
+func (s *SearchResult) AsRelease() (*ReleaseResult, bool) {
+	if s.Type != TypeRelease {
+		return nil, false
+	}
+	return &ReleaseResult{
+		ID:          s.GetID(),
+		Title:       s.Title,
+		Year:        s.Year,
+		Country:     s.Country,
+		Format:      s.Format,
+		Label:       s.Label,
+		CatNo:       s.CatNo,
+		Barcode:     s.Barcode,
+		Genre:       s.Genre,
+		Style:       s.Style,
+		Community:   s.Community,
+		MasterID:    s.MasterID,
+		Thumb:       s.Thumb,
+		CoverImage:  s.CoverImage,
+		URI:         s.URI,
+		ResourceURL: s.ResourceURL,
+	}, true
+}
+
+// AsMaster returns the search result as a MasterResult, and reports whether it is a master.
+func (s *SearchResult) AsMaster() (*MasterResult, bool) {
+	if s.Type != TypeMaster {
+		return nil, false
+	}
+	return &MasterResult{
+		ID:          s.GetID(),
+		Title:       s.Title,
+		Year:        s.Year,
+		Country:     s.Country,
+		Format:      s.Format,
+		Label:       s.Label,
+		CatNo:       s.CatNo,
+		Barcode:     s.Barcode,
+		Genre:       s.Genre,
+		Style:       s.Style,
+		Community:   s.Community,
+		Thumb:       s.Thumb,
+		CoverImage:  s.CoverImage,
+		URI:         s.URI,
+		ResourceURL: s.ResourceURL,
+	}, true
+}
+
+// AsArtist returns the search result as an ArtistResult, and reports whether it is an artist. The name of the artist
+// is the title of the result.
+func (s *SearchResult) AsArtist() (*ArtistResult, bool) {
+	if s.Type != TypeArtist {
+		return nil, false
+	}
+	return &ArtistResult{
+		ID:          s.GetID(),
+		Name:        s.Title,
+		Thumb:       s.Thumb,
+		CoverImage:  s.CoverImage,
+		URI:         s.URI,
+		ResourceURL: s.ResourceURL,
+	}, true
+}
+
+// AsLabel returns the search result as a LabelResult, and reports whether it is a label. The name of the label is the
+// title of the result.
+func (s *SearchResult) AsLabel() (*LabelResult, bool) {
+	if s.Type != TypeLabel {
+		return nil, false
+	}
+	return &LabelResult{
+		ID:          s.GetID(),
+		Name:        s.Title,
+		Thumb:       s.Thumb,
+		CoverImage:  s.CoverImage,
+		URI:         s.URI,
+		ResourceURL: s.ResourceURL,
+	}, true
+}
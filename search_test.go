@@ -0,0 +1,190 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_SearchDepth(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		_, _ = rw.Write([]byte(`{"results":[]}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret})
+	client.Host = server.URL
+
+	_, err := client.Search(ctx, &discogs.SearchOptions{PaginationParams: discogs.PaginationParams{Page: discogs.Int(200)}})
+	assert.NoError(t, err)
+
+	_, err = client.Search(ctx, &discogs.SearchOptions{PaginationParams: discogs.PaginationParams{Page: discogs.Int(201)}})
+	assert.Equal(t, &discogs.ErrSearchDepthExceeded{Page: 201, PerPage: 50}, err)
+
+	_, err = client.Search(ctx, &discogs.SearchOptions{PaginationParams: discogs.PaginationParams{Page: discogs.Int(101), PerPage: discogs.Int(100)}})
+	assert.Equal(t, &discogs.ErrSearchDepthExceeded{Page: 101, PerPage: 100}, err)
+
+	assert.Equal(t, int64(1), requests.Load())
+}
+
+func TestDiscogsClient_CrawlSearch(t *testing.T) {
+	// The number of results matching each year, where the empty year matches all results
+	items := map[string]int64{"": 25000, "2000": 2, "2001": 12000}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		year := req.URL.Query().Get("year")
+		page, _ := strconv.ParseInt(req.URL.Query().Get("page"), 10, 64)
+		perPage, _ := strconv.ParseInt(req.URL.Query().Get("per_page"), 10, 64)
+
+		// Every page only holds a single result to keep the responses small
+		res := discogs.SearchResponse{
+			Pagination: &discogs.Pagination{Page: page, Pages: (items[year] + perPage - 1) / perPage, Items: items[year], PerPage: perPage},
+			Results:    []discogs.SearchResult{{Title: year + "#" + strconv.FormatInt(page, 10)}},
+		}
+		_ = json.NewEncoder(rw).Encode(res)
+	}))
+	defer server.Close()
+
+	type want struct {
+		titles []string
+		err    error
+	}
+	tests := []struct {
+		name    string
+		options *discogs.SearchCrawlOptions
+		query   *discogs.SearchOptions
+		want    want
+	}{
+		{
+			"CrawlSearch within depth",
+			&discogs.SearchCrawlOptions{PerPage: 5000},
+			&discogs.SearchOptions{Year: "2000"},
+			want{[]string{"2000#1"}, nil},
+		},
+		{
+			"CrawlSearch past depth",
+			&discogs.SearchCrawlOptions{PerPage: 5000},
+			nil,
+			want{[]string{"#1", "#2"}, &discogs.ErrSearchDepthExceeded{Page: 3, PerPage: 5000}},
+		},
+		{
+			"CrawlSearch narrowing past depth",
+			&discogs.SearchCrawlOptions{PerPage: 5000, Narrow: discogs.NarrowSearchByYear(2000, 2001)},
+			nil,
+			want{[]string{"2000#1", "2001#1", "2001#2"}, &discogs.ErrSearchDepthExceeded{Page: 3, PerPage: 5000}},
+		},
+		{
+			"CrawlSearch narrowing within depth",
+			&discogs.SearchCrawlOptions{PerPage: 5000, Narrow: discogs.NarrowSearchByYear(2000, 2000)},
+			nil,
+			want{[]string{"2000#1"}, nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret})
+			client.Host = server.URL
+
+			var titles []string
+			err := client.CrawlSearch(ctx, tt.query, tt.options, func(result discogs.SearchResult) error {
+				titles = append(titles, result.Title)
+				return nil
+			})
+
+			assert.Equal(t, tt.want.err, err)
+			assert.Equal(t, tt.want.titles, titles)
+		})
+	}
+}
+
+func TestDiscogsClient_CrawlSearchRemainder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		year := req.URL.Query().Get("year")
+		page, _ := strconv.ParseInt(req.URL.Query().Get("page"), 10, 64)
+
+		// The query matches a release of 2000 and releases without a year
+		res := discogs.SearchResponse{Pagination: &discogs.Pagination{Page: page, Pages: 1, Items: 1}}
+		switch {
+		case year == "2000":
+			res.Results = []discogs.SearchResult{{Type: discogs.TypeRelease, ID: discogs.Int64(1), Title: "2000"}}
+		case page == 1:
+			res.Pagination = &discogs.Pagination{Page: page, Pages: 3, Items: 15000}
+			res.Results = []discogs.SearchResult{
+				{Type: discogs.TypeRelease, ID: discogs.Int64(1), Title: "2000"},
+				{Type: discogs.TypeRelease, ID: discogs.Int64(2), Title: "none#1"},
+			}
+		default:
+			res.Pagination = &discogs.Pagination{Page: page, Pages: 3, Items: 15000}
+			res.Results = []discogs.SearchResult{{Type: discogs.TypeRelease, ID: discogs.Int64(3), Title: "none#2"}}
+		}
+		_ = json.NewEncoder(rw).Encode(res)
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret})
+	client.Host = server.URL
+
+	var titles []string
+	crawlOptions := &discogs.SearchCrawlOptions{PerPage: 5000, Narrow: discogs.NarrowSearchByYear(2000, 2000), CrawlRemainder: true}
+	err := client.CrawlSearch(ctx, nil, crawlOptions, func(result discogs.SearchResult) error {
+		titles = append(titles, result.Title)
+		return nil
+	})
+	assert.Equal(t, &discogs.ErrSearchDepthExceeded{Page: 3, PerPage: 5000}, err)
+	assert.Equal(t, []string{"2000", "none#1", "none#2"}, titles)
+}
+
+func TestSearchResult_Decode(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want any
+	}{
+		{
+			"release",
+			`{"type":"release","id":1,"title":"Nirvana - Nevermind","year":"1991","catno":"DGC-24425","label":["DGC"],"master_id":13814}`,
+			&discogs.ReleaseResult{ID: 1, Title: "Nirvana - Nevermind", Year: "1991", CatNo: "DGC-24425", Label: []string{"DGC"}, MasterID: discogs.Int64(13814)},
+		},
+		{
+			"master",
+			`{"type":"master","id":13814,"title":"Nirvana - Nevermind","year":"1991","master_id":13814}`,
+			&discogs.MasterResult{ID: 13814, Title: "Nirvana - Nevermind", Year: "1991"},
+		},
+		{
+			"artist",
+			`{"type":"artist","id":125246,"title":"Nirvana","thumb":"thumb.jpg","cover_image":"cover.jpg"}`,
+			&discogs.ArtistResult{ID: 125246, Name: "Nirvana", Thumb: "thumb.jpg", CoverImage: "cover.jpg"},
+		},
+		{
+			"label",
+			`{"type":"label","id":1,"title":"Planet E"}`,
+			&discogs.LabelResult{ID: 1, Name: "Planet E"},
+		},
+		{
+			"unknown type",
+			`{"type":"user","id":1}`,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result discogs.SearchResult
+			assert.NoError(t, json.Unmarshal([]byte(tt.data), &result))
+			assert.Equal(t, tt.want, result.Decode())
+		})
+	}
+
+	result := discogs.SearchResult{Type: discogs.TypeArtist, ID: discogs.Int64(1)}
+	release, ok := result.AsRelease()
+	assert.False(t, ok)
+	assert.Nil(t, release)
+}
@@ -0,0 +1,145 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// A LimiterBackend stores the state of rate limiters outside of a DiscogsClient, so processes sharing a Discogs token,
+// such as the pods of a horizontally scaled service, share one limit instead of each allowing the full limit.
+// Implementations must be safe for concurrent use, and take tokens atomically across all the processes using them.
+type LimiterBackend interface {
+	// Take takes a token from the bucket of key, which holds up to burst tokens and is refilled at limit tokens per
+	// second, and returns how long the request must wait before being sent. The token is taken even if the wait is
+	// not 0, so concurrent requests are spaced out rather than all sent once a token is available.
+	Take(ctx context.Context, key string, limit rate.Limit, burst int) (time.Duration, error)
+}
+
+// SharedLimiter is a Limiter keeping its state in a LimiterBackend. Every SharedLimiter using the same backend and key
+// shares the same bucket, which is the number of requests sent to a host across all processes. It is usually created
+// by DiscogsConfig.NewLimiter:
+//
+//	NewLimiter: func(host string, requestsPerMinute int) discogs.Limiter {
+//		return discogs.NewSharedLimiter(backend, host, requestsPerMinute)
+//	},
+type SharedLimiter struct {
+	backend LimiterBackend
+	key     string
+
+	mu    sync.Mutex
+	limit rate.Limit
+	burst int
+}
+
+// NewSharedLimiter creates a new SharedLimiter allowing requestsPerMinute requests per minute for the bucket of key.
+func NewSharedLimiter(backend LimiterBackend, key string, requestsPerMinute int) *SharedLimiter {
+	return &SharedLimiter{
+		backend: backend,
+		key:     key,
+		limit:   rate.Every(time.Minute / time.Duration(max(requestsPerMinute, 1))),
+		burst:   max(requestsPerMinute, 1),
+	}
+}
+
+// Wait takes a token from the backend and blocks until the request can be sent, or returns an error if ctx is done
+// first or the backend fails. The token is not given back if ctx is done while waiting.
+func (l *SharedLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	limit, burst := l.limit, l.burst
+	l.mu.Unlock()
+
+	if limit == rate.Inf {
+		return ctx.Err()
+	}
+	wait, err := l.backend.Take(ctx, l.key, limit, burst)
+	if err != nil {
+		return err
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetLimit sets the number of requests allowed per second. Since the limit is not stored in the backend, every
+// process adjusts its own limit, usually to the same rate limit reported by Discogs.
+func (l *SharedLimiter) SetLimit(limit rate.Limit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = limit
+}
+
+// SetBurst sets the number of requests that can be sent at once after the limiter was idle.
+func (l *SharedLimiter) SetBurst(burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.burst = burst
+}
+
+// memoryLimiterSweepInterval is the minimum time between two sweeps of the idle keys of a MemoryLimiterBackend.
+const memoryLimiterSweepInterval = time.Minute
+
+// MemoryLimiterBackend is a LimiterBackend keeping the buckets in memory, which shares a limit between the clients of
+// a single process. It implements the generic cell rate algorithm, storing the time the bucket is full again for every
+// key. Keys whose bucket is full again are idle, and are dropped at most every minute, so a backend shared by
+// short-lived keys, such as one per user, does not grow without bound.
+type MemoryLimiterBackend struct {
+	mu        sync.Mutex
+	full      map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryLimiterBackend creates a new MemoryLimiterBackend with full buckets.
+func NewMemoryLimiterBackend() *MemoryLimiterBackend {
+	return &MemoryLimiterBackend{full: make(map[string]time.Time)}
+}
+
+// Take takes a token from the bucket of key and returns how long the request must wait before being sent.
+func (b *MemoryLimiterBackend) Take(_ context.Context, key string, limit rate.Limit, burst int) (time.Duration, error) {
+	if limit == rate.Inf {
+		return 0, nil
+	}
+	if limit <= 0 {
+		return 0, &ErrInvalidOption{Option: "limit", Value: fmt.Sprint(float64(limit))}
+	}
+	interval := time.Duration(float64(time.Second) / float64(limit))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(b.lastSweep) >= memoryLimiterSweepInterval {
+		b.sweep(now)
+	}
+
+	full := b.full[key]
+	if full.Before(now) {
+		full = now
+	}
+	full = full.Add(interval)
+	b.full[key] = full
+	return max(full.Sub(now)-time.Duration(max(burst, 1))*interval, 0), nil
+}
+
+// sweep drops the keys whose bucket is full again at now, since a missing key has a full bucket too. The caller must
+// hold b.mu.
+func (b *MemoryLimiterBackend) sweep(now time.Time) {
+	for key, full := range b.full {
+		if !full.After(now) {
+			delete(b.full, key)
+		}
+	}
+	b.lastSweep = now
+}
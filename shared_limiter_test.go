@@ -0,0 +1,96 @@
+package discogs_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestMemoryLimiterBackend_Take(t *testing.T) {
+	backend := discogs.NewMemoryLimiterBackend()
+	limit := rate.Every(time.Second)
+
+	tests := []struct {
+		name string
+		key  string
+		want time.Duration
+	}{
+		{name: "first token of the burst", key: "a", want: 0},
+		{name: "second token of the burst", key: "a", want: 0},
+		{name: "empty bucket", key: "a", want: time.Second},
+		{name: "waiting request", key: "a", want: 2 * time.Second},
+		{name: "other key", key: "b", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, err := backend.Take(ctx, tt.key, limit, 2)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, wait, float64(50*time.Millisecond))
+		})
+	}
+
+	wait, err := backend.Take(ctx, "a", rate.Inf, 0)
+	assert.NoError(t, err)
+	assert.Zero(t, wait)
+
+	_, err = backend.Take(ctx, "a", 0, 1)
+	assert.IsType(t, &discogs.ErrInvalidOption{}, err)
+}
+
+func TestMemoryLimiterBackend_Sweep(t *testing.T) {
+	backend := discogs.NewMemoryLimiterBackend()
+
+	_, err := backend.Take(ctx, "idle", rate.Every(10*time.Millisecond), 1)
+	assert.NoError(t, err)
+	_, err = backend.Take(ctx, "busy", rate.Every(time.Hour), 1)
+	assert.NoError(t, err)
+
+	// Only the key whose bucket is full again is dropped
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 1, backend.Sweep())
+
+	wait, err := backend.Take(ctx, "busy", rate.Every(time.Hour), 1)
+	assert.NoError(t, err)
+	assert.InDelta(t, time.Hour, wait, float64(time.Second))
+}
+
+func TestSharedLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// Two clients, such as two pods, share the bucket of the host
+	backend := discogs.NewMemoryLimiterBackend()
+	newClient := func() *discogs.DiscogsClient {
+		client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{
+			MaxRequests: 600,
+			NewLimiter: func(host string, requestsPerMinute int) discogs.Limiter {
+				limiter := discogs.NewSharedLimiter(backend, host, requestsPerMinute)
+				limiter.SetBurst(1)
+				return limiter
+			},
+		})
+		client.Host = server.URL
+		return client
+	}
+	first, second := newClient(), newClient()
+
+	start := time.Now()
+	assert.NoError(t, first.Get(ctx, "/releases/1", nil, nil, nil))
+	assert.NoError(t, second.Get(ctx, "/releases/1", nil, nil, nil))
+	assert.NoError(t, first.Get(ctx, "/releases/1", nil, nil, nil))
+	assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+
+	// The token is taken before waiting, so a cancelled wait fails without sending the request
+	cancelCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, second.Get(cancelCtx, "/releases/1", nil, nil, nil), context.DeadlineExceeded)
+}
@@ -0,0 +1,174 @@
+package discogs
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// statsSampleSize is the number of most recent latencies kept per endpoint to compute latency percentiles.
+const statsSampleSize = 1024
+
+// routeKey is the context key for the route pattern of a request.
+type routeKey struct{}
+
+// withRoute returns a copy of ctx carrying the route pattern of a request.
+func withRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey{}, route)
+}
+
+// requestRoute returns the route pattern of req, or its path if the request carries no route pattern.
+func requestRoute(req *http.Request) string {
+	if route, ok := req.Context().Value(routeKey{}).(string); ok {
+		return route
+	}
+	return req.URL.Path
+}
+
+// EndpointStats represents the request statistics of a single endpoint, identified by its HTTP method and route
+// pattern (e.g. "GET /releases/{release_id}").
+type EndpointStats struct {
+	Method string
+	Route  string
+	// Requests is the number of attempts sent to the endpoint, including retries.
+	Requests int64
+	// Errors is the number of attempts that failed, either with a transport error or a non-2xx status code.
+	Errors int64
+	// Retries is the number of attempts sent in addition to the first attempt of a request, such as hedged requests.
+	Retries int64
+	// ErrorRate is the fraction of attempts that failed.
+	ErrorRate float64
+	// P50, P90 and P99 are latency percentiles over the most recent attempts, excluding time spent waiting on the
+	// rate limiter.
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+}
+
+// Stats represents a snapshot of the request statistics of a DiscogsClient.
+type Stats struct {
+	Requests  int64
+	Errors    int64
+	Retries   int64
+	Endpoints []EndpointStats
+}
+
+// Stats returns a snapshot of the request statistics collected by the DiscogsClient, including per-endpoint latency
+// percentiles, error rates and retry counts. Endpoints are sorted by route and method.
+func (dc *DiscogsClient) Stats() Stats {
+	return dc.stats.snapshot()
+}
+
+// endpointKey identifies an endpoint in clientStats.
+type endpointKey struct {
+	method string
+	route  string
+}
+
+// endpointStats holds the raw statistics of a single endpoint.
+type endpointStats struct {
+	requests  int64
+	errors    int64
+	retries   int64
+	latencies []time.Duration
+	next      int
+}
+
+// clientStats collects request statistics per endpoint. The zero value is ready to use.
+type clientStats struct {
+	mu        sync.Mutex
+	endpoints map[endpointKey]*endpointStats
+}
+
+// endpoint returns the statistics of the endpoint of req, creating them on first use. The caller must hold s.mu.
+func (s *clientStats) endpoint(req *http.Request) *endpointStats {
+	key := endpointKey{req.Method, requestRoute(req)}
+	if s.endpoints == nil {
+		s.endpoints = make(map[endpointKey]*endpointStats)
+	}
+
+	stats, ok := s.endpoints[key]
+	if !ok {
+		stats = &endpointStats{}
+		s.endpoints[key] = stats
+	}
+	return stats
+}
+
+// recordAttempt records the latency and outcome of an attempt of req.
+func (s *clientStats) recordAttempt(req *http.Request, latency time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.endpoint(req)
+	stats.requests++
+	if err != nil {
+		stats.errors++
+	}
+
+	if len(stats.latencies) < statsSampleSize {
+		stats.latencies = append(stats.latencies, latency)
+	} else {
+		stats.latencies[stats.next] = latency
+		stats.next = (stats.next + 1) % statsSampleSize
+	}
+}
+
+// recordRetry records that an additional attempt of req is being sent.
+func (s *clientStats) recordRetry(req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.endpoint(req).retries++
+}
+
+// snapshot returns a snapshot of the collected statistics.
+func (s *clientStats) snapshot() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var snapshot Stats
+	for key, stats := range s.endpoints {
+		endpoint := EndpointStats{
+			Method:   key.method,
+			Route:    key.route,
+			Requests: stats.requests,
+			Errors:   stats.errors,
+			Retries:  stats.retries,
+		}
+		if stats.requests > 0 {
+			endpoint.ErrorRate = float64(stats.errors) / float64(stats.requests)
+		}
+
+		latencies := make([]time.Duration, len(stats.latencies))
+		copy(latencies, stats.latencies)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		endpoint.P50 = percentile(latencies, 0.50)
+		endpoint.P90 = percentile(latencies, 0.90)
+		endpoint.P99 = percentile(latencies, 0.99)
+
+		snapshot.Requests += stats.requests
+		snapshot.Errors += stats.errors
+		snapshot.Retries += stats.retries
+		snapshot.Endpoints = append(snapshot.Endpoints, endpoint)
+	}
+
+	sort.Slice(snapshot.Endpoints, func(i, j int) bool {
+		if snapshot.Endpoints[i].Route != snapshot.Endpoints[j].Route {
+			return snapshot.Endpoints[i].Route < snapshot.Endpoints[j].Route
+		}
+		return snapshot.Endpoints[i].Method < snapshot.Endpoints[j].Method
+	})
+	return snapshot
+}
+
+// percentile returns the p-th percentile of the sorted latencies using the nearest-rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
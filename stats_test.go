@@ -0,0 +1,56 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_Stats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/releases/2" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	assert.Equal(t, discogs.Stats{}, client.Stats())
+
+	for _, id := range []int64{1, 1, 1, 2} {
+		_, _ = client.Release(ctx, id, nil)
+	}
+	assert.NoError(t, client.Post(ctx, "/test", nil, nil, nil, nil))
+
+	stats := client.Stats()
+	assert.Equal(t, int64(5), stats.Requests)
+	assert.Equal(t, int64(1), stats.Errors)
+	assert.Equal(t, int64(0), stats.Retries)
+
+	if assert.Len(t, stats.Endpoints, 2) {
+		releases := stats.Endpoints[0]
+		assert.Equal(t, http.MethodGet, releases.Method)
+		assert.Equal(t, "/releases/{release_id}", releases.Route)
+		assert.Equal(t, int64(4), releases.Requests)
+		assert.Equal(t, int64(1), releases.Errors)
+		assert.Equal(t, 0.25, releases.ErrorRate)
+		assert.GreaterOrEqual(t, releases.P90, 5*time.Millisecond)
+		assert.LessOrEqual(t, releases.P50, releases.P90)
+		assert.LessOrEqual(t, releases.P90, releases.P99)
+
+		test := stats.Endpoints[1]
+		assert.Equal(t, http.MethodPost, test.Method)
+		assert.Equal(t, "/test", test.Route)
+		assert.Equal(t, int64(1), test.Requests)
+		assert.Equal(t, float64(0), test.ErrorRate)
+	}
+}
@@ -0,0 +1,253 @@
+package discogs
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns a compact, human-readable summary of the release.
+//
+// Example: "Nirvana - Nevermind (1991, Vinyl) [r1234]".
+func (r ReleaseResponse) String() string {
+	var sb strings.Builder
+
+	if artists := r.ArtistNames(); artists != "" {
+		sb.WriteString(artists)
+		sb.WriteString(" - ")
+	}
+	sb.WriteString(r.Title)
+
+	var details []string
+	if r.Year != nil && *r.Year != 0 {
+		details = append(details, strconv.FormatInt(*r.Year, 10))
+	}
+	if formats := formatNames(r.Formats); formats != "" {
+		details = append(details, formats)
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&sb, " (%s)", strings.Join(details, ", "))
+	}
+
+	fmt.Fprintf(&sb, " [r%d]", r.ID)
+	return sb.String()
+}
+
+// Details returns a verbose, multi-line, human-readable description of the release, suitable for debug output.
+func (r ReleaseResponse) Details() string {
+	var sb strings.Builder
+
+	writeLine := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&sb, "%-10s %s\n", name+":", value)
+		}
+	}
+
+	writeLine("Release", r.String())
+	writeLine("Artists", r.ArtistNames())
+
+	labels := make([]string, 0, len(r.Labels))
+	for _, label := range r.Labels {
+		labels = append(labels, strings.TrimSpace(label.Name+" "+label.CatNo))
+	}
+	writeLine("Labels", strings.Join(labels, ", "))
+
+	formats := make([]string, 0, len(r.Formats))
+	for _, format := range r.Formats {
+		formats = append(formats, strings.Join(append([]string{format.Name}, format.Descriptions...), ", "))
+	}
+	writeLine("Formats", strings.Join(formats, "; "))
+
+	writeLine("Country", r.Country)
+	writeLine("Released", r.Released)
+	writeLine("Genres", strings.Join(r.Genres, ", "))
+	writeLine("Styles", strings.Join(r.Styles, ", "))
+
+	if len(r.Tracklist) > 0 {
+		sb.WriteString("Tracklist:\n")
+		for _, track := range r.Tracklist {
+			line := strings.TrimSpace(track.Position + " " + track.Title)
+			if track.Duration != "" {
+				line += " (" + track.Duration + ")"
+			}
+			fmt.Fprintf(&sb, "  %s\n", line)
+		}
+	}
+
+	return sb.String()
+}
+
+// ArtistNames returns the names of the release's artists, combined using their join strings.
+//
+// Example: "Simon & Garfunkel".
+func (r ReleaseResponse) ArtistNames() string {
+	return artistNames(r.Artists)
+}
+
+// ArtistNames returns the names of the release's artists, combined using their join strings.
+//
+// Example: "Simon & Garfunkel".
+func (b BasicInformation) ArtistNames() string {
+	return artistNames(b.Artists)
+}
+
+// artistNames returns the names of the artists, preferring their name variation, combined using their join strings.
+func artistNames(artists []ArtistCredit) string {
+	var sb strings.Builder
+	for i, artist := range artists {
+		name := artist.Name
+		if artist.ANV != "" {
+			name = artist.ANV
+		}
+		sb.WriteString(name)
+
+		if i < len(artists)-1 {
+			switch join := strings.TrimSpace(artist.Join); join {
+			case "", ",":
+				sb.WriteString(join + " ")
+			default:
+				sb.WriteString(" " + join + " ")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// String returns a compact, human-readable summary of the search result.
+//
+// Example: "Nirvana - Nevermind (1991, Vinyl, LP) [release 1234]".
+func (s SearchResult) String() string {
+	var sb strings.Builder
+	sb.WriteString(s.Title)
+
+	var details []string
+	if s.Year != "" {
+		details = append(details, s.Year)
+	}
+	details = append(details, s.Format...)
+	if len(details) > 0 {
+		fmt.Fprintf(&sb, " (%s)", strings.Join(details, ", "))
+	}
+
+	fmt.Fprintf(&sb, " [%s %d]", s.Type, Int64Value(s.ID))
+	return sb.String()
+}
+
+// formatNames returns the distinct names of the formats, separated by commas.
+func formatNames(formats []Format) string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, format := range formats {
+		if format.Name == "" || seen[format.Name] {
+			continue
+		}
+		seen[format.Name] = true
+		names = append(names, format.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// String returns a compact, human-readable summary of the collection item.
+//
+// Example: "Simon & Garfunkel - Bookends (1968, Vinyl) [r1234, instance 5678]".
+func (c CollectionItem) String() string {
+	var info BasicInformation
+	if c.BasicInformation != nil {
+		info = *c.BasicInformation
+	}
+
+	var sb strings.Builder
+	if artists := info.ArtistNames(); artists != "" {
+		sb.WriteString(artists)
+		sb.WriteString(" - ")
+	}
+	sb.WriteString(info.Title)
+
+	var details []string
+	if info.Year != nil && *info.Year != 0 {
+		details = append(details, strconv.FormatInt(*info.Year, 10))
+	}
+	if formats := formatNames(info.Formats); formats != "" {
+		details = append(details, formats)
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&sb, " (%s)", strings.Join(details, ", "))
+	}
+
+	fmt.Fprintf(&sb, " [r%d, instance %d]", c.ID, c.InstanceID)
+	return strings.TrimSpace(sb.String())
+}
+
+// String returns a compact, human-readable summary of the listing.
+//
+// Example: "Nirvana - Nevermind (LP, Album), VG+/VG, €12.50 [l1234]".
+func (l Listing) String() string {
+	var sb strings.Builder
+	if l.Release != nil {
+		switch {
+		case l.Release.Description != "":
+			sb.WriteString(l.Release.Description)
+		case l.Release.Artist != "":
+			sb.WriteString(l.Release.Artist + " - " + l.Release.Title)
+		default:
+			sb.WriteString(l.Release.Title)
+		}
+	}
+
+	var details []string
+	if grades := conditionGrades(l.Condition, l.SleeveCondition); grades != "" {
+		details = append(details, grades)
+	}
+	if l.Price != nil {
+		details = append(details, l.Price.String())
+	}
+	if len(details) > 0 {
+		if sb.Len() > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(strings.Join(details, ", "))
+	}
+
+	fmt.Fprintf(&sb, " [l%d]", l.ID)
+	return strings.TrimSpace(sb.String())
+}
+
+// String returns a compact, human-readable summary of the order.
+//
+// Example: "Shipped: 2 items to buyer, €30.00 [order 123-1]".
+func (o Order) String() string {
+	var sb strings.Builder
+	if o.Status != "" {
+		sb.WriteString(string(o.Status) + ": ")
+	}
+
+	if len(o.Items) == 1 {
+		sb.WriteString("1 item")
+	} else {
+		fmt.Fprintf(&sb, "%d items", len(o.Items))
+	}
+	if o.Buyer != nil && o.Buyer.Username != "" {
+		sb.WriteString(" to " + o.Buyer.Username)
+	}
+	if o.Total != nil {
+		sb.WriteString(", " + o.Total.String())
+	}
+
+	fmt.Fprintf(&sb, " [order %s]", o.ID)
+	return sb.String()
+}
+
+// conditionGrades returns the grades of the media and the sleeve, abbreviated if possible, separated by a slash.
+//
+// Example: "VG+/VG".
+func conditionGrades(media Condition, sleeve SleeveCondition) string {
+	var grades []string
+	if media != "" {
+		grades = append(grades, cmp.Or(media.Abbreviation(), string(media)))
+	}
+	if sleeve != "" {
+		grades = append(grades, cmp.Or(Condition(sleeve).Abbreviation(), string(sleeve)))
+	}
+	return strings.Join(grades, "/")
+}
@@ -0,0 +1,207 @@
+package discogs_test
+
+import (
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseResponse_String(t *testing.T) {
+	tests := []struct {
+		name string
+		args discogs.ReleaseResponse
+		want string
+	}{
+		{
+			"Release with only title",
+			discogs.ReleaseResponse{Title: "Nevermind", ID: 1},
+			"Nevermind [r1]",
+		},
+		{
+			"Release with artists, year and formats",
+			discogs.ReleaseResponse{
+				Title: "Bridge Over Troubled Water",
+				ID:    2,
+				Artists: []discogs.ArtistCredit{
+					{Name: "Simon", Join: "&"},
+					{Name: "Garfunkel"},
+				},
+				Year:    discogs.Int64(1970),
+				Formats: []discogs.Format{{Name: "Vinyl"}, {Name: "Vinyl"}, {Name: "CD"}},
+			},
+			"Simon & Garfunkel - Bridge Over Troubled Water (1970, Vinyl, CD) [r2]",
+		},
+		{
+			"Release with comma joined artists and ANV",
+			discogs.ReleaseResponse{
+				Title: "Compilation",
+				ID:    3,
+				Artists: []discogs.ArtistCredit{
+					{Name: "Artist (2)", ANV: "Artist", Join: ","},
+					{Name: "Other"},
+				},
+			},
+			"Artist, Other - Compilation [r3]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.args.String())
+		})
+	}
+}
+
+func TestReleaseResponse_Details(t *testing.T) {
+	release := discogs.ReleaseResponse{
+		Title:     "Nevermind",
+		ID:        1,
+		Artists:   []discogs.ArtistCredit{{Name: "Nirvana"}},
+		Labels:    []discogs.LabelCredit{{Name: "DGC", CatNo: "DGC-24425"}},
+		Formats:   []discogs.Format{{Name: "Vinyl", Descriptions: []string{"LP", "Album"}}},
+		Country:   "US",
+		Genres:    []string{"Rock"},
+		Tracklist: []discogs.Track{{Position: "A1", Title: "Smells Like Teen Spirit", Duration: "5:01"}},
+	}
+
+	want := "Release:   Nirvana - Nevermind (Vinyl) [r1]\n" +
+		"Artists:   Nirvana\n" +
+		"Labels:    DGC DGC-24425\n" +
+		"Formats:   Vinyl, LP, Album\n" +
+		"Country:   US\n" +
+		"Genres:    Rock\n" +
+		"Tracklist:\n" +
+		"  A1 Smells Like Teen Spirit (5:01)\n"
+
+	assert.Equal(t, want, release.Details())
+}
+
+func TestSearchResult_String(t *testing.T) {
+	tests := []struct {
+		name string
+		args discogs.SearchResult
+		want string
+	}{
+		{
+			"SearchResult release",
+			discogs.SearchResult{Title: "Nirvana - Nevermind", Year: "1991", Format: []string{"Vinyl", "LP"}, Type: discogs.TypeRelease, ID: discogs.Int64(1)},
+			"Nirvana - Nevermind (1991, Vinyl, LP) [release 1]",
+		},
+		{
+			"SearchResult artist",
+			discogs.SearchResult{Title: "Nirvana", Type: discogs.TypeArtist, ID: discogs.Int64(2)},
+			"Nirvana [artist 2]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.args.String())
+		})
+	}
+}
+
+func TestCollectionItem_String(t *testing.T) {
+	tests := []struct {
+		name string
+		args discogs.CollectionItem
+		want string
+	}{
+		{
+			"Item without basic information",
+			discogs.CollectionItem{ID: 1, InstanceID: 2},
+			"[r1, instance 2]",
+		},
+		{
+			"Item with artists, year and formats",
+			discogs.CollectionItem{
+				ID:         10,
+				InstanceID: 100,
+				BasicInformation: &discogs.BasicInformation{
+					Title:   "Bookends",
+					Year:    discogs.Int64(1968),
+					Artists: []discogs.ArtistCredit{{Name: "Simon", Join: "&"}, {Name: "Garfunkel"}},
+					Formats: []discogs.Format{{Name: "Vinyl"}},
+				},
+			},
+			"Simon & Garfunkel - Bookends (1968, Vinyl) [r10, instance 100]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.args.String())
+		})
+	}
+}
+
+func TestListing_String(t *testing.T) {
+	tests := []struct {
+		name string
+		args discogs.Listing
+		want string
+	}{
+		{
+			"Listing without release",
+			discogs.Listing{ID: 1},
+			"[l1]",
+		},
+		{
+			"Listing with description, conditions and price",
+			discogs.Listing{
+				ID:              2,
+				Release:         &discogs.ListingRelease{Artist: "Nirvana", Title: "Nevermind", Description: "Nirvana - Nevermind (LP, Album)"},
+				Condition:       discogs.ConditionVeryGoodPlus,
+				SleeveCondition: discogs.SleeveConditionGeneric,
+				Price:           &discogs.Price{Currency: discogs.CurrencyEUR, Value: 1250},
+			},
+			"Nirvana - Nevermind (LP, Album), VG+/Generic, €12.50 [l2]",
+		},
+		{
+			"Listing without description",
+			discogs.Listing{ID: 3, Release: &discogs.ListingRelease{Artist: "Nirvana", Title: "Nevermind"}},
+			"Nirvana - Nevermind [l3]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.args.String())
+		})
+	}
+}
+
+func TestOrder_String(t *testing.T) {
+	tests := []struct {
+		name string
+		args discogs.Order
+		want string
+	}{
+		{
+			"Order without items",
+			discogs.Order{ID: "1-1"},
+			"0 items [order 1-1]",
+		},
+		{
+			"Order with status, buyer and total",
+			discogs.Order{
+				ID:     "123-1",
+				Status: discogs.OrderStatusShipped,
+				Items:  []discogs.OrderItem{{ID: 1}, {ID: 2}},
+				Buyer:  &discogs.MarketUser{Username: "buyer"},
+				Total:  &discogs.Price{Currency: discogs.CurrencyEUR, Value: 3000},
+			},
+			"Shipped: 2 items to buyer, €30.00 [order 123-1]",
+		},
+		{
+			"Order of a single item",
+			discogs.Order{ID: "2-1", Items: []discogs.OrderItem{{ID: 1}}},
+			"1 item [order 2-1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.args.String())
+		})
+	}
+}
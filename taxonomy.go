@@ -0,0 +1,350 @@
+package discogs
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Genre represents a genre of the Discogs taxonomy. Every release and master has one or more genres.
+type Genre string
+
+// Genre constants representing all genres of the Discogs taxonomy. They are untyped so they can be used as the Genre
+// of SearchOptions, which is a string, as well as a Genre.
+const (
+	GenreBlues               = "Blues"
+	GenreBrassAndMilitary    = "Brass & Military"
+	GenreChildrens           = "Children's"
+	GenreClassical           = "Classical"
+	GenreElectronic          = "Electronic"
+	GenreFolkWorldAndCountry = "Folk, World, & Country"
+	GenreFunkSoul            = "Funk / Soul"
+	GenreHipHop              = "Hip Hop"
+	GenreJazz                = "Jazz"
+	GenreLatin               = "Latin"
+	GenreNonMusic            = "Non-Music"
+	GenrePop                 = "Pop"
+	GenreReggae              = "Reggae"
+	GenreRock                = "Rock"
+	GenreStageAndScreen      = "Stage & Screen"
+)
+
+// Style represents a style of the Discogs taxonomy, a subdivision of one or more genres.
+type Style string
+
+// Style constants representing the most common styles of the Discogs taxonomy. Discogs knows hundreds of styles and
+// keeps adding new ones, so these constants are not exhaustive. Like the Genre constants, they are untyped.
+const (
+	StyleAbstract          = "Abstract"
+	StyleAcid              = "Acid"
+	StyleAcidHouse         = "Acid House"
+	StyleAcidJazz          = "Acid Jazz"
+	StyleAfrican           = "African"
+	StyleAfrobeat          = "Afrobeat"
+	StyleAlternativeRock   = "Alternative Rock"
+	StyleAmbient           = "Ambient"
+	StyleArtRock           = "Art Rock"
+	StyleAvantGardeJazz    = "Avant-garde Jazz"
+	StyleBachata           = "Bachata"
+	StyleBallad            = "Ballad"
+	StyleBaroque           = "Baroque"
+	StyleBassMusic         = "Bass Music"
+	StyleBigBand           = "Big Band"
+	StyleBlackMetal        = "Black Metal"
+	StyleBluegrass         = "Bluegrass"
+	StyleBluesRock         = "Blues Rock"
+	StyleBoogie            = "Boogie"
+	StyleBoomBap           = "Boom Bap"
+	StyleBop               = "Bop"
+	StyleBossaNova         = "Bossa Nova"
+	StyleBreakbeat         = "Breakbeat"
+	StyleBreaks            = "Breaks"
+	StyleBrokenBeat        = "Broken Beat"
+	StyleCajun             = "Cajun"
+	StyleCeltic            = "Celtic"
+	StyleChanson           = "Chanson"
+	StyleChicagoBlues      = "Chicago Blues"
+	StyleClassicRock       = "Classic Rock"
+	StyleConscious         = "Conscious"
+	StyleContemporary      = "Contemporary"
+	StyleContemporaryJazz  = "Contemporary Jazz"
+	StyleContemporaryRAndB = "Contemporary R&B"
+	StyleCoolJazz          = "Cool Jazz"
+	StyleCountry           = "Country"
+	StyleCountryBlues      = "Country Blues"
+	StyleCountryRock       = "Country Rock"
+	StyleCumbia            = "Cumbia"
+	StyleDancehall         = "Dancehall"
+	StyleDarkAmbient       = "Dark Ambient"
+	StyleDarkwave          = "Darkwave"
+	StyleDeathMetal        = "Death Metal"
+	StyleDeepHouse         = "Deep House"
+	StyleDeltaBlues        = "Delta Blues"
+	StyleDisco             = "Disco"
+	StyleDixieland         = "Dixieland"
+	StyleDoomMetal         = "Doom Metal"
+	StyleDowntempo         = "Downtempo"
+	StyleDrumNBass         = "Drum n Bass"
+	StyleDub               = "Dub"
+	StyleDubTechno         = "Dub Techno"
+	StyleDubstep           = "Dubstep"
+	StyleEBM               = "EBM"
+	StyleElectricBlues     = "Electric Blues"
+	StyleElectro           = "Electro"
+	StyleElectroHouse      = "Electro House"
+	StyleEmo               = "Emo"
+	StyleEuroHouse         = "Euro House"
+	StyleEuroDisco         = "Euro-Disco"
+	StyleEurodance         = "Eurodance"
+	StyleEuropop           = "Europop"
+	StyleExperimental      = "Experimental"
+	StyleFado              = "Fado"
+	StyleFieldRecording    = "Field Recording"
+	StyleFlamenco          = "Flamenco"
+	StyleFolk              = "Folk"
+	StyleFolkRock          = "Folk Rock"
+	StyleFootwork          = "Footwork"
+	StyleFreeImprovisation = "Free Improvisation"
+	StyleFreeJazz          = "Free Jazz"
+	StyleFunk              = "Funk"
+	StyleFusion            = "Fusion"
+	StyleGFunk             = "G-Funk"
+	StyleGabber            = "Gabber"
+	StyleGangsta           = "Gangsta"
+	StyleGarageHouse       = "Garage House"
+	StyleGarageRock        = "Garage Rock"
+	StyleGlam              = "Glam"
+	StyleGlitch            = "Glitch"
+	StyleGoaTrance         = "Goa Trance"
+	StyleGospel            = "Gospel"
+	StyleGothRock          = "Goth Rock"
+	StyleGrime             = "Grime"
+	StyleGrindcore         = "Grindcore"
+	StyleGrunge            = "Grunge"
+	StyleHappyHardcore     = "Happy Hardcore"
+	StyleHardBop           = "Hard Bop"
+	StyleHardHouse         = "Hard House"
+	StyleHardRock          = "Hard Rock"
+	StyleHardTechno        = "Hard Techno"
+	StyleHardTrance        = "Hard Trance"
+	StyleHardcore          = "Hardcore"
+	StyleHardstyle         = "Hardstyle"
+	StyleHeavyMetal        = "Heavy Metal"
+	StyleHiNRG             = "Hi NRG"
+	StyleHighlife          = "Highlife"
+	StyleHonkyTonk         = "Honky Tonk"
+	StyleHouse             = "House"
+	StyleIDM               = "IDM"
+	StyleIndiePop          = "Indie Pop"
+	StyleIndieRock         = "Indie Rock"
+	StyleIndustrial        = "Industrial"
+	StyleInstrumental      = "Instrumental"
+	StyleItaloDisco        = "Italo-Disco"
+	StyleItalodance        = "Italodance"
+	StyleJPop              = "J-pop"
+	StyleJazzFunk          = "Jazz-Funk"
+	StyleJazzRock          = "Jazz-Rock"
+	StyleJazzyHipHop       = "Jazzy Hip-Hop"
+	StyleJuke              = "Juke"
+	StyleJungle            = "Jungle"
+	StyleKPop              = "K-pop"
+	StyleKrautrock         = "Krautrock"
+	StyleLatinJazz         = "Latin Jazz"
+	StyleLeftfield         = "Leftfield"
+	StyleLoFi              = "Lo-Fi"
+	StyleLoversRock        = "Lovers Rock"
+	StyleMarches           = "Marches"
+	StyleMathRock          = "Math Rock"
+	StyleMerengue          = "Merengue"
+	StyleMilitary          = "Military"
+	StyleMinimal           = "Minimal"
+	StyleMinimalTechno     = "Minimal Techno"
+	StyleModal             = "Modal"
+	StyleModern            = "Modern"
+	StyleMusical           = "Musical"
+	StyleNeoSoul           = "Neo Soul"
+	StyleNeoClassical      = "Neo-Classical"
+	StyleNewBeat           = "New Beat"
+	StyleNewJackSwing      = "New Jack Swing"
+	StyleNewWave           = "New Wave"
+	StyleNoise             = "Noise"
+	StyleNorthernSoul      = "Northern Soul"
+	StyleNuMetal           = "Nu Metal"
+	StyleNuDisco           = "Nu-Disco"
+	StyleNurseryRhymes     = "Nursery Rhymes"
+	StyleOpera             = "Opera"
+	StylePFunk             = "P.Funk"
+	StylePoetry            = "Poetry"
+	StylePopRap            = "Pop Rap"
+	StylePopRock           = "Pop Rock"
+	StylePostBop           = "Post Bop"
+	StylePostRock          = "Post Rock"
+	StylePostHardcore      = "Post-Hardcore"
+	StylePostPunk          = "Post-Punk"
+	StylePowerMetal        = "Power Metal"
+	StylePowerPop          = "Power Pop"
+	StyleProgRock          = "Prog Rock"
+	StyleProgressiveHouse  = "Progressive House"
+	StyleProgressiveTrance = "Progressive Trance"
+	StylePsyTrance         = "Psy-Trance"
+	StylePsychedelicRock   = "Psychedelic Rock"
+	StylePunk              = "Punk"
+	StyleRagga             = "Ragga"
+	StyleReggaeton         = "Reggaeton"
+	StyleRenaissance       = "Renaissance"
+	StyleRhythmAndBlues    = "Rhythm & Blues"
+	StyleRockAndRoll       = "Rock & Roll"
+	StyleRockabilly        = "Rockabilly"
+	StyleRocksteady        = "Rocksteady"
+	StyleRomantic          = "Romantic"
+	StyleRootsReggae       = "Roots Reggae"
+	StyleSalsa             = "Salsa"
+	StyleSamba             = "Samba"
+	StyleSchlager          = "Schlager"
+	StyleScore             = "Score"
+	StyleShoegaze          = "Shoegaze"
+	StyleSka               = "Ska"
+	StyleSmoothJazz        = "Smooth Jazz"
+	StyleSoftRock          = "Soft Rock"
+	StyleSoul              = "Soul"
+	StyleSoulJazz          = "Soul-Jazz"
+	StyleSoundtrack        = "Soundtrack"
+	StyleSpaceRock         = "Space Rock"
+	StyleSpeedGarage       = "Speed Garage"
+	StyleSpeedMetal        = "Speed Metal"
+	StyleSpokenWord        = "Spoken Word"
+	StyleStonerRock        = "Stoner Rock"
+	StyleSurf              = "Surf"
+	StyleSwing             = "Swing"
+	StyleSynthPop          = "Synth-pop"
+	StyleSynthwave         = "Synthwave"
+	StyleTango             = "Tango"
+	StyleTechHouse         = "Tech House"
+	StyleTechno            = "Techno"
+	StyleTheme             = "Theme"
+	StyleThrash            = "Thrash"
+	StyleTrance            = "Trance"
+	StyleTrap              = "Trap"
+	StyleTripHop           = "Trip Hop"
+	StyleTurntablism       = "Turntablism"
+	StyleUKGarage          = "UK Garage"
+	StyleVaporwave         = "Vaporwave"
+	StyleVocal             = "Vocal"
+)
+
+// Genres returns all genres of the Discogs taxonomy.
+func Genres() []Genre {
+	return []Genre{
+		GenreBlues, GenreBrassAndMilitary, GenreChildrens, GenreClassical, GenreElectronic,
+		GenreFolkWorldAndCountry, GenreFunkSoul, GenreHipHop, GenreJazz, GenreLatin, GenreNonMusic, GenrePop,
+		GenreReggae, GenreRock, GenreStageAndScreen,
+	}
+}
+
+// Styles returns the styles of the Discogs taxonomy that have a constant.
+func Styles() []Style {
+	return []Style{
+		StyleAbstract, StyleAcid, StyleAcidHouse, StyleAcidJazz, StyleAfrican, StyleAfrobeat, StyleAlternativeRock,
+		StyleAmbient, StyleArtRock, StyleAvantGardeJazz, StyleBachata, StyleBallad, StyleBaroque, StyleBassMusic,
+		StyleBigBand, StyleBlackMetal, StyleBluegrass, StyleBluesRock, StyleBoogie, StyleBoomBap, StyleBop,
+		StyleBossaNova, StyleBreakbeat, StyleBreaks, StyleBrokenBeat, StyleCajun, StyleCeltic, StyleChanson,
+		StyleChicagoBlues, StyleClassicRock, StyleConscious, StyleContemporary, StyleContemporaryJazz,
+		StyleContemporaryRAndB, StyleCoolJazz, StyleCountry, StyleCountryBlues, StyleCountryRock, StyleCumbia,
+		StyleDancehall, StyleDarkAmbient, StyleDarkwave, StyleDeathMetal, StyleDeepHouse, StyleDeltaBlues,
+		StyleDisco, StyleDixieland, StyleDoomMetal, StyleDowntempo, StyleDrumNBass, StyleDub, StyleDubTechno,
+		StyleDubstep, StyleEBM, StyleElectricBlues, StyleElectro, StyleElectroHouse, StyleEmo, StyleEuroHouse,
+		StyleEuroDisco, StyleEurodance, StyleEuropop, StyleExperimental, StyleFado, StyleFieldRecording,
+		StyleFlamenco, StyleFolk, StyleFolkRock, StyleFootwork, StyleFreeImprovisation, StyleFreeJazz, StyleFunk,
+		StyleFusion, StyleGFunk, StyleGabber, StyleGangsta, StyleGarageHouse, StyleGarageRock, StyleGlam,
+		StyleGlitch, StyleGoaTrance, StyleGospel, StyleGothRock, StyleGrime, StyleGrindcore, StyleGrunge,
+		StyleHappyHardcore, StyleHardBop, StyleHardHouse, StyleHardRock, StyleHardTechno, StyleHardTrance,
+		StyleHardcore, StyleHardstyle, StyleHeavyMetal, StyleHiNRG, StyleHighlife, StyleHonkyTonk, StyleHouse,
+		StyleIDM, StyleIndiePop, StyleIndieRock, StyleIndustrial, StyleInstrumental, StyleItaloDisco,
+		StyleItalodance, StyleJPop, StyleJazzFunk, StyleJazzRock, StyleJazzyHipHop, StyleJuke, StyleJungle,
+		StyleKPop, StyleKrautrock, StyleLatinJazz, StyleLeftfield, StyleLoFi, StyleLoversRock, StyleMarches,
+		StyleMathRock, StyleMerengue, StyleMilitary, StyleMinimal, StyleMinimalTechno, StyleModal, StyleModern,
+		StyleMusical, StyleNeoSoul, StyleNeoClassical, StyleNewBeat, StyleNewJackSwing, StyleNewWave, StyleNoise,
+		StyleNorthernSoul, StyleNuMetal, StyleNuDisco, StyleNurseryRhymes, StyleOpera, StylePFunk, StylePoetry,
+		StylePopRap, StylePopRock, StylePostBop, StylePostRock, StylePostHardcore, StylePostPunk, StylePowerMetal,
+		StylePowerPop, StyleProgRock, StyleProgressiveHouse, StyleProgressiveTrance, StylePsyTrance,
+		StylePsychedelicRock, StylePunk, StyleRagga, StyleReggaeton, StyleRenaissance, StyleRhythmAndBlues,
+		StyleRockAndRoll, StyleRockabilly, StyleRocksteady, StyleRomantic, StyleRootsReggae, StyleSalsa, StyleSamba,
+		StyleSchlager, StyleScore, StyleShoegaze, StyleSka, StyleSmoothJazz, StyleSoftRock, StyleSoul,
+		StyleSoulJazz, StyleSoundtrack, StyleSpaceRock, StyleSpeedGarage, StyleSpeedMetal, StyleSpokenWord,
+		StyleStonerRock, StyleSurf, StyleSwing, StyleSynthPop, StyleSynthwave, StyleTango, StyleTechHouse,
+		StyleTechno, StyleTheme, StyleThrash, StyleTrance, StyleTrap, StyleTripHop, StyleTurntablism, StyleUKGarage,
+		StyleVaporwave, StyleVocal,
+	}
+}
+
+// genresByKey and stylesByKey map the normalized spelling of the known genres and styles to their exact spelling.
+var (
+	genresByKey = make(map[string]Genre)
+	stylesByKey = make(map[string]Style)
+)
+
+func init() {
+	for _, genre := range Genres() {
+		genresByKey[taxonomyKey(string(genre))] = genre
+	}
+	for _, style := range Styles() {
+		stylesByKey[taxonomyKey(string(style))] = style
+	}
+}
+
+// taxonomyKey normalizes the spelling of a genre or style, ignoring case, spaces and punctuation, so that "Hip-Hop",
+// "hip hop" and "Hip Hop" share a key.
+func taxonomyKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, strings.ReplaceAll(s, "&", "and"))
+}
+
+// IsValid reports whether the genre is spelled exactly as in the Discogs taxonomy.
+func (g Genre) IsValid() bool {
+	return genresByKey[taxonomyKey(string(g))] == g
+}
+
+// ParseGenre returns the genre matching s, ignoring case, spaces and punctuation, so "hip-hop" returns GenreHipHop.
+// It returns an ErrInvalidOption if s matches no genre.
+func ParseGenre(s string) (Genre, error) {
+	if genre, ok := genresByKey[taxonomyKey(s)]; ok {
+		return genre, nil
+	}
+	return "", &ErrInvalidOption{Option: "genre", Value: s}
+}
+
+// IsKnown reports whether the style is spelled exactly as one of the Style constants.
+func (s Style) IsKnown() bool {
+	return stylesByKey[taxonomyKey(string(s))] == s
+}
+
+// ParseStyle returns the known style matching s, ignoring case, spaces and punctuation, so "synth pop" returns
+// StyleSynthPop. Since the Style constants are not exhaustive, a style matching none
+// of them is returned as is.
+func ParseStyle(s string) Style {
+	if style, ok := stylesByKey[taxonomyKey(s)]; ok {
+		return style
+	}
+	return Style(strings.TrimSpace(s))
+}
+
+// validateGenre returns an ErrInvalidOption if the genre is set and not spelled exactly as in the Discogs taxonomy.
+func validateGenre(genre Genre) error {
+	if genre == "" || genre.IsValid() {
+		return nil
+	}
+	return &ErrInvalidOption{Option: "genre", Value: string(genre)}
+}
+
+// validateStyle returns an ErrInvalidOption if the style is a misspelling of a known style, such as "trip-hop" for
+// "Trip Hop". Styles that match no known style are assumed to be valid, since the Style constants are not exhaustive.
+func validateStyle(style Style) error {
+	known, ok := stylesByKey[taxonomyKey(string(style))]
+	if style == "" || !ok || known == style {
+		return nil
+	}
+	return &ErrInvalidOption{Option: "style", Value: string(style)}
+}
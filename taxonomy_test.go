@@ -0,0 +1,87 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGenre(t *testing.T) {
+	type want struct {
+		genre discogs.Genre
+		err   error
+	}
+	tests := []struct {
+		name string
+		args string
+		want want
+	}{
+		{
+			"ParseGenre exact",
+			"Stage & Screen",
+			want{discogs.GenreStageAndScreen, nil},
+		},
+		{
+			"ParseGenre hyphenated",
+			"Hip-Hop",
+			want{discogs.GenreHipHop, nil},
+		},
+		{
+			"ParseGenre punctuation",
+			"funk/soul",
+			want{discogs.GenreFunkSoul, nil},
+		},
+		{
+			"ParseGenre unknown",
+			"Polka",
+			want{"", &discogs.ErrInvalidOption{Option: "genre", Value: "Polka"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genre, err := discogs.ParseGenre(tt.args)
+			assert.Equal(t, tt.want.err, err)
+			assert.Equal(t, tt.want.genre, genre)
+		})
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	assert.Equal(t, discogs.Style(discogs.StyleSynthPop), discogs.ParseStyle("synth pop"))
+	assert.Equal(t, discogs.Style(discogs.StyleDrumNBass), discogs.ParseStyle("Drum N Bass"))
+	assert.Equal(t, discogs.Style("Polka"), discogs.ParseStyle(" Polka "))
+	assert.True(t, discogs.Style(discogs.StyleTripHop).IsKnown())
+	assert.False(t, discogs.Style("trip-hop").IsKnown())
+}
+
+func TestDatabase_SearchTaxonomy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"results":[]}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{ConsumerKey: &key, ConsumerSecret: &secret})
+	client.Host = server.URL
+
+	tests := []struct {
+		name    string
+		options discogs.SearchOptions
+		want    error
+	}{
+		{"known genre and style", discogs.SearchOptions{Genre: discogs.GenreHipHop, Style: discogs.StyleBoomBap}, nil},
+		{"unknown style", discogs.SearchOptions{Style: "Polka"}, nil},
+		{"misspelled genre", discogs.SearchOptions{Genre: "Hip-Hop"}, &discogs.ErrInvalidOption{Option: "genre", Value: "Hip-Hop"}},
+		{"misspelled style", discogs.SearchOptions{Style: "trip-hop"}, &discogs.ErrInvalidOption{Option: "style", Value: "trip-hop"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Search(ctx, &tt.options)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package discogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// timestampLayouts lists the date/time layouts the Discogs API has been observed to return, in the order they are
+// attempted when parsing a Timestamp.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05-07",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05-07:00",
+	"2006-01-02 15:04:05-0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Timestamp represents a time returned by the Discogs API. Unlike time.Time, it tolerates the different formats used
+// across Discogs responses, such as UTC offsets without a colon (e.g. "2013-01-03T20:37:51-0800"), timestamps without
+// an offset, and plain dates.
+type Timestamp struct {
+	time.Time
+}
+
+// ErrInvalidTimestamp indicates that a value could not be parsed as a Timestamp.
+type ErrInvalidTimestamp struct {
+	Value string
+}
+
+func (e *ErrInvalidTimestamp) Error() string {
+	return fmt.Sprintf("invalid timestamp: %s", e.Value)
+}
+
+// ParseTimestamp parses a date/time string in any of the formats returned by the Discogs API.
+func ParseTimestamp(value string) (Timestamp, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Timestamp{t}, nil
+		}
+	}
+	return Timestamp{}, &ErrInvalidTimestamp{Value: value}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Both string timestamps and Unix timestamps (as JSON
+// numbers) are accepted. A null or empty value results in the zero Timestamp.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		seconds, err := strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return &ErrInvalidTimestamp{Value: string(data)}
+		}
+		*t = Timestamp{time.Unix(seconds, 0).UTC()}
+		return nil
+	}
+
+	if value == "" {
+		*t = Timestamp{}
+		return nil
+	}
+
+	parsed, err := ParseTimestamp(value)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. The Timestamp is encoded in RFC 3339 format, with fractional
+// seconds if any so it round-trips, which is accepted when unmarshaling, and the zero Timestamp is encoded as null.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(time.RFC3339Nano))
+}
+
+// Equal reports whether t and u represent the same time instant.
+func (t Timestamp) Equal(u Timestamp) bool {
+	return t.Time.Equal(u.Time)
+}
@@ -0,0 +1,110 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	pst := time.FixedZone("", -8*60*60)
+
+	type want struct {
+		time time.Time
+		err  error
+	}
+	tests := []struct {
+		name string
+		data string
+		want want
+	}{
+		{
+			"Timestamp RFC3339",
+			`"2013-01-03T20:37:51-08:00"`,
+			want{time.Date(2013, 1, 3, 20, 37, 51, 0, pst), nil},
+		},
+		{
+			"Timestamp offset without colon",
+			`"2013-01-03T20:37:51-0800"`,
+			want{time.Date(2013, 1, 3, 20, 37, 51, 0, pst), nil},
+		},
+		{
+			"Timestamp without offset",
+			`"2013-01-03T20:37:51"`,
+			want{time.Date(2013, 1, 3, 20, 37, 51, 0, time.UTC), nil},
+		},
+		{
+			"Timestamp date only",
+			`"2013-01-03"`,
+			want{time.Date(2013, 1, 3, 0, 0, 0, 0, time.UTC), nil},
+		},
+		{
+			"Timestamp unix seconds",
+			`1357274271`,
+			want{time.Date(2013, 1, 4, 4, 37, 51, 0, time.UTC), nil},
+		},
+		{
+			"Timestamp null",
+			`null`,
+			want{time.Time{}, nil},
+		},
+		{
+			"Timestamp empty string",
+			`""`,
+			want{time.Time{}, nil},
+		},
+		{
+			"Timestamp invalid",
+			`"yesterday"`,
+			want{time.Time{}, &discogs.ErrInvalidTimestamp{"yesterday"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts discogs.Timestamp
+			err := json.Unmarshal([]byte(tt.data), &ts)
+
+			if tt.want.err != nil {
+				assert.EqualError(t, err, tt.want.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.True(t, tt.want.time.Equal(ts.Time), "expected %v, got %v", tt.want.time, ts.Time)
+		})
+	}
+}
+
+func TestTimestamp_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		args discogs.Timestamp
+		want string
+	}{
+		{
+			"Timestamp zero value",
+			discogs.Timestamp{},
+			`null`,
+		},
+		{
+			"Timestamp with offset",
+			discogs.Timestamp{Time: time.Date(2013, 1, 3, 20, 37, 51, 0, time.FixedZone("", -8*60*60))},
+			`"2013-01-03T20:37:51-08:00"`,
+		},
+		{
+			"Timestamp with fractional seconds",
+			discogs.Timestamp{Time: time.Date(2013, 1, 3, 20, 37, 51, 250000000, time.UTC)},
+			`"2013-01-03T20:37:51.25Z"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.args)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
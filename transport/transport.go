@@ -0,0 +1,84 @@
+// Package transport exposes the HTTP mechanics of the discogs package: authentication, rate limiting, retries and
+// caching. It lets advanced users call Discogs endpoints that have no typed wrapper yet, such as new or undocumented
+// endpoints, while benefiting from the same mechanics as the typed methods of discogs.DiscogsClient.
+//
+// The API of this package is kept stable: new options are added as fields of Request.
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/couwuch/discogs"
+)
+
+// Request represents a request to a Discogs endpoint.
+type Request struct {
+	// Method is the HTTP method of the request. GET is used if unset.
+	Method string
+	// Path is the path of the endpoint, such as "/releases/1".
+	Path string
+	// Auth is the authentication the endpoint requires. If unset, it is determined by discogs.EndpointAuthMap, and
+	// endpoints missing from it fail with a discogs.ErrMatchNotFound.
+	Auth discogs.AuthType
+	// Params are the query parameters of the request.
+	Params url.Values
+	// Headers are added to the headers of the request.
+	Headers map[string]string
+	// Body is encoded as the JSON body of the request, if not nil.
+	Body any
+}
+
+// Client sends requests to arbitrary Discogs endpoints through a discogs.DiscogsClient, sharing its configuration,
+// credentials and rate limiters.
+type Client struct {
+	dc *discogs.DiscogsClient
+}
+
+// New creates a new Client sending requests through dc.
+func New(dc *discogs.DiscogsClient) *Client {
+	return &Client{dc: dc}
+}
+
+// Call sends a request and unmarshals the JSON response into res, which may be nil. It returns a discogs.HTTPError if
+// the response status code is not 2xx.
+func (c *Client) Call(ctx context.Context, req *Request, res any) error {
+	return c.dc.SendRequest(ctx, &discogs.EndpointRequest{
+		Method:   req.Method,
+		Endpoint: req.Path,
+		AuthType: req.Auth,
+		Params:   req.Params,
+		Headers:  req.Headers,
+		Body:     req.Body,
+	}, res)
+}
+
+// Get sends a GET request to the endpoint at path, authenticated with auth, and unmarshals the response into res.
+func (c *Client) Get(ctx context.Context, path string, auth discogs.AuthType, params url.Values, res any) error {
+	return c.Call(ctx, &Request{Method: http.MethodGet, Path: path, Auth: auth, Params: params}, res)
+}
+
+// Post sends a POST request with a JSON body to the endpoint at path, authenticated with auth, and unmarshals the
+// response into res.
+func (c *Client) Post(ctx context.Context, path string, auth discogs.AuthType, body, res any) error {
+	return c.Call(ctx, &Request{Method: http.MethodPost, Path: path, Auth: auth, Body: body}, res)
+}
+
+// Put sends a PUT request with a JSON body to the endpoint at path, authenticated with auth, and unmarshals the
+// response into res.
+func (c *Client) Put(ctx context.Context, path string, auth discogs.AuthType, body, res any) error {
+	return c.Call(ctx, &Request{Method: http.MethodPut, Path: path, Auth: auth, Body: body}, res)
+}
+
+// Delete sends a DELETE request to the endpoint at path, authenticated with auth, and unmarshals the response into
+// res.
+func (c *Client) Delete(ctx context.Context, path string, auth discogs.AuthType, res any) error {
+	return c.Call(ctx, &Request{Method: http.MethodDelete, Path: path, Auth: auth}, res)
+}
+
+// Do sends a prepared HTTP request, respecting the rate limits, and unmarshals the response into res. The request is
+// sent as is, so it must carry its own authentication headers.
+func (c *Client) Do(ctx context.Context, req *http.Request, res any) error {
+	return c.dc.Do(ctx, req, res)
+}
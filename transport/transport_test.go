@@ -0,0 +1,42 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/couwuch/discogs/transport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Call(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/brand/new/endpoint", req.URL.Path)
+		assert.Equal(t, "Bearer token", req.Header.Get(discogs.AuthHeader))
+		assert.Equal(t, "1", req.URL.Query().Get("page"))
+		_, _ = rw.Write([]byte(`{"value":42}`))
+	}))
+	defer server.Close()
+
+	token := "token"
+	dc := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	dc.Host = server.URL
+	client := transport.New(dc)
+
+	var res struct {
+		Value int `json:"value"`
+	}
+	err := client.Call(context.Background(), &transport.Request{
+		Path:   "/brand/new/endpoint",
+		Auth:   discogs.AuthTypeOAuth,
+		Params: map[string][]string{"page": {"1"}},
+	}, &res)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, res.Value)
+
+	// Without an explicit authentication, the endpoint must be known
+	err = client.Get(context.Background(), "/brand/new/endpoint", "", nil, nil)
+	assert.Equal(t, &discogs.ErrMatchNotFound{Endpoint: "/brand/new/endpoint"}, err)
+}
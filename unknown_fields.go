@@ -0,0 +1,84 @@
+package discogs
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// ExtraFields is embedded in response types to hold JSON fields that are not mapped to a struct field. Extra is only
+// populated when CaptureUnknownFields is enabled in the DiscogsConfig and the response contains unmapped fields.
+type ExtraFields struct {
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// setExtra sets the unmapped JSON fields of the response.
+func (e *ExtraFields) setExtra(extra map[string]json.RawMessage) {
+	e.Extra = extra
+}
+
+// extraCapturer is implemented by response types that can capture unmapped JSON fields.
+type extraCapturer interface {
+	setExtra(extra map[string]json.RawMessage)
+}
+
+// unknownFields returns the top-level fields of the JSON object in data that do not map to a field of v. It returns
+// nil if data is not a JSON object or all of its fields are mapped.
+func unknownFields(data []byte, v interface{}) map[string]json.RawMessage {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	known := knownFields(reflect.TypeOf(v))
+
+	var extra map[string]json.RawMessage
+	for name, value := range fields {
+		// encoding/json matches field names case-insensitively, so do the same here
+		if known[strings.ToLower(name)] {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[name] = value
+	}
+	return extra
+}
+
+// knownFields returns the lowercased JSON names of the fields of t, including fields promoted from embedded structs.
+func knownFields(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	known := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return known
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			for embedded := range knownFields(field.Type) {
+				known[embedded] = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+	return known
+}
@@ -0,0 +1,70 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_CaptureUnknownFields(t *testing.T) {
+	type ExtraTestClientResponse struct {
+		discogs.ExtraFields
+		TestClientResponse
+		Name   string `json:"name,omitempty"`
+		Hidden string `json:"-"`
+		Title  string
+	}
+
+	tests := []struct {
+		name    string
+		capture bool
+		body    string
+		want    map[string]json.RawMessage
+	}{
+		{
+			"CaptureUnknownFields disabled",
+			false,
+			`{"success":true,"unmodeled":"value"}`,
+			nil,
+		},
+		{
+			"CaptureUnknownFields no unknown fields",
+			true,
+			`{"success":true,"name":"test","TITLE":"test"}`,
+			nil,
+		},
+		{
+			"CaptureUnknownFields with unknown fields",
+			true,
+			`{"success":true,"Hidden":"value","unmodeled":{"nested":1}}`,
+			map[string]json.RawMessage{
+				"Hidden":    json.RawMessage(`"value"`),
+				"unmodeled": json.RawMessage(`{"nested":1}`),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if _, err := rw.Write([]byte(tt.body)); err != nil {
+					assert.FailNow(t, "failed to write the response body: %w", err)
+				}
+			}))
+			defer server.Close()
+
+			testClient := discogs.NewDiscogsClient(&discogs.DiscogsConfig{CaptureUnknownFields: tt.capture})
+			testClient.Host = server.URL
+			var res ExtraTestClientResponse
+
+			err := testClient.Get(ctx, "/test", nil, nil, &res)
+
+			assert.NoError(t, err)
+			assert.True(t, res.Success)
+			assert.Equal(t, tt.want, res.Extra)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package discogs
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-querystring/query"
+)
+
+// Identity fetches the identity of the user authenticated by the access token of the client by sending a GET request
+// to the /oauth/identity endpoint. The context.Context provides control over the request's lifecycle. It returns a
+// pointer to an IdentityResponse struct containing the identity, or an error if the request fails.
+//
+// Documentation: https://www.discogs.com/developers#page:user-identity,header:user-identity-identity
+func (dc *DiscogsClient) Identity(ctx context.Context, opts ...RequestOption) (*IdentityResponse, error) {
+	var res IdentityResponse
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), "/oauth/identity", nil, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UserContributions fetches a page of the releases a user has contributed to by sending a GET request to the
+// /users/{username}/contributions endpoint. The options parameter allows for sorting and pagination. The
+// context.Context provides control over the request's lifecycle. It returns a pointer to a ContributionsResponse
+// struct containing the contributions, or an error if the request fails or the user is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:user-identity,header:user-identity-user-contributions
+func (dc *DiscogsClient) UserContributions(ctx context.Context, username string, options *ContributionsOptions, opts ...RequestOption) (*ContributionsResponse, error) {
+	endpoint := "/users/" + url.PathEscape(username) + "/contributions"
+	var res ContributionsResponse
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceUser, username)
+	}
+
+	return &res, nil
+}
+
+// UserContributionsPages returns a PageFetcher that fetches pages of the contributions of a user, for use with
+// NewIterator or ForEachPage. The sorting of options is kept for every page, while its pagination parameters are
+// overridden by the page being fetched.
+func (dc *DiscogsClient) UserContributionsPages(username string, options *ContributionsOptions) PageFetcher[ReleaseResponse] {
+	return func(ctx context.Context, page PaginationParams) ([]ReleaseResponse, *Pagination, error) {
+		var pageOptions ContributionsOptions
+		if options != nil {
+			pageOptions = *options
+		}
+		pageOptions.Page = page.Page
+		if page.PerPage != nil {
+			pageOptions.PerPage = page.PerPage
+		}
+
+		res, err := dc.UserContributions(ctx, username, &pageOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Contributions, res.Pagination, nil
+	}
+}
+
+// UserContributionsIter returns an Iterator over all releases a user has contributed to, fetching pages as needed.
+// The options parameter specifies the sorting, which is kept across pages; its pagination parameters are managed by
+// the iterator. The iterOptions parameter configures the iteration, such as the page size and whether to prefetch
+// pages.
+func (dc *DiscogsClient) UserContributionsIter(username string, options *ContributionsOptions, iterOptions *IteratorOptions) *Iterator[ReleaseResponse] {
+	return NewIterator(dc.UserContributionsPages(username, options), iterOptions)
+}
+
+// UserSubmissions fetches a page of the submissions of a user by sending a GET request to the
+// /users/{username}/submissions endpoint. Every page holds artists, labels and releases, grouped by kind. The
+// context.Context provides control over the request's lifecycle. It returns a pointer to a SubmissionsResponse struct
+// containing the submissions, or an error if the request fails or the user is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:user-identity,header:user-identity-user-submissions
+func (dc *DiscogsClient) UserSubmissions(ctx context.Context, username string, options *PaginationParams, opts ...RequestOption) (*SubmissionsResponse, error) {
+	endpoint := "/users/" + url.PathEscape(username) + "/submissions"
+	var res SubmissionsResponse
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceUser, username)
+	}
+
+	return &res, nil
+}
+
+// Items returns the submissions of the page as a single slice: artists first, then labels, then releases.
+func (s *Submissions) Items() []Submission {
+	if s == nil {
+		return nil
+	}
+
+	items := make([]Submission, 0, len(s.Artists)+len(s.Labels)+len(s.Releases))
+	for i := range s.Artists {
+		items = append(items, Submission{Kind: SubmissionKindArtist, Artist: &s.Artists[i]})
+	}
+	for i := range s.Labels {
+		items = append(items, Submission{Kind: SubmissionKindLabel, Label: &s.Labels[i]})
+	}
+	for i := range s.Releases {
+		items = append(items, Submission{Kind: SubmissionKindRelease, Release: &s.Releases[i]})
+	}
+	return items
+}
+
+// UserSubmissionsPages returns a PageFetcher that fetches pages of the submissions of a user, for use with NewIterator
+// or ForEachPage. The submissions of every page are flattened into a single slice, see Submissions.Items.
+func (dc *DiscogsClient) UserSubmissionsPages(username string) PageFetcher[Submission] {
+	return func(ctx context.Context, page PaginationParams) ([]Submission, *Pagination, error) {
+		res, err := dc.UserSubmissions(ctx, username, &page)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Submissions.Items(), res.Pagination, nil
+	}
+}
+
+// UserSubmissionsIter returns an Iterator over all submissions of a user, fetching pages as needed. Every Submission
+// tells the kind of entity it concerns. The iterOptions parameter configures the iteration, such as the page size and
+// whether to prefetch pages.
+func (dc *DiscogsClient) UserSubmissionsIter(username string, iterOptions *IteratorOptions) *Iterator[Submission] {
+	return NewIterator(dc.UserSubmissionsPages(username), iterOptions)
+}
+
+// User fetches the profile of a user by sending a GET request to the /users/{username} endpoint. The
+// context.Context provides control over the request's lifecycle. It returns a pointer to a UserResponse struct
+// containing the profile, or an error if the request fails or the user is not found.
+//
+// Profiles are public, so the request is only authenticated if the client has an access token. The Email field is only
+// set for the profile of the authenticated user.
+//
+// Documentation: https://www.discogs.com/developers#page:user-identity,header:user-identity-profile-get
+func (dc *DiscogsClient) User(ctx context.Context, username string, opts ...RequestOption) (*UserResponse, error) {
+	endpoint := "/users/" + url.PathEscape(username)
+	var res UserResponse
+
+	if err := dc.requestWithAuth(WithRequestOptions(ctx, opts...), http.MethodGet, endpoint, dc.optionalAuth(ctx), nil, nil, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceUser, username)
+	}
+
+	return &res, nil
+}
+
+// UpdateUser edits the profile of a user by sending a POST request to the /users/{username} endpoint. Only the fields
+// set in the update are sent, leaving the other fields of the profile unchanged. The context.Context provides control
+// over the request's lifecycle. It returns a pointer to a UserResponse struct containing the updated profile, or an
+// error if the request fails or the user is not found.
+//
+// Editing a profile requires OAuth or a personal access token of the user.
+//
+// Documentation: https://www.discogs.com/developers#page:user-identity,header:user-identity-profile-post
+func (dc *DiscogsClient) UpdateUser(ctx context.Context, username string, update *UserUpdate, opts ...RequestOption) (*UserResponse, error) {
+	endpoint := "/users/" + url.PathEscape(username)
+	var res UserResponse
+
+	if update == nil {
+		update = &UserUpdate{}
+	}
+
+	if err := dc.requestWithAuth(WithRequestOptions(ctx, opts...), http.MethodPost, endpoint, AuthTypeOAuth, nil, nil, update, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceUser, username)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,175 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_UserContributionsIter(t *testing.T) {
+	titles := []string{"First", "Second", "Third"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/users/some%20user/contributions", req.URL.EscapedPath())
+		assert.Equal(t, "year", req.URL.Query().Get("sort"))
+		assert.Equal(t, "desc", req.URL.Query().Get("sort_order"))
+		assert.Equal(t, "1", req.URL.Query().Get("per_page"))
+
+		page, err := strconv.Atoi(req.URL.Query().Get("page"))
+		if err != nil {
+			assert.FailNow(t, "invalid page parameter: %v", err)
+		}
+
+		_, _ = fmt.Fprintf(rw, `{"pagination":{"page":%d,"pages":%d,"per_page":1},"contributions":[{"id":%d,"title":%q}]}`,
+			page, len(titles), page, titles[page-1])
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	options := &discogs.ContributionsOptions{Sort: discogs.ContributionSortYear, SortOrder: discogs.SortOrderDesc}
+	it := client.UserContributionsIter("some user", options, &discogs.IteratorOptions{PerPage: 1})
+	defer it.Close()
+
+	var got []string
+	for it.Next(ctx) {
+		release := it.Value()
+		got = append(got, release.Title)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, titles, got)
+	assert.Nil(t, options.Page, "the options of the caller must not be modified")
+}
+
+func TestDiscogsClient_UserSubmissionsIter(t *testing.T) {
+	pages := []string{
+		`{"artists":[{"id":1,"name":"Artist"}],"labels":[{"id":2,"name":"Label"}],"releases":[]}`,
+		`{"artists":[],"labels":[],"releases":[{"id":3,"title":"Release"},{"id":4,"title":"Other Release"}]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/users/user/submissions", req.URL.Path)
+
+		page, err := strconv.Atoi(req.URL.Query().Get("page"))
+		if err != nil {
+			assert.FailNow(t, "invalid page parameter: %v", err)
+		}
+
+		_, _ = fmt.Fprintf(rw, `{"pagination":{"page":%d,"pages":%d,"per_page":2},"submissions":%s}`,
+			page, len(pages), pages[page-1])
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	it := client.UserSubmissionsIter("user", &discogs.IteratorOptions{PerPage: 2})
+	defer it.Close()
+
+	var kinds []discogs.SubmissionKind
+	var ids []int64
+	for it.Next(ctx) {
+		submission := it.Value()
+		kinds = append(kinds, submission.Kind)
+		switch submission.Kind {
+		case discogs.SubmissionKindArtist:
+			ids = append(ids, submission.Artist.ID)
+		case discogs.SubmissionKindLabel:
+			ids = append(ids, submission.Label.ID)
+		case discogs.SubmissionKindRelease:
+			ids = append(ids, submission.Release.ID)
+		}
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []discogs.SubmissionKind{
+		discogs.SubmissionKindArtist, discogs.SubmissionKindLabel, discogs.SubmissionKindRelease, discogs.SubmissionKindRelease,
+	}, kinds)
+	assert.Equal(t, []int64{1, 2, 3, 4}, ids)
+}
+
+func TestUserUpdate_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		update *discogs.UserUpdate
+		want   string
+	}{
+		{
+			name:   "empty",
+			update: &discogs.UserUpdate{},
+			want:   `{}`,
+		},
+		{
+			name:   "set fields only",
+			update: new(discogs.UserUpdate).SetLocation("Lyon").SetCurrAbr(discogs.CurrencyEUR),
+			want:   `{"curr_abbr":"EUR","location":"Lyon"}`,
+		},
+		{
+			name:   "cleared field",
+			update: new(discogs.UserUpdate).SetName("Name").SetHomePage(""),
+			want:   `{"home_page":"","name":"Name"}`,
+		},
+		{
+			name:   "last value wins",
+			update: new(discogs.UserUpdate).SetProfile("first").SetProfile("second"),
+			want:   `{"profile":"second"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.update)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestDiscogsClient_UpdateUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/users/user", req.URL.Path)
+		assert.Equal(t, "Bearer token", req.Header.Get(discogs.AuthHeader))
+
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"location":""}`, string(body))
+
+		_, _ = rw.Write([]byte(`{"id":1,"username":"user","name":"Name","location":""}`))
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	got, err := client.UpdateUser(ctx, "user", new(discogs.UserUpdate).SetLocation(""))
+	assert.NoError(t, err)
+	assert.Equal(t, "Name", got.Name)
+	assert.Equal(t, []string{"location"}, new(discogs.UserUpdate).SetLocation("").Fields())
+}
+
+func TestDiscogsClient_UserWithoutToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Empty(t, req.Header.Get(discogs.AuthHeader))
+		_, _ = rw.Write([]byte(`{"id":1,"username":"user","curr_abbr":"EUR"}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	got, err := client.User(ctx, "user")
+	assert.NoError(t, err)
+	assert.Equal(t, "user", got.Username)
+	assert.Equal(t, discogs.CurrencyEUR, got.CurrAbr)
+}
@@ -0,0 +1,184 @@
+package discogs
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// ContributionSort represents a field the contributions of a user can be sorted by.
+type ContributionSort string
+
+// ContributionSort constants representing the fields the contributions of a user can be sorted by.
+const (
+	ContributionSortLabel  ContributionSort = "label"
+	ContributionSortArtist ContributionSort = "artist"
+	ContributionSortTitle  ContributionSort = "title"
+	ContributionSortCatNo  ContributionSort = "catno"
+	ContributionSortFormat ContributionSort = "format"
+	ContributionSortRating ContributionSort = "rating"
+	ContributionSortYear   ContributionSort = "year"
+	ContributionSortAdded  ContributionSort = "added"
+)
+
+// ContributionsOptions represents the options for retrieving the contributions of a user.
+type ContributionsOptions struct {
+	PaginationParams
+	Sort      ContributionSort `url:"sort,omitempty"`
+	SortOrder SortOrder        `url:"sort_order,omitempty"`
+}
+
+// ContributionsResponse represents the response from the Discogs API for the contributions of a user.
+type ContributionsResponse struct {
+	RawResponse
+	ExtraFields
+	Pagination    *Pagination       `json:"pagination,omitempty"`
+	Contributions []ReleaseResponse `json:"contributions"`
+}
+
+// SubmissionKind represents the kind of entity a submission of a user concerns.
+type SubmissionKind string
+
+// SubmissionKind constants representing the kinds of entities a user can submit.
+const (
+	SubmissionKindArtist  SubmissionKind = "artist"
+	SubmissionKindLabel   SubmissionKind = "label"
+	SubmissionKindRelease SubmissionKind = "release"
+)
+
+// SubmissionsResponse represents the response from the Discogs API for the submissions of a user.
+type SubmissionsResponse struct {
+	RawResponse
+	ExtraFields
+	Pagination  *Pagination  `json:"pagination,omitempty"`
+	Submissions *Submissions `json:"submissions,omitempty"`
+}
+
+// Submissions represents a page of the submissions of a user, grouped by kind of entity.
+type Submissions struct {
+	Artists  []SubmittedArtist `json:"artists"`
+	Labels   []LabelResponse   `json:"labels"`
+	Releases []ReleaseResponse `json:"releases"`
+}
+
+// SubmittedArtist represents an artist submitted by a user.
+type SubmittedArtist struct {
+	ID             int64    `json:"id"`
+	Name           string   `json:"name"`
+	RealName       string   `json:"realname"`
+	Profile        string   `json:"profile"`
+	DataQuality    string   `json:"data_quality"`
+	NameVariations []string `json:"namevariations"`
+	URLs           []string `json:"urls"`
+	ResourceURL    string   `json:"resource_url"`
+	URI            string   `json:"uri"`
+}
+
+// Submission represents a single submission of a user. Kind tells which of Artist, Label and Release is set.
+type Submission struct {
+	Kind    SubmissionKind
+	Artist  *SubmittedArtist
+	Label   *LabelResponse
+	Release *ReleaseResponse
+}
+
+// UserResponse represents the profile of a user returned by the Discogs API. Email is only set for the authenticated
+// user's own profile.
+type UserResponse struct {
+	RawResponse
+	ExtraFields
+	ID                  int64      `json:"id"`
+	Username            string     `json:"username"`
+	Name                string     `json:"name"`
+	Email               string     `json:"email,omitempty"`
+	HomePage            string     `json:"home_page"`
+	Location            string     `json:"location"`
+	Profile             string     `json:"profile"`
+	CurrAbr             Currency   `json:"curr_abbr"`
+	Registered          *Timestamp `json:"registered,omitempty"`
+	Rank                float64    `json:"rank"`
+	NumCollection       int64      `json:"num_collection"`
+	NumWantlist         int64      `json:"num_wantlist"`
+	NumForSale          int64      `json:"num_for_sale"`
+	NumLists            int64      `json:"num_lists"`
+	ReleasesContributed int64      `json:"releases_contributed"`
+	ReleasesRated       int64      `json:"releases_rated"`
+	RatingAvg           float64    `json:"rating_avg"`
+	AvatarURL           string     `json:"avatar_url"`
+	BannerURL           string     `json:"banner_url"`
+	ResourceURL         string     `json:"resource_url"`
+	URI                 string     `json:"uri"`
+}
+
+// UserUpdate is a change set for the profile of a user, built by chaining its setters. Only the fields explicitly set
+// are sent, so fields the caller never touched are left unchanged; setting a field to the empty string clears it.
+// The zero value is an empty change set.
+//
+// Example:
+//
+//	update := new(discogs.UserUpdate).SetLocation("Lyon").SetHomePage("")
+//	profile, err := client.UpdateUser(ctx, "username", update)
+type UserUpdate struct {
+	fields map[string]any
+}
+
+// SetName sets the real name of the user.
+func (u *UserUpdate) SetName(name string) *UserUpdate {
+	return u.set("name", name)
+}
+
+// SetHomePage sets the website of the user.
+func (u *UserUpdate) SetHomePage(homePage string) *UserUpdate {
+	return u.set("home_page", homePage)
+}
+
+// SetLocation sets the geographical location of the user.
+func (u *UserUpdate) SetLocation(location string) *UserUpdate {
+	return u.set("location", location)
+}
+
+// SetProfile sets the biographical information of the user.
+func (u *UserUpdate) SetProfile(profile string) *UserUpdate {
+	return u.set("profile", profile)
+}
+
+// SetCurrAbr sets the currency the marketplace prices are displayed in for the user.
+func (u *UserUpdate) SetCurrAbr(currency Currency) *UserUpdate {
+	return u.set("curr_abbr", currency)
+}
+
+// Fields returns the JSON names of the fields set in the change set, in alphabetical order.
+func (u *UserUpdate) Fields() []string {
+	fields := make([]string, 0, len(u.fields))
+	for field := range u.fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// MarshalJSON encodes the fields set in the change set, and only those.
+func (u *UserUpdate) MarshalJSON() ([]byte, error) {
+	if u.fields == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(u.fields)
+}
+
+// set records the value of a field of the change set.
+func (u *UserUpdate) set(field string, value any) *UserUpdate {
+	if u.fields == nil {
+		u.fields = make(map[string]any)
+	}
+	u.fields[field] = value
+	return u
+}
+
+// IdentityResponse represents the identity of the user authenticated by the access token of the client.
+type IdentityResponse struct {
+	RawResponse
+	ExtraFields
+	ID           int64  `json:"id"`
+	Username     string `json:"username"`
+	ResourceURL  string `json:"resource_url"`
+	ConsumerName string `json:"consumer_name"`
+}
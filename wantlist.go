@@ -0,0 +1,184 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/google/go-querystring/query"
+)
+
+// Wantlist fetches a page of the releases in a user's wantlist by sending a GET request to the
+// /users/{username}/wants endpoint. Private wantlists can only be fetched by the authenticated user owning them. The
+// context.Context provides control over the request's lifecycle. It returns a pointer to a WantlistResponse struct
+// containing the wanted releases, or an error if the request fails or the user is not found.
+//
+// Documentation: https://www.discogs.com/developers#page:user-wantlist,header:user-wantlist-wantlist
+func (dc *DiscogsClient) Wantlist(ctx context.Context, username string, options *PaginationParams, opts ...RequestOption) (*WantlistResponse, error) {
+	endpoint := "/users/" + url.PathEscape(username) + "/wants"
+	var res WantlistResponse
+
+	params, err := query.Values(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dc.Get(WithRequestOptions(ctx, opts...), endpoint, params, nil, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceUser, username)
+	}
+
+	return &res, nil
+}
+
+// WantlistPages returns a PageFetcher that fetches pages of a user's wantlist, for use with NewIterator or
+// ForEachPage.
+func (dc *DiscogsClient) WantlistPages(username string) PageFetcher[WantlistEntry] {
+	return func(ctx context.Context, page PaginationParams) ([]WantlistEntry, *Pagination, error) {
+		res, err := dc.Wantlist(ctx, username, &page)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Wants, res.Pagination, nil
+	}
+}
+
+// WantlistIter returns an Iterator over all releases in a user's wantlist, fetching pages as needed. The iterOptions
+// parameter configures the iteration, such as the page size and whether to prefetch pages.
+func (dc *DiscogsClient) WantlistIter(username string, iterOptions *IteratorOptions) *Iterator[WantlistEntry] {
+	return NewIterator(dc.WantlistPages(username), iterOptions)
+}
+
+// CompareWantlistWithCollection cross-references a user's wantlist against their collection, and reports the wanted
+// releases that are already owned, and the wanted releases of which another version of the same master release is
+// owned. It pages through the whole wantlist and collection, so it costs one request per page of each.
+//
+// Only the releases of the collection visible to the client are compared: the whole collection requires the client
+// to be authenticated as the user, unless the collection is public.
+func (dc *DiscogsClient) CompareWantlistWithCollection(ctx context.Context, username string) (*WantlistComparison, error) {
+	byRelease := make(map[int64][]CollectionItem)
+	byMaster := make(map[int64][]CollectionItem)
+	err := ForEachPage(ctx, dc.CollectionItemsPages(username, FolderAll, nil), &IteratorOptions{PerPage: DefaultCrawlPerPage}, func(items []CollectionItem, _ *Pagination) error {
+		for _, item := range items {
+			byRelease[item.ID] = append(byRelease[item.ID], item)
+			if masterID := item.BasicInformation.GetMasterID(); masterID != 0 {
+				byMaster[masterID] = append(byMaster[masterID], item)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res WantlistComparison
+	err = ForEachPage(ctx, dc.WantlistPages(username), &IteratorOptions{PerPage: DefaultCrawlPerPage}, func(wants []WantlistEntry, _ *Pagination) error {
+		for _, want := range wants {
+			if owned, ok := byRelease[want.ID]; ok {
+				res.OwnedReleases = append(res.OwnedReleases, WantlistMatch{Want: want, Owned: owned})
+			} else if owned, ok := byMaster[want.BasicInformation.GetMasterID()]; ok {
+				res.OwnedVersions = append(res.OwnedVersions, WantlistMatch{Want: want, Owned: owned})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ErrBarcodeNotFound indicates that a search of a barcode found no release.
+type ErrBarcodeNotFound struct {
+	Barcode string
+}
+
+func (e *ErrBarcodeNotFound) Error() string {
+	return fmt.Sprintf("no release found for barcode %q", e.Barcode)
+}
+
+// AddToWantlist adds a release to the authenticated user's wantlist by sending a PUT request to the
+// /users/{username}/wants/{release_id} endpoint. The options parameter allows for setting notes and a rating. The
+// context.Context provides control over the request's lifecycle. It returns a pointer to a WantlistEntry struct
+// containing the added release, or an error if the request fails or the release is not found. It returns an
+// ErrInvalidOption before sending the request if the rating is not between 0 and 5.
+//
+// Documentation: https://www.discogs.com/developers#page:user-wantlist,header:user-wantlist-add-to-wantlist
+func (dc *DiscogsClient) AddToWantlist(ctx context.Context, username string, releaseID int64, options *AddToWantlistOptions, opts ...RequestOption) (*WantlistEntry, error) {
+	if options != nil && (options.Rating < 0 || options.Rating > 5) {
+		return nil, &ErrInvalidOption{Option: "rating", Value: strconv.Itoa(options.Rating)}
+	}
+
+	endpoint := "/users/" + url.PathEscape(username) + "/wants/" + strconv.FormatInt(releaseID, 10)
+	var res WantlistEntry
+
+	if err := dc.Put(WithRequestOptions(ctx, opts...), endpoint, nil, nil, options, &res); err != nil {
+		return nil, wrapNotFound(err, ResourceRelease, strconv.FormatInt(releaseID, 10))
+	}
+
+	return &res, nil
+}
+
+// ImportWantlist adds many releases to the authenticated user's wantlist concurrently, and returns the outcome of
+// every entry, in the order of entries. Entries identified by barcode are resolved with a search first. Every
+// request goes through the client, so they are spaced out by the rate limiter.
+//
+// A failed entry does not stop the others: its error is reported in its result. ImportWantlist only returns an
+// error if ctx is canceled, along with the results so far.
+func (dc *DiscogsClient) ImportWantlist(ctx context.Context, username string, entries []WantlistImportEntry) ([]WantlistImportResult, error) {
+	results := make([]WantlistImportResult, len(entries))
+	queue := make(chan int, len(entries))
+	for i := range entries {
+		queue <- i
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(batchConcurrency, len(entries)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				if ctx.Err() != nil {
+					return
+				}
+				results[i] = dc.importWant(ctx, username, entries[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+// importWant resolves the release of an entry if needed, and adds it to the wantlist.
+func (dc *DiscogsClient) importWant(ctx context.Context, username string, entry WantlistImportEntry) WantlistImportResult {
+	res := WantlistImportResult{Entry: entry, ReleaseID: entry.ReleaseID}
+
+	if res.ReleaseID == 0 {
+		if entry.Barcode == "" {
+			res.Err = &ErrInvalidOption{Option: "release_id", Value: "0"}
+			return res
+		}
+
+		found, err := dc.Search(ctx, &SearchOptions{
+			PaginationParams: PaginationParams{PerPage: Int(1)},
+			Type:             TypeRelease,
+			Barcode:          entry.Barcode,
+		})
+		if err != nil {
+			res.Err = err
+			return res
+		}
+		if len(found.Results) == 0 {
+			res.Err = &ErrBarcodeNotFound{Barcode: entry.Barcode}
+			return res
+		}
+		res.ReleaseID = found.Results[0].GetID()
+	}
+
+	res.Want, res.Err = dc.AddToWantlist(ctx, username, res.ReleaseID, &AddToWantlistOptions{Notes: entry.Notes, Rating: entry.Rating})
+	return res
+}
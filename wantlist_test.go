@@ -0,0 +1,101 @@
+package discogs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscogsClient_CompareWantlistWithCollection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/users/user/collection/folders/0/releases":
+			_, _ = rw.Write([]byte(`{"pagination":{"page":1,"pages":1},"releases":[
+				{"id":1,"instance_id":11,"basic_information":{"id":1,"master_id":100}},
+				{"id":2,"instance_id":12,"basic_information":{"id":2,"master_id":200}},
+				{"id":2,"instance_id":13,"basic_information":{"id":2,"master_id":200}}
+			]}`))
+		case "/users/user/wants":
+			_, _ = rw.Write([]byte(`{"pagination":{"page":1,"pages":1},"wants":[
+				{"id":2,"basic_information":{"id":2,"master_id":200}},
+				{"id":3,"basic_information":{"id":3,"master_id":100}},
+				{"id":4,"basic_information":{"id":4,"master_id":400}},
+				{"id":5,"basic_information":{"id":5}}
+			]}`))
+		default:
+			t.Errorf("unexpected request to %s", req.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	res, err := client.CompareWantlistWithCollection(ctx, "user")
+	assert.NoError(t, err)
+
+	if assert.Len(t, res.OwnedReleases, 1) {
+		assert.Equal(t, int64(2), res.OwnedReleases[0].Want.ID)
+		assert.Len(t, res.OwnedReleases[0].Owned, 2)
+	}
+	if assert.Len(t, res.OwnedVersions, 1) {
+		assert.Equal(t, int64(3), res.OwnedVersions[0].Want.ID)
+		assert.Equal(t, int64(11), res.OwnedVersions[0].Owned[0].InstanceID)
+	}
+}
+
+func TestDiscogsClient_ImportWantlist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/database/search":
+			if req.URL.Query().Get("barcode") == "075992435326" {
+				_, _ = rw.Write([]byte(`{"results":[{"id":3,"type":"release"}]}`))
+				return
+			}
+			_, _ = rw.Write([]byte(`{"results":[]}`))
+		case "/users/user/wants/1", "/users/user/wants/3":
+			assert.Equal(t, http.MethodPut, req.Method)
+			var options discogs.AddToWantlistOptions
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(&options))
+			_, _ = fmt.Fprintf(rw, `{"id":%s,"notes":%q}`, strings.TrimPrefix(req.URL.Path, "/users/user/wants/"), options.Notes)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"message":"Release not found."}`))
+		}
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token, ConsumerKey: &key, ConsumerSecret: &secret})
+	client.Host = server.URL
+
+	results, err := client.ImportWantlist(ctx, "user", []discogs.WantlistImportEntry{
+		{ReleaseID: 1, Notes: "first press"},
+		{Barcode: "075992435326"},
+		{ReleaseID: 2},
+		{Barcode: "000000000000"},
+		{Rating: 6, ReleaseID: 1},
+	})
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 5) {
+		return
+	}
+
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, &discogs.WantlistEntry{ID: 1, Notes: "first press"}, results[0].Want)
+
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, int64(3), results[1].ReleaseID)
+	assert.Equal(t, int64(3), results[1].Want.ID)
+
+	assert.True(t, discogs.IsNotFound(results[2].Err))
+	assert.Equal(t, &discogs.ErrBarcodeNotFound{Barcode: "000000000000"}, results[3].Err)
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "rating", Value: "6"}, results[4].Err)
+}
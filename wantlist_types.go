@@ -0,0 +1,63 @@
+package discogs
+
+// WantlistResponse represents the response from the Discogs API for a page of a user's wantlist.
+type WantlistResponse struct {
+	RawResponse
+	ExtraFields
+	Pagination *Pagination     `json:"pagination,omitempty"`
+	Wants      []WantlistEntry `json:"wants"`
+}
+
+// WantlistEntry represents a release in a user's wantlist.
+type WantlistEntry struct {
+	ID               int64             `json:"id"`
+	Rating           int64             `json:"rating"`
+	Notes            string            `json:"notes"`
+	DateAdded        *Timestamp        `json:"date_added,omitempty"`
+	BasicInformation *BasicInformation `json:"basic_information,omitempty"`
+	ResourceURL      string            `json:"resource_url"`
+}
+
+// WantlistMatch represents a release in a user's wantlist along with the matching instances of their collection.
+type WantlistMatch struct {
+	Want WantlistEntry
+	// Owned are the matching instances of the collection.
+	Owned []CollectionItem
+}
+
+// WantlistComparison represents the result of cross-referencing a user's wantlist against their collection.
+type WantlistComparison struct {
+	// OwnedReleases are the wanted releases that are already in the collection.
+	OwnedReleases []WantlistMatch
+	// OwnedVersions are the wanted releases that are not in the collection, but of whose master release another
+	// version is.
+	OwnedVersions []WantlistMatch
+}
+
+// AddToWantlistOptions represents the options for adding a release to a wantlist.
+type AddToWantlistOptions struct {
+	Notes string `json:"notes,omitempty"`
+	// Rating is the rating of the release, from 1 to 5, or 0 to leave it unrated.
+	Rating int `json:"rating,omitempty"`
+}
+
+// WantlistImportEntry represents a release to add to a wantlist with ImportWantlist. The release is identified by
+// ReleaseID, or by Barcode if ReleaseID is unset.
+type WantlistImportEntry struct {
+	ReleaseID int64
+	// Barcode is resolved to the first release found by a search of it.
+	Barcode string
+	Notes   string
+	Rating  int
+}
+
+// WantlistImportResult represents the outcome of importing a single WantlistImportEntry.
+type WantlistImportResult struct {
+	Entry WantlistImportEntry
+	// ReleaseID is the ID of the release added, or resolved from the barcode of the entry. It is 0 if the barcode could
+	// not be resolved.
+	ReleaseID int64
+	// Want is the added wantlist entry. It is nil if Err is set.
+	Want *WantlistEntry
+	Err  error
+}
@@ -0,0 +1,282 @@
+package discogs
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is the default interval between two polls of a WantlistWatcher.
+const DefaultWatchInterval = 15 * time.Minute
+
+// WantlistWatch represents a watched release along with the constraints a listing of it must meet to fire an alert.
+// Unset constraints match any listing.
+type WantlistWatch struct {
+	ReleaseID int64 `json:"release_id"`
+	// MaxPrice is the highest price, in Currency, a listing may have.
+	MaxPrice Amount `json:"max_price,omitempty"`
+	// Currency is the currency of MaxPrice. Listings priced in another currency never match if it is set.
+	Currency Currency `json:"currency,omitempty"`
+	// MinCondition is the worst media condition a listing may have, such as ConditionVeryGoodPlus.
+	MinCondition Condition `json:"min_condition,omitempty"`
+	// SellerCountries are the countries a listing may ship from.
+	SellerCountries []string `json:"seller_countries,omitempty"`
+}
+
+// Matches reports whether the listing meets all constraints of the watch. Listings with an unknown price, condition
+// or origin never match a watch constraining it.
+func (w *WantlistWatch) Matches(listing *Listing) bool {
+	if listing == nil || (listing.Release != nil && listing.Release.ID != w.ReleaseID) {
+		return false
+	}
+
+	if w.MaxPrice > 0 || w.Currency != "" {
+		if listing.Price == nil {
+			return false
+		}
+		if w.Currency != "" && listing.Price.Currency != w.Currency {
+			return false
+		}
+		if w.MaxPrice > 0 && listing.Price.Value > w.MaxPrice {
+			return false
+		}
+	}
+
+	if w.MinCondition != "" {
+		rank := listing.Condition.Rank()
+		if rank < 0 || rank < w.MinCondition.Rank() {
+			return false
+		}
+	}
+
+	if len(w.SellerCountries) > 0 && !slices.ContainsFunc(w.SellerCountries, func(country string) bool {
+		return strings.EqualFold(country, listing.ShipsFrom)
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// A WatchStore persists the watches of a WantlistWatcher. Implementations must be safe for concurrent use.
+type WatchStore interface {
+	// Watches returns all watches.
+	Watches(ctx context.Context) ([]WantlistWatch, error)
+	// SaveWatch adds a watch, replacing any existing watch of the same release.
+	SaveWatch(ctx context.Context, watch WantlistWatch) error
+	// DeleteWatch removes the watch of a release. It does nothing if the release is not watched.
+	DeleteWatch(ctx context.Context, releaseID int64) error
+}
+
+// MemoryWatchStore is a WatchStore that keeps watches in memory.
+type MemoryWatchStore struct {
+	mu      sync.Mutex
+	watches map[int64]WantlistWatch
+}
+
+// NewMemoryWatchStore creates a new empty MemoryWatchStore.
+func NewMemoryWatchStore() *MemoryWatchStore {
+	return &MemoryWatchStore{watches: make(map[int64]WantlistWatch)}
+}
+
+// Watches returns all watches, ordered by release ID.
+func (s *MemoryWatchStore) Watches(_ context.Context) ([]WantlistWatch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	watches := make([]WantlistWatch, 0, len(s.watches))
+	for _, watch := range s.watches {
+		watches = append(watches, watch)
+	}
+	sort.Slice(watches, func(i, j int) bool { return watches[i].ReleaseID < watches[j].ReleaseID })
+	return watches, nil
+}
+
+// SaveWatch adds a watch, replacing any existing watch of the same release.
+func (s *MemoryWatchStore) SaveWatch(_ context.Context, watch WantlistWatch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watches[watch.ReleaseID] = watch
+	return nil
+}
+
+// DeleteWatch removes the watch of a release.
+func (s *MemoryWatchStore) DeleteWatch(_ context.Context, releaseID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.watches, releaseID)
+	return nil
+}
+
+// A ListingSource finds the marketplace listings of a watched release. The Discogs API cannot list the listings of a
+// release, so sources typically scrape seller inventories or rely on the marketplace statistics of the release.
+type ListingSource interface {
+	Listings(ctx context.Context, watch WantlistWatch) ([]Listing, error)
+}
+
+// ListingSourceFunc is an adapter to allow the use of ordinary functions as a ListingSource.
+type ListingSourceFunc func(ctx context.Context, watch WantlistWatch) ([]Listing, error)
+
+// Listings calls f(ctx, watch).
+func (f ListingSourceFunc) Listings(ctx context.Context, watch WantlistWatch) ([]Listing, error) {
+	return f(ctx, watch)
+}
+
+// MarketplaceStatsSource returns a ListingSource based on the marketplace statistics of a release. It yields a single
+// listing carrying the lowest price of the release, in the currency of the watch. The condition and origin of that
+// listing are unknown, so it never matches watches constraining them.
+func (dc *DiscogsClient) MarketplaceStatsSource() ListingSource {
+	return ListingSourceFunc(func(ctx context.Context, watch WantlistWatch) ([]Listing, error) {
+		stats, err := dc.MarketplaceStats(ctx, watch.ReleaseID, &MarketplaceStatsOptions{CurrAbr: watch.Currency})
+		if err != nil {
+			return nil, err
+		}
+		if stats.LowestPrice == nil || stats.GetNumForSale() == 0 {
+			return nil, nil
+		}
+		return []Listing{{Price: stats.LowestPrice, Release: &ListingRelease{ID: watch.ReleaseID}}}, nil
+	})
+}
+
+// PriceAlert represents a listing matching the constraints of a watch.
+type PriceAlert struct {
+	Watch   WantlistWatch
+	Listing Listing
+}
+
+// WantlistWatcherOptions represents the options for a WantlistWatcher.
+type WantlistWatcherOptions struct {
+	// Interval is the interval between two polls. DefaultWatchInterval is used if unset.
+	Interval time.Duration
+	// Store persists the watches. A MemoryWatchStore is used if unset.
+	Store WatchStore
+	// Source finds the listings of the watched releases. The client's MarketplaceStatsSource is used if unset.
+	Source ListingSource
+	// OnAlert is called for every listing matching a watch. A listing only fires an alert the first time it matches,
+	// unless its price changes.
+	OnAlert func(alert PriceAlert)
+	// OnError is called when polling a watch fails, and polling continues with the next watch. It is called with the
+	// zero WantlistWatch if Run cannot load the watches.
+	OnError func(watch WantlistWatch, err error)
+	// Feed receives an EventPriceAlert event for every alert, after OnAlert is called.
+	Feed *EventFeed
+}
+
+// A WantlistWatcher periodically polls the listings of watched releases and fires alerts for listings matching the
+// constraints of their watch, such as a maximum price or a minimum condition. Every poll goes through the client, so
+// it respects the rate limit.
+//
+// A WantlistWatcher is safe for concurrent use.
+type WantlistWatcher struct {
+	options WantlistWatcherOptions
+
+	mu sync.Mutex
+	// alerted holds the price of every listing that fired an alert, keyed by release ID and listing ID.
+	alerted map[[2]int64]Price
+}
+
+// NewWantlistWatcher creates a new WantlistWatcher that polls listings using the DiscogsClient. If options is nil,
+// the default options are used.
+func (dc *DiscogsClient) NewWantlistWatcher(options *WantlistWatcherOptions) *WantlistWatcher {
+	w := &WantlistWatcher{alerted: make(map[[2]int64]Price)}
+	if options != nil {
+		w.options = *options
+	}
+	if w.options.Interval <= 0 {
+		w.options.Interval = DefaultWatchInterval
+	}
+	if w.options.Store == nil {
+		w.options.Store = NewMemoryWatchStore()
+	}
+	if w.options.Source == nil {
+		w.options.Source = dc.MarketplaceStatsSource()
+	}
+	return w
+}
+
+// Watch adds a watch, replacing any existing watch of the same release.
+func (w *WantlistWatcher) Watch(ctx context.Context, watch WantlistWatch) error {
+	if watch.MinCondition != "" && !watch.MinCondition.IsValid() {
+		return &ErrInvalidOption{Option: "min condition", Value: string(watch.MinCondition)}
+	}
+	return w.options.Store.SaveWatch(ctx, watch)
+}
+
+// Unwatch removes the watch of a release.
+func (w *WantlistWatcher) Unwatch(ctx context.Context, releaseID int64) error {
+	return w.options.Store.DeleteWatch(ctx, releaseID)
+}
+
+// Run polls the watched releases every Interval until ctx is canceled, and then returns the context's error.
+func (w *WantlistWatcher) Run(ctx context.Context) error {
+	return pollEvery(ctx, w.options.Interval, func(ctx context.Context) error {
+		_, err := w.Poll(ctx)
+		return err
+	}, func(err error) {
+		if w.options.OnError != nil {
+			w.options.OnError(WantlistWatch{}, err)
+		}
+	})
+}
+
+// Poll polls the listings of every watched release once, fires the alerts of new matching listings and returns
+// them. It only returns an error if the watches cannot be loaded; errors polling a single watch are passed to OnError.
+func (w *WantlistWatcher) Poll(ctx context.Context) ([]PriceAlert, error) {
+	watches, err := w.options.Store.Watches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []PriceAlert
+	for _, watch := range watches {
+		listings, err := w.options.Source.Listings(ctx, watch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return alerts, ctx.Err()
+			}
+			if w.options.OnError != nil {
+				w.options.OnError(watch, err)
+			}
+			continue
+		}
+
+		for _, listing := range listings {
+			if !watch.Matches(&listing) || !w.markAlerted(watch.ReleaseID, &listing) {
+				continue
+			}
+
+			alert := PriceAlert{Watch: watch, Listing: listing}
+			alerts = append(alerts, alert)
+			if w.options.OnAlert != nil {
+				w.options.OnAlert(alert)
+			}
+			if err := w.options.Feed.Publish(ctx, Event{Kind: EventPriceAlert, PriceAlert: &alert}); err != nil {
+				return alerts, err
+			}
+		}
+	}
+
+	return alerts, nil
+}
+
+// markAlerted records that a listing fired an alert. It returns false if the listing already fired an alert at the
+// same price.
+func (w *WantlistWatcher) markAlerted(releaseID int64, listing *Listing) bool {
+	var price Price
+	if listing.Price != nil {
+		price = *listing.Price
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := [2]int64{releaseID, listing.ID}
+	if previous, ok := w.alerted[key]; ok && previous == price {
+		return false
+	}
+	w.alerted[key] = price
+	return true
+}
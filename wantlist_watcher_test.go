@@ -0,0 +1,109 @@
+package discogs_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWantlistWatch_Matches(t *testing.T) {
+	listing := &discogs.Listing{
+		ID:        1,
+		Price:     &discogs.Price{Currency: discogs.CurrencyEUR, Value: 2500},
+		Condition: "Very Good Plus (VG+)",
+		ShipsFrom: "Germany",
+		Release:   &discogs.ListingRelease{ID: 10},
+	}
+
+	tests := []struct {
+		name    string
+		watch   discogs.WantlistWatch
+		listing *discogs.Listing
+		want    bool
+	}{
+		{"Matches without constraints", discogs.WantlistWatch{ReleaseID: 10}, listing, true},
+		{"Matches other release", discogs.WantlistWatch{ReleaseID: 11}, listing, false},
+		{"Matches all constraints", discogs.WantlistWatch{ReleaseID: 10, MaxPrice: 3000, Currency: discogs.CurrencyEUR, MinCondition: "Very Good (VG)", SellerCountries: []string{"France", "germany"}}, listing, true},
+		{"Matches price above max", discogs.WantlistWatch{ReleaseID: 10, MaxPrice: 2000}, listing, false},
+		{"Matches other currency", discogs.WantlistWatch{ReleaseID: 10, MaxPrice: 3000, Currency: discogs.CurrencyUSD}, listing, false},
+		{"Matches worse condition", discogs.WantlistWatch{ReleaseID: 10, MinCondition: "Near Mint (NM or M-)"}, listing, false},
+		{"Matches other country", discogs.WantlistWatch{ReleaseID: 10, SellerCountries: []string{"France"}}, listing, false},
+		{"Matches unknown condition", discogs.WantlistWatch{ReleaseID: 10, MinCondition: "Good (G)"}, &discogs.Listing{Release: &discogs.ListingRelease{ID: 10}}, false},
+		{"Matches unknown price", discogs.WantlistWatch{ReleaseID: 10, MaxPrice: 3000}, &discogs.Listing{Release: &discogs.ListingRelease{ID: 10}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.watch.Matches(tt.listing))
+		})
+	}
+}
+
+func TestWantlistWatcher_Poll(t *testing.T) {
+	listings := map[int64][]discogs.Listing{
+		1: {
+			{ID: 100, Price: &discogs.Price{Currency: discogs.CurrencyUSD, Value: 1000}, Condition: "Mint (M)"},
+			{ID: 101, Price: &discogs.Price{Currency: discogs.CurrencyUSD, Value: 5000}, Condition: "Mint (M)"},
+		},
+		2: {{ID: 200, Price: &discogs.Price{Currency: discogs.CurrencyUSD, Value: 500}, Condition: "Poor (P)"}},
+	}
+	source := discogs.ListingSourceFunc(func(_ context.Context, watch discogs.WantlistWatch) ([]discogs.Listing, error) {
+		if watch.ReleaseID == 3 {
+			return nil, errors.New("source unavailable")
+		}
+		return listings[watch.ReleaseID], nil
+	})
+
+	var alerted []int64
+	var failed []int64
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	watcher := client.NewWantlistWatcher(&discogs.WantlistWatcherOptions{
+		Source:  source,
+		OnAlert: func(alert discogs.PriceAlert) { alerted = append(alerted, alert.Listing.ID) },
+		OnError: func(watch discogs.WantlistWatch, _ error) { failed = append(failed, watch.ReleaseID) },
+	})
+
+	assert.NoError(t, watcher.Watch(ctx, discogs.WantlistWatch{ReleaseID: 1, MaxPrice: 2000}))
+	assert.NoError(t, watcher.Watch(ctx, discogs.WantlistWatch{ReleaseID: 2, MinCondition: "Good (G)"}))
+	assert.NoError(t, watcher.Watch(ctx, discogs.WantlistWatch{ReleaseID: 3}))
+
+	alerts, err := watcher.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, []int64{100}, alerted)
+	assert.Equal(t, []int64{3}, failed)
+
+	// Listings only alert again once their price changes
+	alerts, err = watcher.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, alerts)
+
+	listings[1][0].Price = &discogs.Price{Currency: discogs.CurrencyUSD, Value: 800}
+	assert.NoError(t, watcher.Unwatch(ctx, 3))
+	alerts, err = watcher.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, []int64{100, 100}, alerted)
+}
+
+func TestDiscogsClient_MarketplaceStatsSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/marketplace/stats/1", req.URL.Path)
+		assert.Equal(t, "EUR", req.URL.Query().Get("curr_abbr"))
+		_, _ = rw.Write([]byte(`{"lowest_price":{"currency":"EUR","value":12.5},"num_for_sale":4,"blocked_from_sale":false}`))
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{})
+	client.Host = server.URL
+
+	listings, err := client.MarketplaceStatsSource().Listings(ctx, discogs.WantlistWatch{ReleaseID: 1, Currency: discogs.CurrencyEUR})
+	assert.NoError(t, err)
+	assert.Len(t, listings, 1)
+	assert.Equal(t, &discogs.Price{Currency: discogs.CurrencyEUR, Value: 1250}, listings[0].Price)
+	assert.True(t, (&discogs.WantlistWatch{ReleaseID: 1, MaxPrice: 1500, Currency: discogs.CurrencyEUR}).Matches(&listings[0]))
+}
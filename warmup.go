@@ -0,0 +1,60 @@
+package discogs
+
+import (
+	"net/http"
+)
+
+// DefaultWarmUpResponses is the default number of successful responses after which the warm-up of a host ends.
+const DefaultWarmUpResponses = 60
+
+// warmUpStart returns the number of requests per minute a new host starts at, given the rate it is allowed. It is
+// requestsPerMinute unless WarmUpFraction is set.
+func warmUpStart(config *DiscogsConfig, requestsPerMinute int) int {
+	if config.WarmUpFraction <= 0 || config.WarmUpFraction >= 1 {
+		return requestsPerMinute
+	}
+	return max(int(float64(requestsPerMinute)*config.WarmUpFraction), adaptiveMinRate)
+}
+
+// responseHostKey returns the key identifying the host that sent res in the rate limiters of a DiscogsClient.
+func (dc *DiscogsClient) responseHostKey(res *http.Response) string {
+	if res.Request != nil {
+		return res.Request.URL.Scheme + "://" + res.Request.URL.Host
+	}
+	return hostKey(dc.Host)
+}
+
+// recordWarmUp counts a successful response towards the warm-up of the host that sent res.
+func (dc *DiscogsClient) recordWarmUp(res *http.Response) {
+	if dc.Config.WarmUpFraction <= 0 || res.StatusCode < 200 || res.StatusCode >= 300 {
+		return
+	}
+
+	key := dc.responseHostKey(res)
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	if dc.warmUp == nil {
+		dc.warmUp = make(map[string]int)
+	}
+	dc.warmUp[key]++
+}
+
+// warmUpCeiling returns the number of requests per minute allowed for the host that sent res while it warms up,
+// given the ceiling allowed by the API and MaxRequests. The rate starts at WarmUpFraction of the ceiling and ramps up
+// linearly with the successful responses of the host, reaching the ceiling after WarmUpResponses of them. The caller
+// must hold dc.mu.
+func (dc *DiscogsClient) warmUpCeiling(res *http.Response, ceiling float64) float64 {
+	start := dc.Config.WarmUpFraction
+	if start <= 0 || start >= 1 {
+		return ceiling
+	}
+
+	responses := dc.Config.WarmUpResponses
+	if responses <= 0 {
+		responses = DefaultWarmUpResponses
+	}
+
+	progress := min(float64(dc.warmUp[dc.responseHostKey(res)])/float64(responses), 1)
+	return max(ceiling*(start+(1-start)*progress), adaptiveMinRate)
+}
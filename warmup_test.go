@@ -0,0 +1,43 @@
+package discogs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestDiscogsClient_WarmUp(t *testing.T) {
+	perMinute := func(requests float64) rate.Limit {
+		return rate.Limit(requests / time.Minute.Seconds())
+	}
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set(discogs.RateLimitHeader, "600")
+		rw.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{MaxRequests: 600, WarmUpFraction: 0.5, WarmUpResponses: 4})
+	assert.InDelta(t, float64(perMinute(300)), float64(client.Limit()), 1e-9)
+
+	client.Host = server.URL
+	assert.NoError(t, client.Get(ctx, "/test", nil, nil, nil))
+	assert.InDelta(t, float64(perMinute(375)), float64(client.Limit()), 1e-9)
+
+	// Failed responses do not count towards the warm-up
+	status = http.StatusNotFound
+	assert.Error(t, client.Get(ctx, "/test", nil, nil, nil))
+	assert.InDelta(t, float64(perMinute(375)), float64(client.Limit()), 1e-9)
+
+	status = http.StatusOK
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, client.Get(ctx, "/test", nil, nil, nil))
+	}
+	assert.InDelta(t, float64(perMinute(600)), float64(client.Limit()), 1e-9)
+}
@@ -0,0 +1,271 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWriteQueueInterval is the default interval between two flushes of a WriteQueue.
+	DefaultWriteQueueInterval = time.Minute
+	// DefaultWriteQueueMaxAttempts is the default number of attempts after which a write is dropped from a WriteQueue.
+	DefaultWriteQueueMaxAttempts = 10
+)
+
+// QueuedWrite represents a POST, PUT or DELETE request waiting in a WriteQueue. It is JSON-serializable, so stores can
+// persist it as is.
+type QueuedWrite struct {
+	// ID identifies the write in the queue. It is the idempotency key of the write if one was given.
+	ID       string          `json:"id"`
+	Method   string          `json:"method"`
+	Endpoint string          `json:"endpoint"`
+	Body     json.RawMessage `json:"body,omitempty"`
+	// Attempts is the number of failed attempts to send the write.
+	Attempts   int       `json:"attempts"`
+	LastError  string    `json:"last_error,omitempty"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+}
+
+// A WriteStore persists the writes of a WriteQueue, so they survive restarts. Implementations must be safe for
+// concurrent use.
+type WriteStore interface {
+	// PendingWrites returns all writes, in the order they were appended.
+	PendingWrites(ctx context.Context) ([]QueuedWrite, error)
+	// AppendWrite adds a write at the end of the queue.
+	AppendWrite(ctx context.Context, write QueuedWrite) error
+	// UpdateWrite replaces the write with the same ID, keeping its position in the queue.
+	UpdateWrite(ctx context.Context, write QueuedWrite) error
+	// RemoveWrite removes the write with the given ID. It does nothing if there is no such write.
+	RemoveWrite(ctx context.Context, id string) error
+}
+
+// MemoryWriteStore is a WriteStore that keeps writes in memory. Writes are lost when the process exits.
+type MemoryWriteStore struct {
+	mu     sync.Mutex
+	writes []QueuedWrite
+}
+
+// NewMemoryWriteStore creates a new empty MemoryWriteStore.
+func NewMemoryWriteStore() *MemoryWriteStore {
+	return &MemoryWriteStore{}
+}
+
+// PendingWrites returns all writes, in the order they were appended.
+func (s *MemoryWriteStore) PendingWrites(_ context.Context) ([]QueuedWrite, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.writes), nil
+}
+
+// AppendWrite adds a write at the end of the queue.
+func (s *MemoryWriteStore) AppendWrite(_ context.Context, write QueuedWrite) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes = append(s.writes, write)
+	return nil
+}
+
+// UpdateWrite replaces the write with the same ID.
+func (s *MemoryWriteStore) UpdateWrite(_ context.Context, write QueuedWrite) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := slices.IndexFunc(s.writes, func(w QueuedWrite) bool { return w.ID == write.ID }); i >= 0 {
+		s.writes[i] = write
+	}
+	return nil
+}
+
+// RemoveWrite removes the write with the given ID.
+func (s *MemoryWriteStore) RemoveWrite(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes = slices.DeleteFunc(s.writes, func(w QueuedWrite) bool { return w.ID == id })
+	return nil
+}
+
+// WriteQueueOptions represents the options for a WriteQueue.
+type WriteQueueOptions struct {
+	// Interval is the interval between two flushes of Run. DefaultWriteQueueInterval is used if unset.
+	Interval time.Duration
+	// Store persists the writes. A MemoryWriteStore is used if unset.
+	Store WriteStore
+	// MaxAttempts is the number of attempts after which a write that keeps failing with a transient error is dropped,
+	// so it does not block the writes behind it forever. DefaultWriteQueueMaxAttempts is used if unset. Writes are
+	// retried until they succeed if negative.
+	MaxAttempts int
+	// OnDone is called when a write leaves the queue, with a nil error if it was sent successfully, or with the error
+	// that caused it to be dropped.
+	OnDone func(write QueuedWrite, err error)
+	// OnError is called when a flush of Run stops on a write that will be retried.
+	OnError func(err error)
+}
+
+// A WriteQueue sends POST, PUT and DELETE requests in the order they were enqueued, retrying them when they fail with
+// a transient error: a network error, a rate limit (429) or a server error (5xx). It lets applications record
+// collection, wantlist and inventory mutations while offline or once the rate limit is exhausted, and have them sent
+// later. A write failing with any other error, such as a 404, is dropped and reported to OnDone.
+//
+// To keep mutations in order, a flush stops at the first write that will be retried. The writes are persisted by the
+// WriteStore of the options, so a durable store lets pending writes survive restarts.
+//
+// Discogs does not support idempotency keys, so a write whose response was lost may be applied twice when retried.
+// Keys are used to deduplicate the queue instead: enqueuing a write with the key of a pending write does nothing.
+//
+// A WriteQueue is safe for concurrent use.
+type WriteQueue struct {
+	client  *DiscogsClient
+	options WriteQueueOptions
+
+	// flushMu serializes flushes, so writes are never sent concurrently or out of order.
+	flushMu sync.Mutex
+	// enqueueMu serializes enqueues, so duplicate keys are detected.
+	enqueueMu sync.Mutex
+}
+
+// NewWriteQueue creates a new WriteQueue that sends writes using the DiscogsClient. If options is nil, the default
+// options are used.
+func (dc *DiscogsClient) NewWriteQueue(options *WriteQueueOptions) *WriteQueue {
+	q := &WriteQueue{client: dc}
+	if options != nil {
+		q.options = *options
+	}
+	if q.options.Interval <= 0 {
+		q.options.Interval = DefaultWriteQueueInterval
+	}
+	if q.options.Store == nil {
+		q.options.Store = NewMemoryWriteStore()
+	}
+	if q.options.MaxAttempts == 0 {
+		q.options.MaxAttempts = DefaultWriteQueueMaxAttempts
+	}
+	return q
+}
+
+// Enqueue adds a write to the end of the queue and returns it. The body is encoded as JSON right away and may be nil.
+// If key is not empty and a pending write has the same key, that write is returned and nothing is enqueued. It
+// returns an ErrInvalidOption if method is not POST, PUT or DELETE.
+//
+// Example:
+//
+//	endpoint := "/users/" + username + "/collection/folders/1/releases/" + strconv.FormatInt(releaseID, 10)
+//	write, err := queue.Enqueue(ctx, http.MethodPost, endpoint, "add-"+strconv.FormatInt(releaseID, 10), nil)
+func (q *WriteQueue) Enqueue(ctx context.Context, method, endpoint, key string, body any) (*QueuedWrite, error) {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodDelete:
+	default:
+		return nil, &ErrInvalidOption{Option: "method", Value: method}
+	}
+
+	write := QueuedWrite{ID: key, Method: method, Endpoint: endpoint, EnqueuedAt: time.Now()}
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		write.Body = encoded
+	}
+
+	q.enqueueMu.Lock()
+	defer q.enqueueMu.Unlock()
+
+	pending, err := q.options.Store.PendingWrites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		if i := slices.IndexFunc(pending, func(w QueuedWrite) bool { return w.ID == key }); i >= 0 {
+			return &pending[i], nil
+		}
+	} else {
+		write.ID = newWriteID(pending)
+	}
+
+	if err := q.options.Store.AppendWrite(ctx, write); err != nil {
+		return nil, err
+	}
+	return &write, nil
+}
+
+// newWriteID returns an ID that no pending write has.
+func newWriteID(pending []QueuedWrite) string {
+	for {
+		id := "write-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+		if !slices.ContainsFunc(pending, func(w QueuedWrite) bool { return w.ID == id }) {
+			return id
+		}
+	}
+}
+
+// Run flushes the queue every Interval until ctx is canceled, and then returns the context's error.
+func (q *WriteQueue) Run(ctx context.Context) error {
+	return pollEvery(ctx, q.options.Interval, func(ctx context.Context) error {
+		_, err := q.Flush(ctx)
+		return err
+	}, q.options.OnError)
+}
+
+// Flush sends the pending writes in order and returns the number of writes that left the queue, successfully or not.
+// It stops at the first write failing with a transient error and returns that error; the write is retried by the
+// next flush.
+func (q *WriteQueue) Flush(ctx context.Context) (int, error) {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	pending, err := q.options.Store.PendingWrites(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var done int
+	for _, write := range pending {
+		var body any
+		if write.Body != nil {
+			body = write.Body
+		}
+
+		err := q.client.request(ctx, write.Method, write.Endpoint, nil, nil, body, nil)
+		if ctx.Err() != nil {
+			return done, ctx.Err()
+		}
+
+		if err != nil && isTransientWriteError(err) {
+			write.Attempts++
+			write.LastError = err.Error()
+			if q.options.MaxAttempts < 0 || write.Attempts < q.options.MaxAttempts {
+				if storeErr := q.options.Store.UpdateWrite(ctx, write); storeErr != nil {
+					return done, storeErr
+				}
+				return done, err
+			}
+		}
+
+		if err := q.options.Store.RemoveWrite(ctx, write.ID); err != nil {
+			return done, err
+		}
+		done++
+		if q.options.OnDone != nil {
+			q.options.OnDone(write, err)
+		}
+	}
+
+	return done, nil
+}
+
+// isTransientWriteError reports whether a write failing with err may succeed if retried later: a network error, such
+// as Discogs being unreachable while offline, a timeout, a rate limit (429) or a server error (5xx). Other errors,
+// such as a response that cannot be decoded, fail again on every attempt.
+func isTransientWriteError(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) || isTransientNetworkError(err)
+}
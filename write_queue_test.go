@@ -0,0 +1,123 @@
+package discogs_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteQueue_Flush(t *testing.T) {
+	var requests []string
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		requests = append(requests, req.Method+" "+req.URL.Path+" "+string(body))
+
+		switch {
+		case req.URL.Path == "/users/user/collection/folders/1/releases/404":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"message":"Release not found."}`))
+		case req.URL.Path == "/users/user" && failing:
+			failing = false
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = rw.Write([]byte(`{"message":"Service unavailable."}`))
+		default:
+			_, _ = rw.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	var done []string
+	var dropped []error
+	queue := client.NewWriteQueue(&discogs.WriteQueueOptions{
+		OnDone: func(write discogs.QueuedWrite, err error) {
+			done = append(done, write.ID)
+			if err != nil {
+				dropped = append(dropped, err)
+			}
+		},
+	})
+
+	_, err := queue.Enqueue(ctx, http.MethodPost, "/users/user/collection/folders/1/releases/404", "missing", nil)
+	assert.NoError(t, err)
+	_, err = queue.Enqueue(ctx, http.MethodPost, "/users/user", "profile", map[string]string{"location": "Lyon"})
+	assert.NoError(t, err)
+	_, err = queue.Enqueue(ctx, http.MethodPost, "/users/user/collection/folders/1/releases/1", "", nil)
+	assert.NoError(t, err)
+
+	duplicate, err := queue.Enqueue(ctx, http.MethodPost, "/users/user", "profile", map[string]string{"location": "Paris"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"location":"Lyon"}`, string(duplicate.Body), "pending writes are deduplicated by key")
+
+	_, err = queue.Enqueue(ctx, http.MethodGet, "/users/user", "", nil)
+	assert.Equal(t, &discogs.ErrInvalidOption{Option: "method", Value: http.MethodGet}, err)
+
+	// The server error stops the flush, so the last write is not sent out of order
+	n, err := queue.Flush(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"missing"}, done)
+	assert.Len(t, dropped, 1)
+
+	n, err = queue.Flush(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "profile", done[1])
+	assert.Equal(t, []string{
+		"POST /users/user/collection/folders/1/releases/404 ",
+		`POST /users/user {"location":"Lyon"}`,
+		`POST /users/user {"location":"Lyon"}`,
+		"POST /users/user/collection/folders/1/releases/1 ",
+	}, requests)
+}
+
+func TestWriteQueue_FlushTransientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+
+	token := "token"
+	client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{AccessToken: &token})
+	client.Host = server.URL
+
+	var dropped []error
+	queue := client.NewWriteQueue(&discogs.WriteQueueOptions{
+		MaxAttempts: 2,
+		OnDone: func(write discogs.QueuedWrite, err error) {
+			dropped = append(dropped, err)
+		},
+	})
+
+	// An endpoint without a route is not transient, so the write is dropped right away
+	_, err := queue.Enqueue(ctx, http.MethodPost, "/unknown/endpoint", "", nil)
+	assert.NoError(t, err)
+	n, err := queue.Flush(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	if assert.Len(t, dropped, 1) {
+		var matchNotFound *discogs.ErrMatchNotFound
+		assert.ErrorAs(t, dropped[0], &matchNotFound)
+	}
+
+	// An unreachable server is transient, so the write is retried until MaxAttempts
+	server.Close()
+	_, err = queue.Enqueue(ctx, http.MethodPost, "/users/user/collection/folders/1/releases/2", "", nil)
+	assert.NoError(t, err)
+	n, err = queue.Flush(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 0, n)
+	n, err = queue.Flush(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	if assert.Len(t, dropped, 2) {
+		assert.Error(t, dropped[1])
+	}
+}
@@ -0,0 +1,66 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// A WriteVerifier reports whether a write that failed without a usable response, such as a listing created right
+// before the connection dropped, was nevertheless applied by Discogs. It usually looks the written resource up with
+// GET requests.
+type WriteVerifier func(ctx context.Context) (applied bool, err error)
+
+// writeVerifierKey is the context key for the WriteVerifier of a write.
+type writeVerifierKey struct{}
+
+// WithWriteVerifier returns a copy of ctx allowing the POST, PUT or DELETE request made with it to be retried like a
+// GET request, see MaxRetries and MaxTransportRetries. Before every retry, verify is called: if the write was applied,
+// the call succeeds without sending it again, so it is never applied twice, and its result is left empty since the
+// response was lost. If verify fails, the call fails with both errors.
+//
+// Example, adding a want that is retried unless it is already in the wantlist:
+//
+//	verify := func(ctx context.Context) (bool, error) {
+//		wants, err := client.Wantlist(ctx, username, nil)
+//		if err != nil {
+//			return false, err
+//		}
+//		return slices.ContainsFunc(wants.Wants, func(w discogs.WantlistEntry) bool { return w.ID == releaseID }), nil
+//	}
+//	_, err := client.AddToWantlist(discogs.WithWriteVerifier(ctx, verify), username, releaseID, nil)
+func WithWriteVerifier(ctx context.Context, verify WriteVerifier) context.Context {
+	return context.WithValue(ctx, writeVerifierKey{}, verify)
+}
+
+// writeVerifier returns the WriteVerifier of a write made with ctx, or nil if there is none.
+func writeVerifier(ctx context.Context) WriteVerifier {
+	verify, _ := ctx.Value(writeVerifierKey{}).(WriteVerifier)
+	return verify
+}
+
+// isRetryable reports whether req can be sent again: it is idempotent, or it is a write whose WriteVerifier is called
+// before it is sent again.
+func isRetryable(req *http.Request) bool {
+	return isIdempotent(req.Method) || writeVerifier(req.Context()) != nil
+}
+
+// verifyWrite calls the WriteVerifier of a write that failed with err before it is retried. It returns a response to
+// the write if it was applied, or an error joining err and the error of the verifier if it failed.
+func verifyWrite(ctx context.Context, req *http.Request, err error) (*http.Response, error) {
+	verify := writeVerifier(req.Context())
+	if verify == nil || isIdempotent(req.Method) {
+		return nil, nil
+	}
+
+	applied, verifyErr := verify(ctx)
+	if verifyErr != nil {
+		return nil, errors.Join(err, fmt.Errorf("failed to verify write: %w", verifyErr))
+	}
+	if !applied {
+		return nil, nil
+	}
+	// The response was lost, so the write is answered with an empty response
+	return &http.Response{StatusCode: http.StatusNoContent, Header: make(http.Header), Request: req}, nil
+}
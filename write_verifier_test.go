@@ -0,0 +1,78 @@
+package discogs_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/couwuch/discogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithWriteVerifier(t *testing.T) {
+	verifyErr := errors.New("lookup failed")
+
+	tests := []struct {
+		name string
+		// appliedFirst reports whether the first write is applied despite failing.
+		appliedFirst bool
+		verifyErr    error
+		noVerifier   bool
+		wantPosts    int64
+		wantApplied  int64
+		wantErr      bool
+	}{
+		{name: "applied write not sent again", appliedFirst: true, wantPosts: 1, wantApplied: 1},
+		{name: "lost write sent again", wantPosts: 2, wantApplied: 1},
+		{name: "verification failed", verifyErr: verifyErr, wantPosts: 1, wantErr: true},
+		{name: "write without verifier", noVerifier: true, wantPosts: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posts, applied atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if posts.Add(1) == 1 {
+					if tt.appliedFirst {
+						applied.Add(1)
+					}
+					rw.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				applied.Add(1)
+				_, _ = rw.Write([]byte(`{"success":true}`))
+			}))
+			defer server.Close()
+
+			client := discogs.NewDiscogsClient(&discogs.DiscogsConfig{MaxRetries: 2, RetryWait: time.Millisecond})
+			client.Host = server.URL
+
+			writeCtx := ctx
+			if !tt.noVerifier {
+				writeCtx = discogs.WithWriteVerifier(ctx, func(context.Context) (bool, error) {
+					return applied.Load() > 0, tt.verifyErr
+				})
+			}
+
+			var res TestClientResponse
+			err := client.Post(writeCtx, "/test", nil, nil, nil, &res)
+			assert.Equal(t, tt.wantPosts, posts.Load())
+			assert.Equal(t, tt.wantApplied, applied.Load())
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			// The error of the write is returned, along with the error of the verifier if any
+			var httpErr *discogs.HTTPError
+			assert.ErrorAs(t, err, &httpErr)
+			if tt.verifyErr != nil {
+				assert.ErrorIs(t, err, tt.verifyErr)
+			}
+		})
+	}
+}